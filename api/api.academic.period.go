@@ -0,0 +1,197 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/gomodule/redigo/redis"
+	uuid "github.com/satori/go.uuid"
+	"net/http"
+	"school/helpers"
+	"school/models"
+	"time"
+)
+
+type (
+	AcademicPeriodModule struct {
+		db     *sql.DB
+		cache  *redis.Pool
+		name   string
+		logger *helpers.Logger
+	}
+
+	AcademicPeriodListParam struct {
+		IntakeID uuid.UUID `json:"intake_id"`
+		Type     string    `json:"type"`
+	}
+
+	AcademicPeriodAddParam struct {
+		IntakeID  uuid.UUID `json:"intake_id"`
+		Type      string    `json:"type" valid:"required"`
+		Name      string    `json:"name" valid:"required"`
+		StartDate time.Time `json:"start_date"`
+		EndDate   time.Time `json:"end_date"`
+	}
+
+	AcademicPeriodUpdateParam struct {
+		ID        uuid.UUID `json:"id"`
+		Type      string    `json:"type" valid:"required"`
+		Name      string    `json:"name" valid:"required"`
+		StartDate time.Time `json:"start_date"`
+		EndDate   time.Time `json:"end_date"`
+	}
+
+	AcademicPeriodDeleteParam struct {
+		ID uuid.UUID `json:"id"`
+	}
+)
+
+func NewAcademicPeriodModule(db *sql.DB, cache *redis.Pool, logger *helpers.Logger) *AcademicPeriodModule {
+	return &AcademicPeriodModule{
+		db:     db,
+		cache:  cache,
+		name:   "module/academic_period",
+		logger: logger,
+	}
+}
+
+func (s AcademicPeriodModule) ListByIntake(ctx context.Context, param AcademicPeriodListParam) (
+	interface{}, *helpers.Error) {
+
+	if param.Type != "" && !isAcademicPeriodType(param.Type) {
+		return nil, helpers.ErrorWrap(errors.New("Invalid Period Type"), s.name, "ListByIntake/ValidationType",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	academicPeriods, err := models.GetAllAcademicPeriodByIntake(ctx, s.db, param.IntakeID, param.Type)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "ListByIntake/GetAllAcademicPeriodByIntake",
+			helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	var academicPeriodsResponse []models.AcademicPeriodResponse
+	for _, academicPeriod := range academicPeriods {
+		academicPeriodsResponse = append(academicPeriodsResponse, academicPeriod.Response())
+	}
+
+	return academicPeriodsResponse, nil
+}
+
+func (s AcademicPeriodModule) Add(ctx context.Context, param AcademicPeriodAddParam) (interface{}, *helpers.Error) {
+
+	err := validateAcademicPeriod(param.Type, param.StartDate, param.EndDate)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Add/ValidationPeriod", helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	_, err = models.GetOneIntake(ctx, s.db, param.IntakeID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Add/GetOneIntake", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	academicPeriod := models.AcademicPeriodModel{
+		IntakeID:  param.IntakeID,
+		Type:      param.Type,
+		Name:      param.Name,
+		StartDate: param.StartDate,
+		EndDate:   param.EndDate,
+		CreatedBy: uuid.FromStringOrNil(ctx.Value("user_id").(string)),
+	}
+
+	err = academicPeriod.Insert(ctx, s.db)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Add/Insert", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	return academicPeriod.Response(), nil
+}
+
+func (s AcademicPeriodModule) Update(ctx context.Context, param AcademicPeriodUpdateParam) (
+	interface{}, *helpers.Error) {
+
+	err := validateAcademicPeriod(param.Type, param.StartDate, param.EndDate)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Update/ValidationPeriod", helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	academicPeriod := models.AcademicPeriodModel{
+		ID:        param.ID,
+		Type:      param.Type,
+		Name:      param.Name,
+		StartDate: param.StartDate,
+		EndDate:   param.EndDate,
+		UpdatedBy: uuid.NullUUID{
+			UUID:  uuid.FromStringOrNil(ctx.Value("user_id").(string)),
+			Valid: true,
+		},
+	}
+
+	err = academicPeriod.Update(ctx, s.db)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Update/Update", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	return academicPeriod.Response(), nil
+}
+
+func (s AcademicPeriodModule) Delete(ctx context.Context, param AcademicPeriodDeleteParam) (
+	interface{}, *helpers.Error) {
+
+	academicPeriod := models.AcademicPeriodModel{
+		ID: param.ID,
+		UpdatedBy: uuid.NullUUID{
+			UUID:  uuid.FromStringOrNil(ctx.Value("user_id").(string)),
+			Valid: true,
+		},
+	}
+
+	err := academicPeriod.Delete(ctx, s.db)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Delete/Delete", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	return nil, nil
+}
+
+func validateAcademicPeriod(periodType string, startDate time.Time, endDate time.Time) error {
+
+	if !isAcademicPeriodType(periodType) {
+		return errors.New("Invalid Period Type")
+	}
+
+	if startDate.IsZero() || endDate.IsZero() || endDate.Before(startDate) {
+		return errors.New("Invalid Period Dates")
+	}
+
+	return nil
+}
+
+func isAcademicPeriodType(periodType string) bool {
+
+	switch periodType {
+	case models.ACADEMIC_PERIOD_ENROLLMENT, models.ACADEMIC_PERIOD_ADD_DROP, models.ACADEMIC_PERIOD_WITHDRAW,
+		models.ACADEMIC_PERIOD_TEACHING, models.ACADEMIC_PERIOD_EXAM, models.ACADEMIC_PERIOD_HOLIDAY:
+		return true
+	}
+
+	return false
+}
+
+func isInAcademicPeriods(academicPeriods []models.AcademicPeriodModel, moment time.Time) bool {
+
+	for _, academicPeriod := range academicPeriods {
+		if academicPeriod.Contains(moment) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -27,8 +27,8 @@ type (
 	}
 
 	AdminLoginParam struct {
-		Username string `json:"username"valid:"required"`
-		Password string `json:"password"valid:"required"`
+		Username string `json:"username" valid:"required"`
+		Password string `json:"password" valid:"required"`
 	}
 
 	AdminWithSession struct {
@@ -0,0 +1,605 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/gomodule/redigo/redis"
+	uuid "github.com/satori/go.uuid"
+	"math"
+	"net/http"
+	"school/helpers"
+	"school/models"
+)
+
+const ASSESSMENT_TOTAL_WEIGHT = 100
+
+type (
+	AssessmentModule struct {
+		db     *sql.DB
+		cache  *redis.Pool
+		name   string
+		logger *helpers.Logger
+	}
+
+	AssessmentListParam struct {
+		SessionID uuid.UUID `json:"session_id"`
+	}
+
+	AssessmentAddParam struct {
+		SessionID uuid.UUID `json:"session_id"`
+		Name      string    `json:"name" valid:"required"`
+		Weight    int       `json:"weight" valid:"required"`
+		MaxMarks  int       `json:"max_marks" valid:"required"`
+	}
+
+	AssessmentUpdateParam struct {
+		ID        uuid.UUID `json:"id"`
+		SessionID uuid.UUID `json:"session_id"`
+		Name      string    `json:"name" valid:"required"`
+		Weight    int       `json:"weight" valid:"required"`
+		MaxMarks  int       `json:"max_marks" valid:"required"`
+	}
+
+	AssessmentDeleteParam struct {
+		ID        uuid.UUID `json:"id"`
+		SessionID uuid.UUID `json:"session_id"`
+	}
+
+	AssessmentScoreListParam struct {
+		ID        uuid.UUID `json:"id"`
+		SessionID uuid.UUID `json:"session_id"`
+	}
+
+	AssessmentScoreParam struct {
+		StudentEnrollID uuid.UUID `json:"student_enroll_id"`
+		Score           float64   `json:"score"`
+	}
+
+	AssessmentScoreUpdateParam struct {
+		ID        uuid.UUID              `json:"id"`
+		SessionID uuid.UUID              `json:"session_id"`
+		Scores    []AssessmentScoreParam `json:"scores"`
+	}
+
+	AssessmentListResponse struct {
+		Assessments []models.AssessmentResponse `json:"assessments"`
+		TotalWeight int                         `json:"total_weight"`
+	}
+)
+
+func NewAssessmentModule(db *sql.DB, cache *redis.Pool, logger *helpers.Logger) *AssessmentModule {
+	return &AssessmentModule{
+		db:     db,
+		cache:  cache,
+		name:   "module/assessment",
+		logger: logger,
+	}
+}
+
+func (s AssessmentModule) ListBySession(ctx context.Context, param AssessmentListParam) (interface{}, *helpers.Error) {
+
+	_, errSession := checkSessionLecturer(ctx, s.db, s.name, param.SessionID)
+	if errSession != nil {
+		return nil, errSession
+	}
+
+	assessments, err := models.GetAllAssessmentBySession(ctx, s.db, param.SessionID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "ListBySession/GetAllAssessmentBySession",
+			helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	var response AssessmentListResponse
+	for _, assessment := range assessments {
+		response.Assessments = append(response.Assessments, assessment.Response())
+		response.TotalWeight += assessment.Weight
+	}
+
+	return response, nil
+}
+
+func (s AssessmentModule) Add(ctx context.Context, param AssessmentAddParam) (interface{}, *helpers.Error) {
+
+	_, errSession := checkSessionLecturer(ctx, s.db, s.name, param.SessionID)
+	if errSession != nil {
+		return nil, errSession
+	}
+
+	errEditable := checkSessionResultEditable(ctx, s.db, s.name, param.SessionID)
+	if errEditable != nil {
+		return nil, errEditable
+	}
+
+	if param.Weight <= 0 || param.MaxMarks <= 0 {
+		return nil, helpers.ErrorWrap(errors.New("Weight And Maximum Marks Must Be Positive"), s.name,
+			"Add/ValidationWeight",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	totalWeight, err := models.GetTotalAssessmentWeightBySession(ctx, s.db, param.SessionID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Add/GetTotalAssessmentWeightBySession", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	if totalWeight+param.Weight > ASSESSMENT_TOTAL_WEIGHT {
+		return nil, helpers.ErrorWrap(errors.New("Assessment Weights Exceed 100"), s.name,
+			"Add/ValidationTotalWeight",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	assessment := models.AssessmentModel{
+		SessionID: param.SessionID,
+		Name:      param.Name,
+		Weight:    param.Weight,
+		MaxMarks:  param.MaxMarks,
+		CreatedBy: uuid.FromStringOrNil(ctx.Value("user_id").(string)),
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Add/BeginTx", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+	defer tx.Rollback()
+
+	err = assessment.Insert(ctx, tx)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Add/Insert", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	err = syncSessionResults(ctx, s.db, tx, param.SessionID, totalWeight, totalWeight+param.Weight)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Add/syncSessionResults", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Add/Commit", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	return assessment.Response(), nil
+}
+
+func (s AssessmentModule) Update(ctx context.Context, param AssessmentUpdateParam) (interface{}, *helpers.Error) {
+
+	_, errSession := checkSessionLecturer(ctx, s.db, s.name, param.SessionID)
+	if errSession != nil {
+		return nil, errSession
+	}
+
+	errEditable := checkSessionResultEditable(ctx, s.db, s.name, param.SessionID)
+	if errEditable != nil {
+		return nil, errEditable
+	}
+
+	if param.Weight <= 0 || param.MaxMarks <= 0 {
+		return nil, helpers.ErrorWrap(errors.New("Weight And Maximum Marks Must Be Positive"), s.name,
+			"Update/ValidationWeight",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	current, err := models.GetOneAssessment(ctx, s.db, param.ID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Update/GetOneAssessment", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	if current.SessionID != param.SessionID {
+		return nil, helpers.ErrorWrap(errors.New("Assessment Does Not Belong To Session"), s.name,
+			"Update/ValidationSession",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	totalWeight, err := models.GetTotalAssessmentWeightBySession(ctx, s.db, param.SessionID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Update/GetTotalAssessmentWeightBySession",
+			helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	previousWeight := totalWeight
+	totalWeight = totalWeight - current.Weight + param.Weight
+	if totalWeight > ASSESSMENT_TOTAL_WEIGHT {
+		return nil, helpers.ErrorWrap(errors.New("Assessment Weights Exceed 100"), s.name,
+			"Update/ValidationTotalWeight",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	assessment := models.AssessmentModel{
+		ID:       param.ID,
+		Name:     param.Name,
+		Weight:   param.Weight,
+		MaxMarks: param.MaxMarks,
+		UpdatedBy: uuid.NullUUID{
+			UUID:  uuid.FromStringOrNil(ctx.Value("user_id").(string)),
+			Valid: true,
+		},
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Update/BeginTx", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+	defer tx.Rollback()
+
+	err = assessment.Update(ctx, tx)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Update/Update", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	err = syncSessionResults(ctx, s.db, tx, param.SessionID, previousWeight, totalWeight)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Update/syncSessionResults", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Update/Commit", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	return assessment.Response(), nil
+}
+
+func (s AssessmentModule) Delete(ctx context.Context, param AssessmentDeleteParam) (interface{}, *helpers.Error) {
+
+	_, errSession := checkSessionLecturer(ctx, s.db, s.name, param.SessionID)
+	if errSession != nil {
+		return nil, errSession
+	}
+
+	errEditable := checkSessionResultEditable(ctx, s.db, s.name, param.SessionID)
+	if errEditable != nil {
+		return nil, errEditable
+	}
+
+	current, err := models.GetOneAssessment(ctx, s.db, param.ID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Delete/GetOneAssessment", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	if current.SessionID != param.SessionID {
+		return nil, helpers.ErrorWrap(errors.New("Assessment Does Not Belong To Session"), s.name,
+			"Delete/ValidationSession",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	totalWeight, err := models.GetTotalAssessmentWeightBySession(ctx, s.db, param.SessionID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Delete/GetTotalAssessmentWeightBySession",
+			helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	updatedBy := uuid.NullUUID{
+		UUID:  uuid.FromStringOrNil(ctx.Value("user_id").(string)),
+		Valid: true,
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Delete/BeginTx", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+	defer tx.Rollback()
+
+	assessment := models.AssessmentModel{
+		ID:        param.ID,
+		UpdatedBy: updatedBy,
+	}
+
+	err = assessment.Delete(ctx, tx)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Delete/Delete", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	err = models.DeleteAssessmentScoreByAssessment(ctx, tx, param.ID, updatedBy)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Delete/DeleteAssessmentScoreByAssessment",
+			helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	err = syncSessionResults(ctx, s.db, tx, param.SessionID, totalWeight, totalWeight-current.Weight)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Delete/syncSessionResults", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Delete/Commit", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	return nil, nil
+}
+
+func (s AssessmentModule) ListScore(ctx context.Context, param AssessmentScoreListParam) (
+	interface{}, *helpers.Error) {
+
+	_, errSession := checkSessionLecturer(ctx, s.db, s.name, param.SessionID)
+	if errSession != nil {
+		return nil, errSession
+	}
+
+	assessment, err := models.GetOneAssessment(ctx, s.db, param.ID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "ListScore/GetOneAssessment", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	if assessment.SessionID != param.SessionID {
+		return nil, helpers.ErrorWrap(errors.New("Assessment Does Not Belong To Session"), s.name,
+			"ListScore/ValidationSession",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	scores, err := models.GetAllAssessmentScoreByAssessment(ctx, s.db, param.ID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "ListScore/GetAllAssessmentScoreByAssessment",
+			helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	var scoresResponse []models.AssessmentScoreResponse
+	for _, score := range scores {
+		response, err := score.Response(ctx, s.db, s.logger)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "ListScore/AssessmentScoreResponse",
+				helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+		scoresResponse = append(scoresResponse, response)
+	}
+
+	return scoresResponse, nil
+}
+
+// UpdateScore records the component scores of the listed students and re-derives their results.
+func (s AssessmentModule) UpdateScore(ctx context.Context, param AssessmentScoreUpdateParam) (
+	interface{}, *helpers.Error) {
+
+	_, errSession := checkSessionLecturer(ctx, s.db, s.name, param.SessionID)
+	if errSession != nil {
+		return nil, errSession
+	}
+
+	errEditable := checkSessionResultEditable(ctx, s.db, s.name, param.SessionID)
+	if errEditable != nil {
+		return nil, errEditable
+	}
+
+	assessment, err := models.GetOneAssessment(ctx, s.db, param.ID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "UpdateScore/GetOneAssessment", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	if assessment.SessionID != param.SessionID {
+		return nil, helpers.ErrorWrap(errors.New("Assessment Does Not Belong To Session"), s.name,
+			"UpdateScore/ValidationSession",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	totalWeight, err := models.GetTotalAssessmentWeightBySession(ctx, s.db, param.SessionID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "UpdateScore/GetTotalAssessmentWeightBySession",
+			helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	if totalWeight != ASSESSMENT_TOTAL_WEIGHT {
+		return nil, helpers.ErrorWrap(errors.New("Assessment Weights Must Sum To 100"), s.name,
+			"UpdateScore/ValidationTotalWeight",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	for _, score := range param.Scores {
+		if score.Score < 0 || score.Score > float64(assessment.MaxMarks) {
+			return nil, helpers.ErrorWrap(errors.New("Score Out Of Range"), s.name, "UpdateScore/ValidationScore",
+				helpers.BadRequestMessage,
+				http.StatusBadRequest)
+		}
+
+		studentEnroll, err := models.GetOneStudentEnroll(ctx, s.db, score.StudentEnrollID)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "UpdateScore/GetOneStudentEnroll", helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+
+		if studentEnroll.SessionID != param.SessionID {
+			return nil, helpers.ErrorWrap(errors.New("Student Enroll Does Not Belong To Session"), s.name,
+				"UpdateScore/ValidationStudentEnroll",
+				helpers.BadRequestMessage,
+				http.StatusBadRequest)
+		}
+	}
+
+	userID := uuid.FromStringOrNil(ctx.Value("user_id").(string))
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "UpdateScore/BeginTx", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+	defer tx.Rollback()
+
+	for _, entry := range param.Scores {
+		score, err := models.GetOneAssessmentScoreByStudentEnroll(ctx, tx, assessment.ID, entry.StudentEnrollID)
+		if err != nil && err != sql.ErrNoRows {
+			return nil, helpers.ErrorWrap(err, s.name, "UpdateScore/GetOneAssessmentScoreByStudentEnroll",
+				helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+
+		if err == sql.ErrNoRows {
+			score = models.AssessmentScoreModel{
+				AssessmentID:    assessment.ID,
+				StudentEnrollID: entry.StudentEnrollID,
+				Score:           entry.Score,
+				CreatedBy:       userID,
+			}
+			err = score.Insert(ctx, tx)
+		} else {
+			score.Score = entry.Score
+			score.UpdatedBy = uuid.NullUUID{
+				UUID:  userID,
+				Valid: true,
+			}
+			err = score.Update(ctx, tx)
+		}
+
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "UpdateScore/SaveScore", helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+
+		err = deriveResult(ctx, s.db, tx, assessment.SessionID, entry.StudentEnrollID)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "UpdateScore/deriveResult", helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "UpdateScore/Commit", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	return s.ListScore(ctx, AssessmentScoreListParam{
+		ID:        param.ID,
+		SessionID: param.SessionID,
+	})
+}
+
+// deriveResult sets result.marks of the enrollment to its weighted assessment total and re-grades it. A result
+// revised by an approved appeal keeps the appealed marks.
+// Enrollments without any score are left ungraded.
+func deriveResult(ctx context.Context, db *sql.DB, tx *sql.Tx, sessionID uuid.UUID, studentEnrollID uuid.UUID) error {
+
+	marks, count, err := models.GetWeightedMarksByStudentEnroll(ctx, tx, studentEnrollID)
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return nil
+	}
+
+	results, err := models.GetAllResultByStudentEnroll(ctx, db, helpers.Filter{
+		FilterOption: helpers.FilterOption{
+			Limit:  999,
+			Offset: 0,
+		},
+		StudentEnrollID: studentEnrollID,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		if result.IsDelete || result.Grade == models.RESULT_GRADE_WITHDRAWN {
+			continue
+		}
+
+		appeals, err := models.GetCountResultAppealByResult(ctx, tx, result.ID, models.RESULT_APPEAL_APPROVED)
+		if err != nil {
+			return err
+		}
+
+		if appeals > 0 {
+			continue
+		}
+
+		result.Marks = int(math.Round(marks))
+		result.UpdatedBy = uuid.NullUUID{
+			UUID:  uuid.FromStringOrNil(ctx.Value("user_id").(string)),
+			Valid: true,
+		}
+
+		err = gradeResult(ctx, db, sessionID, &result)
+		if err != nil {
+			return err
+		}
+
+		err = result.DerivedUpdate(ctx, tx)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func deriveSessionResults(ctx context.Context, db *sql.DB, tx *sql.Tx, sessionID uuid.UUID) error {
+
+	studentEnrolls, err := models.GetAllStudentEnrollBySession(ctx, db, helpers.Filter{
+		FilterOption: helpers.FilterOption{
+			Limit:  999,
+			Offset: 0,
+		},
+		SessionID: sessionID,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	for _, studentEnroll := range studentEnrolls {
+		if studentEnroll.IsDelete {
+			continue
+		}
+
+		err = deriveResult(ctx, db, tx, sessionID, studentEnroll.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// syncSessionResults brings the session's results in line with a change of its assessment weights from
+// previousWeight to totalWeight. Once the weights total ASSESSMENT_TOTAL_WEIGHT the results are derived again
+// from the scores, and when they stop doing so the derived results are cleared, so none can be submitted with
+// the marks of a removed component. A change that leaves the weights short of the total both before and after,
+// such as a rename, leaves the results alone.
+func syncSessionResults(ctx context.Context, db *sql.DB, tx *sql.Tx, sessionID uuid.UUID, previousWeight int,
+	totalWeight int) error {
+
+	if totalWeight == ASSESSMENT_TOTAL_WEIGHT {
+		return deriveSessionResults(ctx, db, tx, sessionID)
+	}
+
+	if previousWeight != ASSESSMENT_TOTAL_WEIGHT {
+		return nil
+	}
+
+	return models.ResetDerivedResultBySession(ctx, tx, sessionID, uuid.NullUUID{
+		UUID:  uuid.FromStringOrNil(ctx.Value("user_id").(string)),
+		Valid: true,
+	})
+}
@@ -0,0 +1,200 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/gomodule/redigo/redis"
+	uuid "github.com/satori/go.uuid"
+	"github.com/spf13/viper"
+	"net/http"
+	"school/helpers"
+	"school/models"
+	"school/util"
+	"strings"
+	"time"
+)
+
+// CHECK_IN_CODE_TTL is used when attendance.check_in_code_ttl (seconds) is not configured.
+const CHECK_IN_CODE_TTL = 60
+
+type (
+	AttendanceCheckInOpenParam struct {
+		ClassID uuid.UUID `json:"class_id"`
+	}
+
+	AttendanceCheckInOpenResponse struct {
+		ClassID   uuid.UUID `json:"class_id"`
+		Code      string    `json:"code"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+
+	AttendanceCheckInParam struct {
+		ClassID uuid.UUID `json:"class_id"`
+		Code    string    `json:"code" valid:"required"`
+	}
+)
+
+// OpenCheckIn issues a new check-in code for the class. Every call rotates the code, so the previous one
+// stops working immediately.
+func (s AttendanceModule) OpenCheckIn(ctx context.Context, param AttendanceCheckInOpenParam) (
+	interface{}, *helpers.Error) {
+
+	class, err := models.GetOneClass(ctx, s.db, param.ClassID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "OpenCheckIn/GetOneClass", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	session, errSession := checkClassLecturer(ctx, s.db, s.name, class)
+	if errSession != nil {
+		return nil, errSession
+	}
+
+	if isClassCancelled(class) {
+		return nil, helpers.ErrorWrap(errors.New("Class Has Been Cancelled"), s.name,
+			"OpenCheckIn/ValidationStatus",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	_, end := classWindow(class, session)
+	if time.Now().After(end) {
+		return nil, helpers.ErrorWrap(errors.New("Class Has Ended"), s.name, "OpenCheckIn/ValidationWindow",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	ttl := viper.GetInt("attendance.check_in_code_ttl")
+	if ttl <= 0 {
+		ttl = CHECK_IN_CODE_TTL
+	}
+
+	code := strings.ToUpper(util.RandomString(6))
+
+	err = helpers.SetDataToCacheWithExpiry(ctx, checkInCodeKey(class.ID), code, ttl)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "OpenCheckIn/SetDataToCacheWithExpiry",
+			helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	return AttendanceCheckInOpenResponse{
+		ClassID:   class.ID,
+		Code:      code,
+		ExpiresAt: time.Now().Add(time.Duration(ttl) * time.Second),
+	}, nil
+}
+
+// CheckIn marks the student present when the code matches the class's current code and the class is running.
+func (s AttendanceModule) CheckIn(ctx context.Context, param AttendanceCheckInParam) (interface{}, *helpers.Error) {
+
+	studentID := uuid.FromStringOrNil(ctx.Value("user_id").(string))
+
+	class, err := models.GetOneClass(ctx, s.db, param.ClassID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "CheckIn/GetOneClass", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	session, err := models.GetOneSession(ctx, s.db, class.SessionID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "CheckIn/GetOneSession", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	now := time.Now()
+	start, end := classWindow(class, session)
+	if isClassCancelled(class) || now.Before(start) || now.After(end) {
+		return nil, helpers.ErrorWrap(errors.New("Check-In Is Only Open During Class"), s.name,
+			"CheckIn/ValidationWindow",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	attendance, err := models.GetOneAttendanceByClassAndStudent(ctx, s.db, class.ID, studentID)
+	if err == sql.ErrNoRows {
+		return nil, helpers.ErrorWrap(errors.New("Student Not In Class"), s.name, "CheckIn/ValidationStudent",
+			helpers.ForbiddenMessage,
+			http.StatusForbidden)
+	}
+
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "CheckIn/GetOneAttendanceByClassAndStudent",
+			helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	code, err := helpers.GetDataFromCache(ctx, checkInCodeKey(class.ID))
+	if err != nil && err != redis.ErrNil {
+		return nil, helpers.ErrorWrap(err, s.name, "CheckIn/GetDataFromCache", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	if err == redis.ErrNil || code != strings.ToUpper(strings.TrimSpace(param.Code)) {
+		return nil, helpers.ErrorWrap(errors.New("Invalid Or Expired Code"), s.name, "CheckIn/ValidationCode",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	usedKey := fmt.Sprintf("%s:%s:%s", checkInCodeKey(class.ID), code, studentID)
+
+	_, err = helpers.GetDataFromCache(ctx, usedKey)
+	if err == nil {
+		return nil, helpers.ErrorWrap(errors.New("Code Already Used"), s.name, "CheckIn/ValidationUsed",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	if err != redis.ErrNil {
+		return nil, helpers.ErrorWrap(err, s.name, "CheckIn/GetDataFromCache", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	attendance.IsAttend = true
+	attendance.Status = models.ATTENDANCE_PRESENT
+	attendance.Reason = ""
+	attendance.UpdatedBy = uuid.NullUUID{
+		UUID:  studentID,
+		Valid: true,
+	}
+
+	err = attendance.Update(ctx, s.db)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "CheckIn/Update", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	err = helpers.SetDataToCacheWithExpiry(ctx, usedKey, attendance.ID.String(), int(end.Sub(now).Seconds())+1)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "CheckIn/SetDataToCacheWithExpiry", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	response, err := attendance.Response(ctx, s.db, s.logger)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "CheckIn/AttendanceResponse", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	return response, nil
+}
+
+func checkInCodeKey(classID uuid.UUID) string {
+	return fmt.Sprintf("class_check_in:%s", classID)
+}
+
+// classWindow combines the class date with the session's start and end time.
+func classWindow(class models.ClassModel, session models.SessionModel) (time.Time, time.Time) {
+
+	year, month, day := class.Date.Date()
+	location := class.Date.Location()
+
+	start := time.Date(year, month, day,
+		session.StartTime.Hour(), session.StartTime.Minute(), session.StartTime.Second(), 0, location)
+	end := time.Date(year, month, day,
+		session.EndTime.Hour(), session.EndTime.Minute(), session.EndTime.Second(), 0, location)
+
+	return start, end
+}
@@ -3,13 +3,20 @@ package api
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"github.com/gomodule/redigo/redis"
 	uuid "github.com/satori/go.uuid"
+	"github.com/spf13/viper"
 	"net/http"
 	"school/helpers"
 	"school/models"
+	"strings"
+	"time"
 )
 
+// ATTENDANCE_LOCK_DAYS is used when attendance.lock_days is not configured.
+const ATTENDANCE_LOCK_DAYS = 7
+
 type (
 	AttendanceModule struct {
 		db     *sql.DB
@@ -30,6 +37,21 @@ type (
 	AttendanceUpdateParam struct {
 		ID       uuid.UUID `json:"id"`
 		IsAttend bool      `json:"is_attend"`
+		Status   string    `json:"status"`
+		Reason   string    `json:"reason"`
+	}
+
+	AttendanceStudentParam struct {
+		StudentID uuid.UUID `json:"student_id"`
+		Status    string    `json:"status"`
+		Reason    string    `json:"reason"`
+	}
+
+	AttendanceUpdateByClassParam struct {
+		ClassID     uuid.UUID                `json:"class_id"`
+		Status      string                   `json:"status"`
+		Reason      string                   `json:"reason"`
+		Attendances []AttendanceStudentParam `json:"attendances"`
 	}
 
 	AttendanceListByClassParam struct {
@@ -110,16 +132,43 @@ func (s AttendanceModule) Add(ctx context.Context, param AttendanceAddParam) (in
 
 func (s AttendanceModule) Update(ctx context.Context, param AttendanceUpdateParam) (interface{}, *helpers.Error) {
 
+	// Clients sending only is_attend keep working as present/absent.
+	if param.Status == "" {
+		param.Status = models.ATTENDANCE_ABSENT
+		if param.IsAttend {
+			param.Status = models.ATTENDANCE_PRESENT
+		}
+	}
+
+	err := validateAttendanceStatus(param.Status, param.Reason)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Update/ValidationStatus", helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	current, err := models.GetOneAttendance(ctx, s.db, param.ID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Update/GetOneAttendance", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	_, errClass := checkAttendanceEditable(ctx, s.db, s.name, current.ClassID)
+	if errClass != nil {
+		return nil, errClass
+	}
+
 	attendance := models.AttendanceModel{
 		ID:       param.ID,
-		IsAttend: param.IsAttend,
+		IsAttend: isAttend(param.Status),
+		Status:   param.Status,
+		Reason:   param.Reason,
 		UpdatedBy: uuid.NullUUID{
 			UUID:  uuid.FromStringOrNil(ctx.Value("user_id").(string)),
 			Valid: true,
 		},
 	}
 
-	err := attendance.Update(ctx, s.db)
+	err = attendance.Update(ctx, s.db)
 	if err != nil {
 		return nil, helpers.ErrorWrap(err, s.name, "Update/Update", helpers.InternalServerError,
 			http.StatusInternalServerError)
@@ -135,6 +184,128 @@ func (s AttendanceModule) Update(ctx context.Context, param AttendanceUpdatePara
 
 }
 
+// UpdateByClass marks the whole class at once. Status applies to every student not listed in Attendances;
+// all rows are written in one transaction.
+func (s AttendanceModule) UpdateByClass(ctx context.Context, param AttendanceUpdateByClassParam) (
+	interface{}, *helpers.Error) {
+
+	if param.Status == "" && len(param.Attendances) == 0 {
+		return nil, helpers.ErrorWrap(errors.New("Status Or Attendances Is Required"), s.name,
+			"UpdateByClass/ValidationParam",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	if param.Status != "" {
+		err := validateAttendanceStatus(param.Status, param.Reason)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "UpdateByClass/ValidationStatus", helpers.BadRequestMessage,
+				http.StatusBadRequest)
+		}
+	}
+
+	byStudent := make(map[uuid.UUID]AttendanceStudentParam)
+	for _, attendance := range param.Attendances {
+		err := validateAttendanceStatus(attendance.Status, attendance.Reason)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "UpdateByClass/ValidationAttendance",
+				helpers.BadRequestMessage,
+				http.StatusBadRequest)
+		}
+
+		byStudent[attendance.StudentID] = attendance
+	}
+
+	_, errClass := checkAttendanceEditable(ctx, s.db, s.name, param.ClassID)
+	if errClass != nil {
+		return nil, errClass
+	}
+
+	attendances, err := models.GetAllAttendanceByClass(ctx, s.db, helpers.Filter{
+		FilterOption: helpers.FilterOption{
+			Limit:  999,
+			Offset: 0,
+		},
+		ClassID: param.ClassID,
+	})
+
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "UpdateByClass/GetAllAttendanceByClass",
+			helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	found := make(map[uuid.UUID]bool)
+	for _, attendance := range attendances {
+		found[attendance.StudentID] = true
+	}
+
+	for studentID := range byStudent {
+		if !found[studentID] {
+			return nil, helpers.ErrorWrap(errors.New("Student Not In Class"), s.name,
+				"UpdateByClass/ValidationStudent",
+				helpers.BadRequestMessage,
+				http.StatusBadRequest)
+		}
+	}
+
+	updatedBy := uuid.NullUUID{
+		UUID:  uuid.FromStringOrNil(ctx.Value("user_id").(string)),
+		Valid: true,
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "UpdateByClass/BeginTx", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+	defer tx.Rollback()
+
+	var updated []models.AttendanceModel
+	for _, attendance := range attendances {
+		status, reason := param.Status, param.Reason
+		if override, ok := byStudent[attendance.StudentID]; ok {
+			status, reason = override.Status, override.Reason
+		}
+
+		if status == "" {
+			continue
+		}
+
+		attendance.IsAttend = isAttend(status)
+		attendance.Status = status
+		attendance.Reason = reason
+		attendance.UpdatedBy = updatedBy
+
+		err = attendance.Update(ctx, tx)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "UpdateByClass/Update", helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+
+		updated = append(updated, attendance)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "UpdateByClass/Commit", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	var attendancesResponse []models.AttendanceResponse
+	for _, attendance := range updated {
+		response, err := attendance.Response(ctx, s.db, s.logger)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "UpdateByClass/AttendanceResponse",
+				helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+		attendancesResponse = append(attendancesResponse, response)
+	}
+
+	return attendancesResponse, nil
+}
+
 func (s AttendanceModule) ListByClass(ctx context.Context, filter helpers.Filter, param AttendanceListByClassParam) (
 	interface{}, *helpers.Error) {
 
@@ -163,3 +334,69 @@ func (s AttendanceModule) ListByClass(ctx context.Context, filter helpers.Filter
 
 	return attendancesResponse, nil
 }
+
+func isAttend(status string) bool {
+	return status == models.ATTENDANCE_PRESENT || status == models.ATTENDANCE_LATE
+}
+
+func validateAttendanceStatus(status string, reason string) error {
+
+	switch status {
+	case models.ATTENDANCE_PRESENT, models.ATTENDANCE_ABSENT, models.ATTENDANCE_LATE:
+		return nil
+	case models.ATTENDANCE_EXCUSED:
+		if strings.TrimSpace(reason) == "" {
+			return errors.New("Reason Is Required For Excused Attendance")
+		}
+		return nil
+	}
+
+	return errors.New("Status Must Be present, absent, late Or excused")
+}
+
+// checkAttendanceEditable makes sure the lecturer teaches the class and it is neither in the future nor
+// older than attendance.lock_days.
+func checkAttendanceEditable(ctx context.Context, db *sql.DB, name string, classID uuid.UUID) (
+	models.ClassModel, *helpers.Error) {
+
+	class, err := models.GetOneClass(ctx, db, classID)
+	if err != nil {
+		return models.ClassModel{}, helpers.ErrorWrap(err, name, "CheckAttendanceEditable/GetOneClass",
+			helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	_, errSession := checkClassLecturer(ctx, db, name, class)
+	if errSession != nil {
+		return models.ClassModel{}, errSession
+	}
+
+	if isClassCancelled(class) {
+		return models.ClassModel{}, helpers.ErrorWrap(errors.New("Class Has Been Cancelled"), name,
+			"CheckAttendanceEditable/ValidationStatus",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	lockDays := viper.GetInt("attendance.lock_days")
+	if lockDays <= 0 {
+		lockDays = ATTENDANCE_LOCK_DAYS
+	}
+
+	now := time.Now()
+	if class.Date.After(now) {
+		return models.ClassModel{}, helpers.ErrorWrap(errors.New("Class Has Not Started"), name,
+			"CheckAttendanceEditable/ValidationFuture",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	if now.After(class.Date.AddDate(0, 0, lockDays)) {
+		return models.ClassModel{}, helpers.ErrorWrap(errors.New("Attendance Is Locked"), name,
+			"CheckAttendanceEditable/ValidationLock",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	return class, nil
+}
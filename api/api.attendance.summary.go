@@ -0,0 +1,119 @@
+package api
+
+import (
+	"context"
+	"errors"
+	uuid "github.com/satori/go.uuid"
+	"github.com/spf13/viper"
+	"net/http"
+	"school/helpers"
+	"school/models"
+)
+
+// ATTENDANCE_THRESHOLD is used when attendance.threshold is not configured.
+const ATTENDANCE_THRESHOLD = 80
+
+type (
+	AttendanceSummaryBySessionParam struct {
+		SessionID uuid.UUID `json:"session_id"`
+	}
+
+	AttendanceBelowThresholdParam struct {
+		IntakeID uuid.UUID `json:"intake_id"`
+	}
+
+	AttendanceSummaryListResponse struct {
+		Threshold float64                            `json:"threshold"`
+		Summaries []models.AttendanceSummaryResponse `json:"summaries"`
+	}
+)
+
+func (s AttendanceModule) SummaryBySession(ctx context.Context, param AttendanceSummaryBySessionParam) (
+	interface{}, *helpers.Error) {
+
+	_, errSession := checkSessionLecturer(ctx, s.db, s.name, param.SessionID)
+	if errSession != nil {
+		return nil, errSession
+	}
+
+	summaries, err := models.GetAllAttendanceSummaryBySession(ctx, s.db, param.SessionID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "SummaryBySession/GetAllAttendanceSummaryBySession",
+			helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	return s.summaryResponse(ctx, "SummaryBySession", summaries, false)
+}
+
+func (s AttendanceModule) SummaryByOneStudent(ctx context.Context) (interface{}, *helpers.Error) {
+
+	studentID := uuid.FromStringOrNil(ctx.Value("user_id").(string))
+
+	summaries, err := models.GetAllAttendanceSummaryByStudent(ctx, s.db, studentID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "SummaryByOneStudent/GetAllAttendanceSummaryByStudent",
+			helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	return s.summaryResponse(ctx, "SummaryByOneStudent", summaries, false)
+}
+
+// ListBelowThreshold reports every student of the intake whose attendance in a session is below
+// attendance.threshold percent.
+func (s AttendanceModule) ListBelowThreshold(ctx context.Context, param AttendanceBelowThresholdParam) (
+	interface{}, *helpers.Error) {
+
+	if param.IntakeID == uuid.Nil {
+		return nil, helpers.ErrorWrap(errors.New("Intake Is Required"), s.name, "ListBelowThreshold/ValidationIntake",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	summaries, err := models.GetAllAttendanceSummaryByIntake(ctx, s.db, param.IntakeID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "ListBelowThreshold/GetAllAttendanceSummaryByIntake",
+			helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	return s.summaryResponse(ctx, "ListBelowThreshold", summaries, true)
+}
+
+func (s AttendanceModule) summaryResponse(ctx context.Context, step string, summaries []models.AttendanceSummaryModel,
+	belowOnly bool) (interface{}, *helpers.Error) {
+
+	threshold := attendanceThreshold()
+
+	response := AttendanceSummaryListResponse{
+		Threshold: threshold,
+	}
+
+	for _, summary := range summaries {
+		if belowOnly && summary.Percentage() >= threshold {
+			continue
+		}
+
+		summaryResponse, err := summary.Response(ctx, s.db, s.logger)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, step+"/AttendanceSummaryResponse",
+				helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+
+		response.Summaries = append(response.Summaries, summaryResponse)
+	}
+
+	return response, nil
+}
+
+func attendanceThreshold() float64 {
+
+	threshold := viper.GetFloat64("attendance.threshold")
+	if threshold <= 0 {
+		threshold = ATTENDANCE_THRESHOLD
+	}
+
+	return threshold
+}
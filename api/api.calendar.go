@@ -0,0 +1,388 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/gomodule/redigo/redis"
+	uuid "github.com/satori/go.uuid"
+	"net/http"
+	"school/helpers"
+	"school/models"
+	"school/session"
+	"school/util"
+	"time"
+)
+
+type (
+	CalendarModule struct {
+		db     *sql.DB
+		cache  *redis.Pool
+		name   string
+		logger *helpers.Logger
+	}
+
+	CalendarTokenParam struct {
+		Role string `json:"role"`
+	}
+
+	CalendarFeedParam struct {
+		Role  string `json:"role"`
+		Token string `json:"token"`
+	}
+
+	CalendarTokenResponse struct {
+		Token string `json:"token"`
+		URL   string `json:"url"`
+	}
+)
+
+func NewCalendarModule(db *sql.DB, cache *redis.Pool, logger *helpers.Logger) *CalendarModule {
+	return &CalendarModule{
+		db:     db,
+		cache:  cache,
+		name:   "module/calendar",
+		logger: logger,
+	}
+}
+
+// TokenAdd issues a new feed token for the logged in user and revokes the previous one, so a leaked feed url
+// stops working once a new one is requested. Only the hash of the token is stored.
+func (s CalendarModule) TokenAdd(ctx context.Context, param CalendarTokenParam) (interface{}, *helpers.Error) {
+
+	userID := uuid.FromStringOrNil(ctx.Value("user_id").(string))
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "TokenAdd/BeginTx", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+	defer tx.Rollback()
+
+	_, err = models.DeleteCalendarTokenByUser(ctx, tx, userID, param.Role, uuid.NullUUID{UUID: userID, Valid: true})
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "TokenAdd/DeleteCalendarTokenByUser", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	token, err := util.RandomToken()
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "TokenAdd/RandomToken", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	calendarToken := models.CalendarTokenModel{
+		UserID:    userID,
+		Role:      param.Role,
+		Token:     util.HashToken(token),
+		CreatedBy: userID,
+	}
+
+	err = calendarToken.Insert(ctx, tx)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "TokenAdd/Insert", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "TokenAdd/Commit", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	return CalendarTokenResponse{
+		Token: token,
+		URL:   fmt.Sprintf("/api/v1/%s/timetable.ics?token=%s", param.Role, token),
+	}, nil
+}
+
+// TokenDelete revokes the feed token of the logged in user.
+func (s CalendarModule) TokenDelete(ctx context.Context, param CalendarTokenParam) (interface{}, *helpers.Error) {
+
+	userID := uuid.FromStringOrNil(ctx.Value("user_id").(string))
+
+	_, err := models.DeleteCalendarTokenByUser(ctx, s.db, userID, param.Role, uuid.NullUUID{UUID: userID, Valid: true})
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "TokenDelete/DeleteCalendarTokenByUser",
+			helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	return nil, nil
+}
+
+// Feed builds the iCalendar timetable of the feed token's owner. Every session is one weekly event between
+// its intake's start and end date, without the holidays and the cancelled classes. Classes that were moved
+// or handed to a substitute are single events of their own, in the feed of the lecturer who teaches them.
+func (s CalendarModule) Feed(ctx context.Context, param CalendarFeedParam) (*helpers.File, *helpers.Error) {
+
+	calendarToken, err := models.GetOneCalendarTokenByToken(ctx, s.db, util.HashToken(param.Token))
+	if err == sql.ErrNoRows || (err == nil && calendarToken.Role != param.Role) {
+		return nil, helpers.ErrorWrap(errors.New("Invalid Calendar Token"), s.name, "Feed/ValidationToken",
+			helpers.UnauthorizedMessage,
+			http.StatusUnauthorized)
+	}
+
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Feed/GetOneCalendarTokenByToken", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	var filter helpers.Filter
+	var name string
+	switch calendarToken.Role {
+	case session.STUDENT_ROLE:
+		student, err := models.GetOneStudent(ctx, s.db, calendarToken.UserID)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "Feed/GetOneStudent", helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+
+		if !canStudentLogin(student.Status) {
+			return nil, helpers.ErrorWrap(errors.New("Student Status Does Not Allow Calendar Feed"), s.name,
+				"Feed/ValidationStatus",
+				helpers.StudentStatusMessage,
+				http.StatusForbidden)
+		}
+
+		filter.StudentID = student.ID
+		name = student.Name
+
+	case session.LECTURER_ROLE:
+		lecturer, err := models.GetOneLecturer(ctx, s.db, calendarToken.UserID)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "Feed/GetOneLecturer", helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+
+		filter.LecturerID = lecturer.ID
+		name = lecturer.Name
+	}
+
+	sessions, err := models.GetAllSessionTimetable(ctx, s.db, filter)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Feed/GetAllSessionTimetable", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	var events []helpers.CalendarEvent
+	for _, current := range sessions {
+		event, ok, err := s.calendarEvent(ctx, current)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "Feed/calendarEvent", helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+
+		if ok {
+			events = append(events, event)
+		}
+
+		classes, err := models.GetAllClassBySession(ctx, s.db, helpers.Filter{
+			FilterOption: helpers.FilterOption{
+				Limit:  999,
+				Offset: 0,
+			},
+			SessionID: current.ID,
+		})
+
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "Feed/GetAllClassBySession", helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+
+		for _, class := range classes {
+			if isClassCancelled(class) || !isClassMoved(class) {
+				continue
+			}
+
+			if filter.LecturerID != uuid.Nil && classLecturerID(class, current) != filter.LecturerID {
+				continue
+			}
+
+			event, err := s.classCalendarEvent(ctx, class, current)
+			if err != nil {
+				return nil, helpers.ErrorWrap(err, s.name, "Feed/classCalendarEvent", helpers.InternalServerError,
+					http.StatusInternalServerError)
+			}
+
+			events = append(events, event)
+		}
+	}
+
+	if filter.LecturerID != uuid.Nil {
+		classes, err := models.GetAllClassBySubstitute(ctx, s.db, filter.LecturerID)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "Feed/GetAllClassBySubstitute", helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+
+		for _, class := range classes {
+			current, err := models.GetOneSession(ctx, s.db, class.SessionID)
+			if err != nil {
+				return nil, helpers.ErrorWrap(err, s.name, "Feed/GetOneSession", helpers.InternalServerError,
+					http.StatusInternalServerError)
+			}
+
+			if current.LecturerID == filter.LecturerID {
+				continue
+			}
+
+			event, err := s.classCalendarEvent(ctx, class, current)
+			if err != nil {
+				return nil, helpers.ErrorWrap(err, s.name, "Feed/classCalendarEvent", helpers.InternalServerError,
+					http.StatusInternalServerError)
+			}
+
+			events = append(events, event)
+		}
+	}
+
+	return &helpers.File{
+		Name:        "timetable.ics",
+		ContentType: "text/calendar; charset=utf-8",
+		Content:     helpers.BuildCalendar(fmt.Sprintf("%s Timetable", name), events),
+	}, nil
+}
+
+// calendarEvent repeats the session weekly from its first day on or after the intake's start date. It is
+// false when the intake has no such day.
+func (s CalendarModule) calendarEvent(ctx context.Context, current models.SessionModel) (
+	helpers.CalendarEvent, bool, error) {
+
+	intake, err := models.GetOneIntake(ctx, s.db, current.IntakeID)
+	if err != nil {
+		return helpers.CalendarEvent{}, false, err
+	}
+
+	startDate := truncateDate(intake.StartDate)
+	endDate := truncateDate(intake.EndDate)
+
+	firstDate := startDate
+	for int(firstDate.Weekday()) != current.Day {
+		firstDate = firstDate.AddDate(0, 0, 1)
+	}
+
+	if firstDate.After(endDate) {
+		return helpers.CalendarEvent{}, false, nil
+	}
+
+	subject, err := models.GetOneSubject(ctx, s.db, current.SubjectID)
+	if err != nil {
+		return helpers.CalendarEvent{}, false, err
+	}
+
+	classroom, err := models.GetOneClassroom(ctx, s.db, current.ClassroomID)
+	if err != nil {
+		return helpers.CalendarEvent{}, false, err
+	}
+
+	exDates, err := sessionExDates(ctx, s.db, current, intake)
+	if err != nil {
+		return helpers.CalendarEvent{}, false, err
+	}
+
+	event := helpers.CalendarEvent{
+		UID:      fmt.Sprintf("%s@school", current.ID),
+		Summary:  subject.Name,
+		Location: classroom.Code,
+		Start:    atTimeOfDay(firstDate, current.StartTime),
+		End:      atTimeOfDay(firstDate, current.EndTime),
+		Until:    endDate.Add(24*time.Hour - time.Second),
+	}
+
+	for _, exDate := range exDates {
+		event.ExDates = append(event.ExDates, atTimeOfDay(exDate, current.StartTime))
+	}
+
+	return event, true, nil
+}
+
+// sessionExDates lists the session's days in the intake that fall on a holiday, in a holiday period, or
+// whose class was cancelled or moved. A day that still has its regular class is kept.
+func sessionExDates(ctx context.Context, db *sql.DB, current models.SessionModel, intake models.IntakeModel) (
+	[]time.Time, error) {
+
+	startDate := truncateDate(intake.StartDate)
+	endDate := truncateDate(intake.EndDate)
+
+	holidays, err := models.GetAllHolidayBetween(ctx, db, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	holidayPeriods, err := models.GetAllAcademicPeriodByIntake(ctx, db, intake.ID, models.ACADEMIC_PERIOD_HOLIDAY)
+	if err != nil {
+		return nil, err
+	}
+
+	classes, err := models.GetAllClassBySession(ctx, db, helpers.Filter{
+		FilterOption: helpers.FilterOption{
+			Limit:  999,
+			Offset: 0,
+		},
+		SessionID: current.ID,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	excluded := make(map[string]bool)
+	for _, holiday := range holidays {
+		excluded[dateKey(holiday.Date)] = true
+	}
+
+	classDates := make(map[string]bool)
+	for _, class := range classes {
+		if isClassCancelled(class) || isClassMoved(class) {
+			excluded[dateKey(class.Date)] = true
+		} else {
+			classDates[dateKey(class.Date)] = true
+		}
+	}
+
+	var exDates []time.Time
+	for date := startDate; !date.After(endDate); date = date.AddDate(0, 0, 1) {
+		if int(date.Weekday()) != current.Day || classDates[dateKey(date)] {
+			continue
+		}
+
+		if excluded[dateKey(date)] || isInAcademicPeriods(holidayPeriods, date) {
+			exDates = append(exDates, date)
+		}
+	}
+
+	return exDates, nil
+}
+
+// classCalendarEvent is the single event of a class that is not held as its session says.
+func (s CalendarModule) classCalendarEvent(ctx context.Context, class models.ClassModel,
+	current models.SessionModel) (helpers.CalendarEvent, error) {
+
+	subject, err := models.GetOneSubject(ctx, s.db, current.SubjectID)
+	if err != nil {
+		return helpers.CalendarEvent{}, err
+	}
+
+	classroom, err := models.GetOneClassroom(ctx, s.db, classClassroomID(class, current))
+	if err != nil {
+		return helpers.CalendarEvent{}, err
+	}
+
+	start, end := classWindow(class, current)
+
+	return helpers.CalendarEvent{
+		UID:         fmt.Sprintf("%s@school", class.ID),
+		Summary:     subject.Name,
+		Location:    classroom.Code,
+		Description: class.Reason,
+		Start:       start,
+		End:         end,
+	}, nil
+}
+
+func atTimeOfDay(date time.Time, clock time.Time) time.Time {
+	year, month, day := date.Date()
+	return time.Date(year, month, day, clock.Hour(), clock.Minute(), clock.Second(), 0, date.Location())
+}
@@ -0,0 +1,225 @@
+package api
+
+import (
+	"context"
+	uuid "github.com/satori/go.uuid"
+	"net/http"
+	"school/helpers"
+	"school/models"
+	"time"
+)
+
+type (
+	ClassGenerateParam struct {
+		IntakeID uuid.UUID `json:"intake_id"`
+		DryRun   bool      `json:"dry_run"`
+	}
+
+	ClassGenerateRowResponse struct {
+		SessionID uuid.UUID `json:"session_id"`
+		Date      time.Time `json:"date"`
+	}
+
+	ClassGenerateResponse struct {
+		IntakeID uuid.UUID                  `json:"intake_id"`
+		DryRun   bool                       `json:"dry_run"`
+		Created  int                        `json:"created"`
+		Existing int                        `json:"existing"`
+		Holidays int                        `json:"holidays"`
+		Classes  []ClassGenerateRowResponse `json:"classes"`
+	}
+)
+
+// Generate creates a class on the session's day of every week between the intake's start and end date,
+// together with the attendance rows of its enrolled students. When the intake's calendar has teaching periods
+// only dates inside them are used. Holidays and dates that already have a class, even a cancelled or
+// rescheduled one, are skipped, so running it again only fills in what is missing.
+func (s ClassModule) Generate(ctx context.Context, param ClassGenerateParam) (interface{}, *helpers.Error) {
+
+	intake, err := models.GetOneIntake(ctx, s.db, param.IntakeID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Generate/GetOneIntake", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	sessions, err := models.GetAllSession(ctx, s.db, helpers.Filter{
+		FilterOption: helpers.FilterOption{
+			Limit:  999,
+			Offset: 0,
+		},
+		IntakeID: param.IntakeID,
+	})
+
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Generate/GetAllSession", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	startDate := truncateDate(intake.StartDate)
+	endDate := truncateDate(intake.EndDate)
+
+	holidays, err := models.GetAllHolidayBetween(ctx, s.db, startDate, endDate)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Generate/GetAllHolidayBetween", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	holidayDates := make(map[string]bool)
+	for _, holiday := range holidays {
+		holidayDates[dateKey(holiday.Date)] = true
+	}
+
+	holidayPeriods, err := models.GetAllAcademicPeriodByIntake(ctx, s.db, intake.ID, models.ACADEMIC_PERIOD_HOLIDAY)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Generate/GetAllAcademicPeriodByIntake",
+			helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	teachingPeriods, err := models.GetAllAcademicPeriodByIntake(ctx, s.db, intake.ID, models.ACADEMIC_PERIOD_TEACHING)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Generate/GetAllAcademicPeriodByIntake",
+			helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	response := ClassGenerateResponse{
+		IntakeID: param.IntakeID,
+		DryRun:   param.DryRun,
+	}
+
+	userID := uuid.FromStringOrNil(ctx.Value("user_id").(string))
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Generate/BeginTx", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+	defer tx.Rollback()
+
+	for _, session := range sessions {
+		// The session row is locked before its classes are read, so a concurrent run waits for this one
+		// and then sees the classes it created instead of inserting them again. The unique index on the
+		// session and date of a class rejects a duplicate either way.
+		_, err = models.GetSessionCapacityForUpdate(ctx, tx, session.ID)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "Generate/GetSessionCapacityForUpdate",
+				helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+
+		classes, err := models.GetAllClassBySession(ctx, tx, helpers.Filter{
+			FilterOption: helpers.FilterOption{
+				Limit:  999,
+				Offset: 0,
+			},
+			SessionID: session.ID,
+		})
+
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "Generate/GetAllClassBySession", helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+
+		classDates := make(map[string]bool)
+		for _, class := range classes {
+			classDates[dateKey(class.Date)] = true
+		}
+
+		studentEnrolls, err := models.GetAllStudentEnrollBySession(ctx, s.db, helpers.Filter{
+			FilterOption: helpers.FilterOption{
+				Limit:  999,
+				Offset: 0,
+			},
+			SessionID: session.ID,
+		})
+
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "Generate/GetAllStudentEnrollBySession",
+				helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+
+		for date := startDate; !date.After(endDate); date = date.AddDate(0, 0, 1) {
+			if int(date.Weekday()) != session.Day {
+				continue
+			}
+
+			if len(teachingPeriods) > 0 && !isInAcademicPeriods(teachingPeriods, date) {
+				continue
+			}
+
+			if holidayDates[dateKey(date)] || isInAcademicPeriods(holidayPeriods, date) {
+				response.Holidays++
+				continue
+			}
+
+			if classDates[dateKey(date)] {
+				response.Existing++
+				continue
+			}
+
+			response.Created++
+			response.Classes = append(response.Classes, ClassGenerateRowResponse{
+				SessionID: session.ID,
+				Date:      date,
+			})
+
+			if param.DryRun {
+				continue
+			}
+
+			class := models.ClassModel{
+				SessionID: session.ID,
+				Date:      date,
+				CreatedBy: userID,
+			}
+
+			err = class.Insert(ctx, tx)
+			if err != nil {
+				return nil, helpers.ErrorWrap(err, s.name, "Generate/ClassInsert", helpers.InternalServerError,
+					http.StatusInternalServerError)
+			}
+
+			for _, studentEnroll := range studentEnrolls {
+				if studentEnroll.IsDelete || studentEnroll.Status == models.STUDENT_ENROLL_WITHDRAWN {
+					continue
+				}
+
+				attendance := models.AttendanceModel{
+					StudentID: studentEnroll.StudentID,
+					ClassID:   class.ID,
+					CreatedBy: userID,
+				}
+
+				err = attendance.Insert(ctx, tx)
+				if err != nil {
+					return nil, helpers.ErrorWrap(err, s.name, "Generate/AttendanceInsert",
+						helpers.InternalServerError,
+						http.StatusInternalServerError)
+				}
+			}
+		}
+	}
+
+	if param.DryRun {
+		return response, nil
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Generate/Commit", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	return response, nil
+}
+
+func truncateDate(date time.Time) time.Time {
+	year, month, day := date.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, date.Location())
+}
+
+func dateKey(date time.Time) string {
+	return date.Format("2006-01-02")
+}
@@ -3,11 +3,14 @@ package api
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 	"github.com/gomodule/redigo/redis"
 	uuid "github.com/satori/go.uuid"
 	"net/http"
 	"school/helpers"
 	"school/models"
+	"school/session"
 	"time"
 )
 
@@ -96,7 +99,21 @@ func (s ClassModule) Add(ctx context.Context, param ClassAddParam) (interface{},
 		CreatedBy: uuid.FromStringOrNil(ctx.Value("user_id").(string)),
 	}
 
-	err := class.Insert(ctx, s.db)
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Add/BeginTx", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+	defer tx.Rollback()
+
+	err = class.Insert(ctx, tx)
+	if helpers.IsUniqueViolation(err) {
+		return nil, helpers.ErrorWrap(errors.New("Session Already Has A Class On That Date"), s.name,
+			"Add/ValidationDuplicate",
+			helpers.SessionConflictMessage,
+			http.StatusConflict)
+	}
+
 	if err != nil {
 		return nil, helpers.ErrorWrap(err, s.name, "Add/ClassInsert", helpers.InternalServerError,
 			http.StatusInternalServerError)
@@ -117,19 +134,35 @@ func (s ClassModule) Add(ctx context.Context, param ClassAddParam) (interface{},
 	}
 
 	for _, student := range students {
+		if student.IsDelete || student.Status == models.STUDENT_ENROLL_WITHDRAWN {
+			continue
+		}
+
 		attendance := models.AttendanceModel{
 			StudentID: student.StudentID,
 			ClassID:   class.ID,
 			CreatedBy: uuid.FromStringOrNil(ctx.Value("user_id").(string)),
 		}
 
-		err = attendance.Insert(ctx, s.db)
+		err = attendance.Insert(ctx, tx)
 		if err != nil {
 			return nil, helpers.ErrorWrap(err, s.name, "Add/AttendanceInsert", helpers.InternalServerError,
 				http.StatusInternalServerError)
 		}
 	}
 
+	err = s.notifyClassAdded(ctx, tx, class)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Add/NotifyClassAdded", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Add/Commit", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
 	response, err := class.Response(ctx, s.db, s.logger)
 	if err != nil {
 		return nil, helpers.ErrorWrap(err, s.name, "Add/Response", helpers.InternalServerError,
@@ -138,3 +171,38 @@ func (s ClassModule) Add(ctx context.Context, param ClassAddParam) (interface{},
 
 	return response, nil
 }
+
+// notifyClassAdded tells the session's lecturer and enrolled students about an extra class, inside the
+// transaction adding it.
+func (s ClassModule) notifyClassAdded(ctx context.Context, tx *sql.Tx, class models.ClassModel) error {
+
+	classSession, err := models.GetOneSession(ctx, s.db, class.SessionID)
+	if err != nil {
+		return err
+	}
+
+	subject, err := models.GetOneSubject(ctx, s.db, classSession.SubjectID)
+	if err != nil {
+		return err
+	}
+
+	studentIDs, err := sessionStudentIDs(ctx, s.db, class.SessionID)
+	if err != nil {
+		return err
+	}
+
+	start, _ := classWindow(class, classSession)
+
+	title := "Class Added"
+	body := fmt.Sprintf("A %s class has been added on %s at %s.", subject.Name,
+		start.Format("Monday, 02 Jan 2006"), start.Format("15:04"))
+
+	err = notifyUsers(ctx, tx, studentIDs, session.STUDENT_ROLE, models.NOTIFICATION_CLASS_ADDED, title, body,
+		class.ID)
+	if err != nil {
+		return err
+	}
+
+	return notifyUsers(ctx, tx, []uuid.UUID{classSession.LecturerID}, session.LECTURER_ROLE,
+		models.NOTIFICATION_CLASS_ADDED, title, body, class.ID)
+}
@@ -0,0 +1,593 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	uuid "github.com/satori/go.uuid"
+	"net/http"
+	"school/helpers"
+	"school/models"
+	"school/session"
+	"time"
+)
+
+type (
+	ClassCancelParam struct {
+		ID         uuid.UUID `json:"id"`
+		Reason     string    `json:"reason" valid:"required"`
+		ByLecturer bool      `json:"-"`
+	}
+
+	ClassRescheduleParam struct {
+		ID          uuid.UUID `json:"id"`
+		Date        time.Time `json:"date"`
+		ClassroomID uuid.UUID `json:"classroom_id"`
+		Reason      string    `json:"reason" valid:"required"`
+		ByLecturer  bool      `json:"-"`
+	}
+
+	ClassSubstituteParam struct {
+		ID         uuid.UUID `json:"id"`
+		LecturerID uuid.UUID `json:"lecturer_id"`
+		Reason     string    `json:"reason" valid:"required"`
+		ByLecturer bool      `json:"-"`
+	}
+
+	ClassConflictResponse struct {
+		Sessions []models.SessionResponse          `json:"sessions"`
+		Classes  []models.ClassResponse            `json:"classes"`
+		Bookings []models.ClassroomBookingResponse `json:"bookings"`
+	}
+)
+
+// Cancel cancels a class that has not taken place yet and notifies its students. The attendance rows stay
+// with the cancelled class and no longer count towards attendance.
+func (s ClassModule) Cancel(ctx context.Context, param ClassCancelParam) (interface{}, *helpers.Error) {
+
+	class, classSession, errClass := s.changeableClass(ctx, "Cancel", param.ID, param.ByLecturer)
+	if errClass != nil {
+		return nil, errClass
+	}
+
+	studentIDs, err := sessionStudentIDs(ctx, s.db, classSession.ID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Cancel/sessionStudentIDs", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	title := "Class Cancelled"
+	body, err := s.classChangeBody(ctx, class, classSession, "has been cancelled", param.Reason)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Cancel/classChangeBody", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Cancel/BeginTx", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+	defer tx.Rollback()
+
+	class.Status = models.CLASS_CANCELLED
+	class.Reason = param.Reason
+	class.UpdatedBy = uuid.NullUUID{
+		UUID:  uuid.FromStringOrNil(ctx.Value("user_id").(string)),
+		Valid: true,
+	}
+
+	err = class.StatusUpdate(ctx, tx)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Cancel/StatusUpdate", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	err = notifyUsers(ctx, tx, studentIDs, session.STUDENT_ROLE, models.NOTIFICATION_CLASS_CANCELLED, title, body,
+		class.ID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Cancel/notifyUsers", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Cancel/Commit", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	mailStudents(ctx, s.db, s.logger, studentIDs, title, body)
+
+	response, err := class.Response(ctx, s.db, s.logger)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Cancel/Response", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	return response, nil
+}
+
+// Reschedule moves a class to another date and/or classroom at the session's time. The original class is
+// kept as rescheduled, a new class takes its place and the attendance rows are carried over to it. The move
+// is refused when the classroom or the lecturer is busy at the new time.
+func (s ClassModule) Reschedule(ctx context.Context, param ClassRescheduleParam) (interface{}, *helpers.Error) {
+
+	class, classSession, errClass := s.changeableClass(ctx, "Reschedule", param.ID, param.ByLecturer)
+	if errClass != nil {
+		return nil, errClass
+	}
+
+	date := truncateDate(param.Date)
+	if date.Before(truncateDate(time.Now())) {
+		return nil, helpers.ErrorWrap(errors.New("Class Cannot Be Moved To The Past"), s.name,
+			"Reschedule/ValidationDate",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	classroomID := param.ClassroomID
+	if classroomID == uuid.Nil {
+		classroomID = classClassroomID(class, classSession)
+	}
+
+	if dateKey(date) == dateKey(class.Date) && classroomID == classClassroomID(class, classSession) {
+		return nil, helpers.ErrorWrap(errors.New("Class Is Already At That Date And Classroom"), s.name,
+			"Reschedule/ValidationChange",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	classroom, err := models.GetOneClassroom(ctx, s.db, classroomID)
+	if err == sql.ErrNoRows || (err == nil && classroom.IsDelete) {
+		return nil, helpers.ErrorWrap(errors.New("Classroom Not Found"), s.name, "Reschedule/ValidationClassroom",
+			helpers.BadRequestMessage,
+			http.StatusNotFound)
+	}
+
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Reschedule/GetOneClassroom", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	holidays, err := models.GetAllHolidayBetween(ctx, s.db, date, date)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Reschedule/GetAllHolidayBetween", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	if len(holidays) > 0 {
+		return nil, helpers.ErrorWrap(errors.New("Date Is A Holiday"), s.name, "Reschedule/ValidationHoliday",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	classes, err := models.GetAllClassBySession(ctx, s.db, helpers.Filter{
+		FilterOption: helpers.FilterOption{
+			Limit:  999,
+			Offset: 0,
+		},
+		SessionID: classSession.ID,
+	})
+
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Reschedule/GetAllClassBySession", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	for _, current := range classes {
+		if current.ID != class.ID && !current.IsDelete && dateKey(current.Date) == dateKey(date) {
+			return nil, helpers.ErrorWrap(errors.New("Session Already Has A Class On That Date"), s.name,
+				"Reschedule/ValidationDuplicate",
+				helpers.SessionConflictMessage,
+				http.StatusConflict)
+		}
+	}
+
+	conflict, errConflict := s.checkClassConflict(ctx, "Reschedule", class, classSession, date, classroomID,
+		classLecturerID(class, classSession))
+	if errConflict != nil {
+		return conflict, errConflict
+	}
+
+	studentIDs, err := sessionStudentIDs(ctx, s.db, classSession.ID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Reschedule/sessionStudentIDs", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	title := "Class Rescheduled"
+	body, err := s.classChangeBody(ctx, class, classSession,
+		fmt.Sprintf("has been moved to %s in %s", date.Format("Monday, 02 Jan 2006"), classroom.Code),
+		param.Reason)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Reschedule/classChangeBody", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	userID := uuid.FromStringOrNil(ctx.Value("user_id").(string))
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Reschedule/BeginTx", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+	defer tx.Rollback()
+
+	class.Status = models.CLASS_RESCHEDULED
+	class.Reason = param.Reason
+	class.UpdatedBy = uuid.NullUUID{UUID: userID, Valid: true}
+
+	err = class.StatusUpdate(ctx, tx)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Reschedule/StatusUpdate", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	rescheduled := models.ClassModel{
+		SessionID: classSession.ID,
+		Date:      date,
+		ClassroomID: uuid.NullUUID{
+			UUID:  classroomID,
+			Valid: classroomID != classSession.ClassroomID,
+		},
+		SubstituteLecturerID: class.SubstituteLecturerID,
+		RescheduledFromID:    uuid.NullUUID{UUID: class.ID, Valid: true},
+		CreatedBy:            userID,
+	}
+
+	err = rescheduled.Insert(ctx, tx)
+	if helpers.IsUniqueViolation(err) {
+		return nil, helpers.ErrorWrap(errors.New("Session Already Has A Class On That Date"), s.name,
+			"Reschedule/ValidationDuplicate",
+			helpers.SessionConflictMessage,
+			http.StatusConflict)
+	}
+
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Reschedule/Insert", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	err = models.MoveAttendanceClass(ctx, tx, class.ID, rescheduled.ID, class.UpdatedBy)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Reschedule/MoveAttendanceClass", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	err = notifyUsers(ctx, tx, studentIDs, session.STUDENT_ROLE, models.NOTIFICATION_CLASS_RESCHEDULED, title,
+		body, rescheduled.ID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Reschedule/notifyUsers", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Reschedule/Commit", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	mailStudents(ctx, s.db, s.logger, studentIDs, title, body)
+
+	response, err := rescheduled.Response(ctx, s.db, s.logger)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Reschedule/Response", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	return response, nil
+}
+
+// Substitute hands the class to another lecturer, who must be free at that time. Assigning the session's own
+// lecturer, or none, removes the substitute.
+func (s ClassModule) Substitute(ctx context.Context, param ClassSubstituteParam) (interface{}, *helpers.Error) {
+
+	class, classSession, errClass := s.changeableClass(ctx, "Substitute", param.ID, param.ByLecturer)
+	if errClass != nil {
+		return nil, errClass
+	}
+
+	substituteID := uuid.NullUUID{
+		UUID:  param.LecturerID,
+		Valid: param.LecturerID != uuid.Nil && param.LecturerID != classSession.LecturerID,
+	}
+
+	lecturerName := "the session's lecturer"
+	if substituteID.Valid {
+		lecturer, err := models.GetOneLecturer(ctx, s.db, substituteID.UUID)
+		if err == sql.ErrNoRows {
+			return nil, helpers.ErrorWrap(errors.New("Lecturer Not Found"), s.name, "Substitute/ValidationLecturer",
+				helpers.BadRequestMessage,
+				http.StatusNotFound)
+		}
+
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "Substitute/GetOneLecturer", helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+
+		lecturerName = lecturer.Name
+
+		conflict, errConflict := s.checkClassConflict(ctx, "Substitute", class, classSession, class.Date,
+			uuid.Nil, lecturer.ID)
+		if errConflict != nil {
+			return conflict, errConflict
+		}
+	}
+
+	studentIDs, err := sessionStudentIDs(ctx, s.db, classSession.ID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Substitute/sessionStudentIDs", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	title := "Substitute Lecturer Assigned"
+	body, err := s.classChangeBody(ctx, class, classSession,
+		fmt.Sprintf("will be taught by %s", lecturerName), param.Reason)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Substitute/classChangeBody", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Substitute/BeginTx", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+	defer tx.Rollback()
+
+	class.SubstituteLecturerID = substituteID
+	class.Reason = param.Reason
+	class.UpdatedBy = uuid.NullUUID{
+		UUID:  uuid.FromStringOrNil(ctx.Value("user_id").(string)),
+		Valid: true,
+	}
+
+	err = class.SubstituteUpdate(ctx, tx)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Substitute/SubstituteUpdate", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	err = notifyUsers(ctx, tx, studentIDs, session.STUDENT_ROLE, models.NOTIFICATION_CLASS_SUBSTITUTED, title,
+		body, class.ID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Substitute/notifyUsers", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	if substituteID.Valid {
+		err = notifyUsers(ctx, tx, []uuid.UUID{substituteID.UUID}, session.LECTURER_ROLE,
+			models.NOTIFICATION_CLASS_SUBSTITUTED, title, body, class.ID)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "Substitute/notifyUsers", helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Substitute/Commit", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	mailStudents(ctx, s.db, s.logger, studentIDs, title, body)
+
+	response, err := class.Response(ctx, s.db, s.logger)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Substitute/Response", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	return response, nil
+}
+
+// changeableClass loads a class that is neither cancelled nor over. A lecturer may only change the classes
+// they teach.
+func (s ClassModule) changeableClass(ctx context.Context, step string, classID uuid.UUID, byLecturer bool) (
+	models.ClassModel, models.SessionModel, *helpers.Error) {
+
+	class, err := models.GetOneClass(ctx, s.db, classID)
+	if err == sql.ErrNoRows {
+		return models.ClassModel{}, models.SessionModel{}, helpers.ErrorWrap(errors.New("Class Not Found"),
+			s.name, step+"/ValidationClass",
+			helpers.BadRequestMessage,
+			http.StatusNotFound)
+	}
+
+	if err != nil {
+		return models.ClassModel{}, models.SessionModel{}, helpers.ErrorWrap(err, s.name, step+"/GetOneClass",
+			helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	var classSession models.SessionModel
+	if byLecturer {
+		var errSession *helpers.Error
+		classSession, errSession = checkClassLecturer(ctx, s.db, s.name, class)
+		if errSession != nil {
+			return models.ClassModel{}, models.SessionModel{}, errSession
+		}
+	} else {
+		classSession, err = models.GetOneSession(ctx, s.db, class.SessionID)
+		if err != nil {
+			return models.ClassModel{}, models.SessionModel{}, helpers.ErrorWrap(err, s.name,
+				step+"/GetOneSession",
+				helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+	}
+
+	if isClassCancelled(class) {
+		return models.ClassModel{}, models.SessionModel{}, helpers.ErrorWrap(
+			fmt.Errorf("Class Has Been %s", class.Status), s.name, step+"/ValidationStatus",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	_, end := classWindow(class, classSession)
+	if time.Now().After(end) {
+		return models.ClassModel{}, models.SessionModel{}, helpers.ErrorWrap(
+			errors.New("Class Has Already Taken Place"), s.name, step+"/ValidationWindow",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	return class, classSession, nil
+}
+
+// checkClassConflict looks for what keeps the class from being held on the date in the classroom by the
+// lecturer: sessions of that weekday, moved or substituted classes and classroom bookings. A nil classroom
+// or lecturer is not checked.
+func (s ClassModule) checkClassConflict(ctx context.Context, step string, class models.ClassModel,
+	classSession models.SessionModel, date time.Time, classroomID uuid.UUID, lecturerID uuid.UUID) (
+	interface{}, *helpers.Error) {
+
+	var filters []helpers.Filter
+	if classroomID != uuid.Nil {
+		filters = append(filters, helpers.Filter{ClassID: class.ID, ClassroomID: classroomID})
+	}
+
+	if lecturerID != uuid.Nil {
+		filters = append(filters, helpers.Filter{ClassID: class.ID, LecturerID: lecturerID})
+	}
+
+	var response ClassConflictResponse
+	seen := make(map[uuid.UUID]bool)
+	for _, filter := range filters {
+		sessions, err := models.GetAllSessionInSlot(ctx, s.db, filter, int(date.Weekday()), date,
+			classSession.StartTime, classSession.EndTime)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, step+"/GetAllSessionInSlot", helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+
+		for _, current := range sessions {
+			if current.ID == classSession.ID || seen[current.ID] {
+				continue
+			}
+
+			seen[current.ID] = true
+			sessionResponse, err := current.Response(ctx, s.db, s.logger)
+			if err != nil {
+				return nil, helpers.ErrorWrap(err, s.name, step+"/SessionResponse", helpers.InternalServerError,
+					http.StatusInternalServerError)
+			}
+
+			response.Sessions = append(response.Sessions, sessionResponse)
+		}
+
+		classes, err := models.GetAllClassInSlot(ctx, s.db, filter, date, classSession.StartTime,
+			classSession.EndTime)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, step+"/GetAllClassInSlot", helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+
+		for _, current := range classes {
+			if current.SessionID == classSession.ID || seen[current.ID] || seen[current.SessionID] {
+				continue
+			}
+
+			seen[current.ID] = true
+			classResponse, err := current.Response(ctx, s.db, s.logger)
+			if err != nil {
+				return nil, helpers.ErrorWrap(err, s.name, step+"/ClassResponse", helpers.InternalServerError,
+					http.StatusInternalServerError)
+			}
+
+			response.Classes = append(response.Classes, classResponse)
+		}
+	}
+
+	if classroomID != uuid.Nil {
+		bookings, err := models.GetAllClassroomBookingConflict(ctx, s.db, models.ClassroomBookingModel{
+			ClassroomID: classroomID,
+			Date:        date,
+			StartTime:   classSession.StartTime,
+			EndTime:     classSession.EndTime,
+		})
+
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, step+"/GetAllClassroomBookingConflict",
+				helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+
+		for _, booking := range bookings {
+			response.Bookings = append(response.Bookings, booking.Response())
+		}
+	}
+
+	if len(response.Sessions) == 0 && len(response.Classes) == 0 && len(response.Bookings) == 0 {
+		return nil, nil
+	}
+
+	return response, helpers.ErrorWrap(errors.New("Class Clashes With Existing Classes Or Bookings"), s.name,
+		step+"/ValidationConflict",
+		helpers.SessionConflictMessage,
+		http.StatusConflict)
+}
+
+func (s ClassModule) classChangeBody(ctx context.Context, class models.ClassModel,
+	classSession models.SessionModel, change string, reason string) (string, error) {
+
+	subject, err := models.GetOneSubject(ctx, s.db, classSession.SubjectID)
+	if err != nil {
+		return "", err
+	}
+
+	start, _ := classWindow(class, classSession)
+
+	return fmt.Sprintf("%s on %s at %s %s.\r\n\r\nReason : %s", subject.Name,
+		start.Format("Monday, 02 Jan 2006"), start.Format("15:04"), change, reason), nil
+}
+
+// checkClassLecturer loads the class's session and makes sure the logged in lecturer teaches the class,
+// either as the session's lecturer or as its substitute.
+func checkClassLecturer(ctx context.Context, db *sql.DB, name string, class models.ClassModel) (
+	models.SessionModel, *helpers.Error) {
+
+	userID := uuid.FromStringOrNil(ctx.Value("user_id").(string))
+	if class.SubstituteLecturerID.Valid && class.SubstituteLecturerID.UUID == userID {
+		classSession, err := models.GetOneSession(ctx, db, class.SessionID)
+		if err != nil {
+			return models.SessionModel{}, helpers.ErrorWrap(err, name, "CheckClassLecturer/GetOneSession",
+				helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+
+		return classSession, nil
+	}
+
+	return checkSessionLecturer(ctx, db, name, class.SessionID)
+}
+
+// isClassMoved reports whether the class is not held as its session says, so it cannot be read off the
+// weekly timetable.
+// isClassCancelled reports whether the class will not be held on its date, because it was deleted, cancelled
+// or moved to another date.
+func isClassCancelled(class models.ClassModel) bool {
+	return class.IsDelete || class.Status == models.CLASS_CANCELLED || class.Status == models.CLASS_RESCHEDULED
+}
+
+func isClassMoved(class models.ClassModel) bool {
+	return class.ClassroomID.Valid || class.SubstituteLecturerID.Valid || class.RescheduledFromID.Valid
+}
+
+func classClassroomID(class models.ClassModel, classSession models.SessionModel) uuid.UUID {
+	if class.ClassroomID.Valid {
+		return class.ClassroomID.UUID
+	}
+
+	return classSession.ClassroomID
+}
+
+func classLecturerID(class models.ClassModel, classSession models.SessionModel) uuid.UUID {
+	if class.SubstituteLecturerID.Valid {
+		return class.SubstituteLecturerID.UUID
+	}
+
+	return classSession.LecturerID
+}
@@ -0,0 +1,102 @@
+package api
+
+import (
+	uuid "github.com/satori/go.uuid"
+	"school/models"
+	"testing"
+	"time"
+)
+
+func TestIsClassMoved(t *testing.T) {
+
+	moved := uuid.NullUUID{UUID: uuid.NewV4(), Valid: true}
+
+	cases := map[string]struct {
+		class models.ClassModel
+		moved bool
+	}{
+		"scheduled":   {models.ClassModel{}, false},
+		"classroom":   {models.ClassModel{ClassroomID: moved}, true},
+		"substitute":  {models.ClassModel{SubstituteLecturerID: moved}, true},
+		"rescheduled": {models.ClassModel{RescheduledFromID: moved}, true},
+		"cancelled":   {models.ClassModel{IsDelete: true}, false},
+	}
+
+	for name, c := range cases {
+		if got := isClassMoved(c.class); got != c.moved {
+			t.Fatalf("isClassMoved(%s) = %v, expected %v", name, got, c.moved)
+		}
+	}
+
+}
+
+func TestIsClassCancelled(t *testing.T) {
+
+	cases := map[string]struct {
+		class     models.ClassModel
+		cancelled bool
+	}{
+		"scheduled":   {models.ClassModel{Status: models.CLASS_SCHEDULED}, false},
+		"cancelled":   {models.ClassModel{Status: models.CLASS_CANCELLED}, true},
+		"rescheduled": {models.ClassModel{Status: models.CLASS_RESCHEDULED}, true},
+		"deleted":     {models.ClassModel{Status: models.CLASS_SCHEDULED, IsDelete: true}, true},
+	}
+
+	for name, c := range cases {
+		if got := isClassCancelled(c.class); got != c.cancelled {
+			t.Fatalf("isClassCancelled(%s) = %v, expected %v", name, got, c.cancelled)
+		}
+	}
+
+}
+
+func TestClockBefore(t *testing.T) {
+
+	clock := func(day int, hour int, minute int, second int) time.Time {
+		return time.Date(2026, 3, day, hour, minute, second, 0, time.UTC)
+	}
+
+	cases := []struct {
+		a      time.Time
+		b      time.Time
+		before bool
+	}{
+		{clock(2, 9, 0, 0), clock(2, 10, 0, 0), true},
+		{clock(2, 10, 0, 0), clock(2, 9, 0, 0), false},
+		{clock(2, 9, 0, 0), clock(2, 9, 0, 0), false},
+		{clock(2, 9, 0, 0), clock(2, 9, 0, 1), true},
+		{clock(9, 8, 0, 0), clock(2, 9, 0, 0), true},
+		{clock(2, 11, 0, 0), clock(9, 10, 59, 59), false},
+	}
+
+	for _, c := range cases {
+		if got := clockBefore(c.a, c.b); got != c.before {
+			t.Fatalf("clockBefore(%s, %s) = %v, expected %v", c.a, c.b, got, c.before)
+		}
+	}
+
+}
+
+func TestClassWindow(t *testing.T) {
+
+	location := time.FixedZone("MYT", 8*3600)
+	class := models.ClassModel{Date: time.Date(2026, 3, 16, 0, 0, 0, 0, location)}
+	classSession := models.SessionModel{
+		StartTime: time.Date(0, 1, 1, 9, 30, 0, 0, time.UTC),
+		EndTime:   time.Date(0, 1, 1, 11, 15, 30, 0, time.UTC),
+	}
+
+	start, end := classWindow(class, classSession)
+
+	expectedStart := time.Date(2026, 3, 16, 9, 30, 0, 0, location)
+	expectedEnd := time.Date(2026, 3, 16, 11, 15, 30, 0, location)
+
+	if !start.Equal(expectedStart) || start.Location() != location {
+		t.Fatalf("classWindow() start = %s, expected %s", start, expectedStart)
+	}
+
+	if !end.Equal(expectedEnd) || end.Location() != location {
+		t.Fatalf("classWindow() end = %s, expected %s", end, expectedEnd)
+	}
+
+}
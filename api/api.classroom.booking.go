@@ -0,0 +1,320 @@
+package api
+
+import (
+	"context"
+	"errors"
+	uuid "github.com/satori/go.uuid"
+	"net/http"
+	"school/helpers"
+	"school/models"
+	"time"
+)
+
+type (
+	ClassroomBookingListParam struct {
+		ClassroomID uuid.UUID `json:"classroom_id"`
+		From        time.Time `json:"from"`
+		To          time.Time `json:"to"`
+	}
+
+	ClassroomBookingAddParam struct {
+		ClassroomID uuid.UUID `json:"classroom_id"`
+		Title       string    `json:"title" valid:"required"`
+		Date        time.Time `json:"date"`
+		StartTime   time.Time `json:"start_time"`
+		EndTime     time.Time `json:"end_time"`
+	}
+
+	ClassroomBookingDeleteParam struct {
+		ID uuid.UUID `json:"id"`
+	}
+
+	ClassroomFreeParam struct {
+		Day       int       `json:"day"`
+		Date      time.Time `json:"date"`
+		StartTime time.Time `json:"start_time"`
+		EndTime   time.Time `json:"end_time"`
+		Capacity  int       `json:"capacity"`
+		Features  []string  `json:"features"`
+		IntakeID  uuid.UUID `json:"intake_id"`
+	}
+)
+
+// BookingList returns the classroom's bookings between from and to, from today for a year when they are
+// not given.
+func (s ClassroomModule) BookingList(ctx context.Context, param ClassroomBookingListParam) (
+	interface{}, *helpers.Error) {
+
+	if param.From.IsZero() {
+		param.From = truncateDate(time.Now())
+	}
+
+	if param.To.IsZero() {
+		param.To = param.From.AddDate(1, 0, 0)
+	}
+
+	bookings, err := models.GetAllClassroomBookingBetween(ctx, s.db, param.ClassroomID, param.From, param.To)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "BookingList/GetAllClassroomBookingBetween",
+			helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	var response []models.ClassroomBookingResponse
+	for _, booking := range bookings {
+		response = append(response, booking.Response())
+	}
+
+	return response, nil
+}
+
+// BookingAdd books the classroom for an event outside the sessions. The booking is refused when a session
+// or a class moved there is held in the classroom at that time, or when it overlaps another booking.
+func (s ClassroomModule) BookingAdd(ctx context.Context, param ClassroomBookingAddParam) (
+	interface{}, *helpers.Error) {
+
+	if !clockBefore(param.StartTime, param.EndTime) {
+		return nil, helpers.ErrorWrap(errors.New("Start Time Must Be Before End Time"), s.name,
+			"BookingAdd/ValidationTime",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	if truncateDate(param.Date).Before(truncateDate(time.Now())) {
+		return nil, helpers.ErrorWrap(errors.New("Booking Date Is In The Past"), s.name,
+			"BookingAdd/ValidationDate",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	classroom, err := models.GetOneClassroom(ctx, s.db, param.ClassroomID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "BookingAdd/GetOneClassroom", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	if classroom.IsDelete {
+		return nil, helpers.ErrorWrap(errors.New("Classroom Has Been Deleted"), s.name,
+			"BookingAdd/ValidationClassroom",
+			helpers.BadRequestMessage,
+			http.StatusNotFound)
+	}
+
+	booking := models.ClassroomBookingModel{
+		ClassroomID: classroom.ID,
+		Title:       param.Title,
+		Date:        truncateDate(param.Date),
+		StartTime:   param.StartTime,
+		EndTime:     param.EndTime,
+		CreatedBy:   uuid.FromStringOrNil(ctx.Value("user_id").(string)),
+	}
+
+	sessions, err := models.GetAllSessionInSlot(ctx, s.db, helpers.Filter{ClassroomID: booking.ClassroomID},
+		int(booking.Date.Weekday()), booking.Date, booking.StartTime, booking.EndTime)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "BookingAdd/GetAllSessionInSlot", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	bookings, err := models.GetAllClassroomBookingConflict(ctx, s.db, booking)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "BookingAdd/GetAllClassroomBookingConflict",
+			helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	classes, err := models.GetAllClassInSlot(ctx, s.db, helpers.Filter{ClassroomID: booking.ClassroomID},
+		booking.Date, booking.StartTime, booking.EndTime)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "BookingAdd/GetAllClassInSlot", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	if len(sessions) > 0 || len(classes) > 0 || len(bookings) > 0 {
+		var conflictResponse ClassConflictResponse
+		for _, session := range sessions {
+			sessionResponse, err := session.Response(ctx, s.db, s.logger)
+			if err != nil {
+				return nil, helpers.ErrorWrap(err, s.name, "BookingAdd/SessionResponse",
+					helpers.InternalServerError,
+					http.StatusInternalServerError)
+			}
+
+			conflictResponse.Sessions = append(conflictResponse.Sessions, sessionResponse)
+		}
+
+		for _, class := range classes {
+			classResponse, err := class.Response(ctx, s.db, s.logger)
+			if err != nil {
+				return nil, helpers.ErrorWrap(err, s.name, "BookingAdd/ClassResponse",
+					helpers.InternalServerError,
+					http.StatusInternalServerError)
+			}
+
+			conflictResponse.Classes = append(conflictResponse.Classes, classResponse)
+		}
+
+		for _, conflict := range bookings {
+			conflictResponse.Bookings = append(conflictResponse.Bookings, conflict.Response())
+		}
+
+		return conflictResponse, helpers.ErrorWrap(errors.New("Classroom Is In Use At That Time"), s.name,
+			"BookingAdd/ValidationConflict",
+			helpers.ClassroomBookedMessage,
+			http.StatusConflict)
+	}
+
+	err = booking.Insert(ctx, s.db)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "BookingAdd/Insert", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	return booking.Response(), nil
+}
+
+func (s ClassroomModule) BookingDelete(ctx context.Context, param ClassroomBookingDeleteParam) (
+	interface{}, *helpers.Error) {
+
+	booking := models.ClassroomBookingModel{
+		ID: param.ID,
+		UpdatedBy: uuid.NullUUID{
+			UUID:  uuid.FromStringOrNil(ctx.Value("user_id").(string)),
+			Valid: true,
+		},
+	}
+
+	err := booking.Delete(ctx, s.db)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "BookingDelete/Delete", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	return nil, nil
+}
+
+// Free lists the classrooms with at least the capacity and every feature asked for that have no session
+// at that time. With a date the weekday is taken from it, and the bookings, cancelled and moved classes of
+// that date are taken into account.
+func (s ClassroomModule) Free(ctx context.Context, param ClassroomFreeParam) (interface{}, *helpers.Error) {
+
+	if !param.Date.IsZero() {
+		param.Date = truncateDate(param.Date)
+		param.Day = int(param.Date.Weekday())
+	}
+
+	if param.Day < 0 || param.Day > 6 {
+		return nil, helpers.ErrorWrap(errors.New("Day Must Be Between 0 And 6"), s.name, "Free/ValidationDay",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	if !clockBefore(param.StartTime, param.EndTime) {
+		return nil, helpers.ErrorWrap(errors.New("Start Time Must Be Before End Time"), s.name,
+			"Free/ValidationTime",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	classrooms, err := models.GetAllClassroom(ctx, s.db, helpers.Filter{
+		FilterOption: helpers.FilterOption{
+			Limit:  999,
+			Offset: 0,
+			Dir:    "ASC",
+		},
+	})
+
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Free/GetAllClassroom", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	sessions, err := models.GetAllSessionInSlot(ctx, s.db, helpers.Filter{IntakeID: param.IntakeID}, param.Day,
+		param.Date, param.StartTime, param.EndTime)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Free/GetAllSessionInSlot", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	occupied := make(map[uuid.UUID]bool)
+	for _, session := range sessions {
+		occupied[session.ClassroomID] = true
+	}
+
+	if !param.Date.IsZero() {
+		bookings, err := models.GetAllClassroomBookingBetween(ctx, s.db, uuid.Nil, param.Date, param.Date)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "Free/GetAllClassroomBookingBetween",
+				helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+
+		for _, booking := range bookings {
+			if clockBefore(booking.StartTime, param.EndTime) && clockBefore(param.StartTime, booking.EndTime) {
+				occupied[booking.ClassroomID] = true
+			}
+		}
+
+		classes, err := models.GetAllClassInSlot(ctx, s.db, helpers.Filter{}, param.Date, param.StartTime,
+			param.EndTime)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "Free/GetAllClassInSlot", helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+
+		for _, class := range classes {
+			classSession, err := models.GetOneSession(ctx, s.db, class.SessionID)
+			if err != nil {
+				return nil, helpers.ErrorWrap(err, s.name, "Free/GetOneSession", helpers.InternalServerError,
+					http.StatusInternalServerError)
+			}
+
+			occupied[classClassroomID(class, classSession)] = true
+		}
+	}
+
+	features := classroomFeatures(param.Features)
+
+	var response []models.ClassRoomResponse
+	for _, classroom := range classrooms {
+		if occupied[classroom.ID] || classroom.Capacity < param.Capacity ||
+			!hasClassroomFeatures(classroom, features) {
+			continue
+		}
+
+		classroomResponse, err := classroom.Response(ctx, s.db, s.logger)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "Free/ClassroomResponse", helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+
+		response = append(response, classroomResponse)
+	}
+
+	return response, nil
+}
+
+func hasClassroomFeatures(classroom models.ClassRoomModel, features []string) bool {
+
+	has := make(map[string]bool)
+	for _, feature := range classroom.Features {
+		has[feature] = true
+	}
+
+	for _, feature := range features {
+		if !has[feature] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// clockBefore compares the time of day only, session and booking times carry no meaningful date.
+func clockBefore(a time.Time, b time.Time) bool {
+	return clockSeconds(a) < clockSeconds(b)
+}
+
+func clockSeconds(moment time.Time) int {
+	return moment.Hour()*3600 + moment.Minute()*60 + moment.Second()
+}
@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"school/helpers"
 	"school/models"
+	"strings"
 )
 
 type (
@@ -24,16 +25,20 @@ type (
 	}
 
 	ClassroomAddParam struct {
-		FacultyID uuid.UUID `json:"faculty_id"valid:"required"`
-		Floor     int       `json:"floor"valid:"required"`
-		RoomNo    int       `json:"room_no"valid:"required"`
+		FacultyID uuid.UUID `json:"faculty_id" valid:"required"`
+		Floor     int       `json:"floor" valid:"required"`
+		RoomNo    int       `json:"room_no" valid:"required"`
+		Capacity  int       `json:"capacity" valid:"optional"`
+		Features  []string  `json:"features" valid:"optional"`
 	}
 
 	ClassroomUpdateParam struct {
 		ID        uuid.UUID `json:"id"`
-		FacultyID uuid.UUID `json:"faculty_id"valid:"required"`
-		Floor     int       `json:"floor"valid:"required"`
-		RoomNo    int       `json:"room_no"valid:"required"`
+		FacultyID uuid.UUID `json:"faculty_id" valid:"required"`
+		Floor     int       `json:"floor" valid:"required"`
+		RoomNo    int       `json:"room_no" valid:"required"`
+		Capacity  int       `json:"capacity" valid:"optional"`
+		Features  []string  `json:"features" valid:"optional"`
 	}
 
 	ClassroomDeleteParam struct {
@@ -109,6 +114,8 @@ func (s ClassroomModule) Add(ctx context.Context, param ClassroomAddParam) (inte
 		Floor:     param.Floor,
 		RoomNo:    param.RoomNo,
 		Code:      roomCode,
+		Capacity:  param.Capacity,
+		Features:  classroomFeatures(param.Features),
 		CreatedBy: uuid.FromStringOrNil(ctx.Value("user_id").(string)),
 	}
 
@@ -149,6 +156,8 @@ func (s ClassroomModule) Update(ctx context.Context, param ClassroomUpdateParam)
 		Floor:     param.Floor,
 		RoomNo:    param.RoomNo,
 		Code:      roomCode,
+		Capacity:  param.Capacity,
+		Features:  classroomFeatures(param.Features),
 		UpdatedBy: uuid.NullUUID{
 			UUID:  uuid.FromStringOrNil(ctx.Value("user_id").(string)),
 			Valid: true,
@@ -190,3 +199,22 @@ func (s ClassroomModule) Delete(ctx context.Context, param ClassroomDeleteParam)
 	return nil, nil
 
 }
+
+// classroomFeatures lower cases and trims the features and drops the empty and repeated ones, so searching
+// by feature does not depend on how it was typed.
+func classroomFeatures(features []string) []string {
+
+	normalized := []string{}
+	seen := make(map[string]bool)
+	for _, feature := range features {
+		feature = strings.ToLower(strings.TrimSpace(feature))
+		if feature == "" || seen[feature] {
+			continue
+		}
+
+		seen[feature] = true
+		normalized = append(normalized, feature)
+	}
+
+	return normalized
+}
@@ -0,0 +1,220 @@
+package api
+
+import (
+	"context"
+	uuid "github.com/satori/go.uuid"
+	"github.com/spf13/viper"
+	"math"
+	"net/http"
+	"school/helpers"
+	"school/models"
+)
+
+// CLASSROOM_AVAILABLE_DAILY_HOURS is the hours a classroom can be used on a teaching day, when
+// classroom.available_daily_hours is not configured.
+const CLASSROOM_AVAILABLE_DAILY_HOURS = 10.0
+
+// CLASSROOM_TEACHING_DAYS are the weekdays classrooms are available, when classroom.teaching_days is not
+// configured.
+var CLASSROOM_TEACHING_DAYS = []int{1, 2, 3, 4, 5}
+
+type (
+	ClassroomUtilizationParam struct {
+		IntakeID uuid.UUID `json:"intake_id"`
+	}
+
+	ClassroomUtilizationResponse struct {
+		ClassroomID    uuid.UUID `json:"classroom_id"`
+		Code           string    `json:"code"`
+		Capacity       int       `json:"capacity"`
+		IntakeID       uuid.UUID `json:"intake_id"`
+		SessionHours   float64   `json:"session_hours"`
+		BookingHours   float64   `json:"booking_hours"`
+		BookedHours    float64   `json:"booked_hours"`
+		AvailableHours float64   `json:"available_hours"`
+		Utilization    float64   `json:"utilization"`
+	}
+)
+
+// Utilization compares the hours every classroom is booked during the intake, by its sessions' classes,
+// the classes moved into it and ad-hoc bookings, with the hours it is available on the intake's teaching days
+// that are not holidays. The teaching days are the configured weekdays plus any weekday a session of the
+// intake is scheduled on, so weekend classes are measured against weekend availability. Utilization is a
+// percentage.
+func (s ClassroomModule) Utilization(ctx context.Context, param ClassroomUtilizationParam) (
+	interface{}, *helpers.Error) {
+
+	intake, err := models.GetOneIntake(ctx, s.db, param.IntakeID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Utilization/GetOneIntake", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	startDate := truncateDate(intake.StartDate)
+	endDate := truncateDate(intake.EndDate)
+
+	holidays, err := models.GetAllHolidayBetween(ctx, s.db, startDate, endDate)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Utilization/GetAllHolidayBetween", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	holidayDates := make(map[string]bool)
+	for _, holiday := range holidays {
+		holidayDates[dateKey(holiday.Date)] = true
+	}
+
+	holidayPeriods, err := models.GetAllAcademicPeriodByIntake(ctx, s.db, intake.ID, models.ACADEMIC_PERIOD_HOLIDAY)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Utilization/GetAllAcademicPeriodByIntake",
+			helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	intakeSessions, err := models.GetAllSessionTimetable(ctx, s.db, helpers.Filter{IntakeID: intake.ID})
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Utilization/GetAllSessionTimetable",
+			helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	weekdays := classroomTeachingWeekdays()
+	for _, session := range intakeSessions {
+		weekdays[session.Day] = true
+	}
+
+	var teachingDays int
+	for date := startDate; !date.After(endDate); date = date.AddDate(0, 0, 1) {
+		if !weekdays[int(date.Weekday())] {
+			continue
+		}
+
+		if !holidayDates[dateKey(date)] && !isInAcademicPeriods(holidayPeriods, date) {
+			teachingDays++
+		}
+	}
+
+	availableHours := float64(teachingDays) * classroomAvailableDailyHours()
+
+	classrooms, err := models.GetAllClassroom(ctx, s.db, helpers.Filter{
+		FilterOption: helpers.FilterOption{
+			Limit:  999,
+			Offset: 0,
+			Dir:    "ASC",
+		},
+	})
+
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Utilization/GetAllClassroom", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	movedClasses, err := models.GetAllClassMovedByIntake(ctx, s.db, intake.ID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Utilization/GetAllClassMovedByIntake",
+			helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	movedHours := make(map[uuid.UUID]float64)
+	for _, class := range movedClasses {
+		classSession, err := models.GetOneSession(ctx, s.db, class.SessionID)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "Utilization/GetOneSession", helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+
+		movedHours[classClassroomID(class, classSession)] += sessionHours(classSession)
+	}
+
+	var response []ClassroomUtilizationResponse
+	for _, classroom := range classrooms {
+		utilization := ClassroomUtilizationResponse{
+			ClassroomID:    classroom.ID,
+			Code:           classroom.Code,
+			Capacity:       classroom.Capacity,
+			IntakeID:       intake.ID,
+			AvailableHours: availableHours,
+		}
+
+		sessions, err := models.GetAllSessionTimetable(ctx, s.db, helpers.Filter{
+			IntakeID:    intake.ID,
+			ClassroomID: classroom.ID,
+		})
+
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "Utilization/GetAllSessionTimetable",
+				helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+
+		for _, session := range sessions {
+			exDates, err := sessionExDates(ctx, s.db, session, intake)
+			if err != nil {
+				return nil, helpers.ErrorWrap(err, s.name, "Utilization/sessionExDates",
+					helpers.InternalServerError,
+					http.StatusInternalServerError)
+			}
+
+			var occurrences int
+			for date := startDate; !date.After(endDate); date = date.AddDate(0, 0, 1) {
+				if int(date.Weekday()) == session.Day {
+					occurrences++
+				}
+			}
+
+			utilization.SessionHours += float64(occurrences-len(exDates)) * sessionHours(session)
+		}
+
+		utilization.SessionHours += movedHours[classroom.ID]
+
+		bookings, err := models.GetAllClassroomBookingBetween(ctx, s.db, classroom.ID, startDate, endDate)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "Utilization/GetAllClassroomBookingBetween",
+				helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+
+		for _, booking := range bookings {
+			utilization.BookingHours += booking.EndTime.Sub(booking.StartTime).Hours()
+		}
+
+		utilization.BookedHours = utilization.SessionHours + utilization.BookingHours
+		if availableHours > 0 {
+			utilization.Utilization = math.Round(utilization.BookedHours/availableHours*10000) / 100
+		}
+
+		response = append(response, utilization)
+	}
+
+	return response, nil
+}
+
+func classroomAvailableDailyHours() float64 {
+
+	dailyHours := viper.GetFloat64("classroom.available_daily_hours")
+	if dailyHours <= 0 {
+		dailyHours = CLASSROOM_AVAILABLE_DAILY_HOURS
+	}
+
+	return dailyHours
+}
+
+// classroomTeachingWeekdays returns the weekdays, 0 being Sunday, on which classrooms are available, from
+// classroom.teaching_days or Monday to Friday.
+func classroomTeachingWeekdays() map[int]bool {
+
+	days := viper.GetIntSlice("classroom.teaching_days")
+	if len(days) == 0 {
+		days = CLASSROOM_TEACHING_DAYS
+	}
+
+	weekdays := make(map[int]bool)
+	for _, day := range days {
+		if day >= 0 && day <= 6 {
+			weekdays[day] = true
+		}
+	}
+
+	return weekdays
+}
@@ -0,0 +1,255 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/gomodule/redigo/redis"
+	uuid "github.com/satori/go.uuid"
+	"net/http"
+	"school/helpers"
+	"school/models"
+)
+
+type (
+	CurriculumModule struct {
+		db     *sql.DB
+		cache  *redis.Pool
+		name   string
+		logger *helpers.Logger
+	}
+
+	CurriculumListParam struct {
+		ProgramID uuid.UUID `json:"program_id"`
+	}
+
+	CurriculumAddParam struct {
+		ProgramID   uuid.UUID `json:"program_id"`
+		SubjectID   uuid.UUID `json:"subject_id" valid:"required"`
+		Year        int       `json:"year"`
+		Trimester   int       `json:"trimester"`
+		IsElective  bool      `json:"is_elective"`
+		CreditHours int       `json:"credit_hours"`
+	}
+
+	CurriculumUpdateParam struct {
+		ID          uuid.UUID `json:"id"`
+		ProgramID   uuid.UUID `json:"program_id"`
+		Year        int       `json:"year"`
+		Trimester   int       `json:"trimester"`
+		IsElective  bool      `json:"is_elective"`
+		CreditHours int       `json:"credit_hours"`
+	}
+
+	CurriculumDeleteParam struct {
+		ID        uuid.UUID `json:"id"`
+		ProgramID uuid.UUID `json:"program_id"`
+	}
+)
+
+func NewCurriculumModule(db *sql.DB, cache *redis.Pool, logger *helpers.Logger) *CurriculumModule {
+	return &CurriculumModule{
+		db:     db,
+		cache:  cache,
+		name:   "module/curriculum",
+		logger: logger,
+	}
+}
+
+func (s CurriculumModule) ListByProgram(ctx context.Context, param CurriculumListParam) (
+	interface{}, *helpers.Error) {
+
+	curriculums, err := models.GetAllCurriculumByProgram(ctx, s.db, param.ProgramID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "ListByProgram/GetAllCurriculumByProgram",
+			helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	var curriculumsResponse []models.CurriculumResponse
+	for _, curriculum := range curriculums {
+		response, err := curriculum.Response(ctx, s.db, s.logger)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "ListByProgram/CurriculumResponse",
+				helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+		curriculumsResponse = append(curriculumsResponse, response)
+	}
+
+	return curriculumsResponse, nil
+}
+
+// Add places a subject in the program's degree plan. Credit hours default to the subject's own when not
+// given.
+func (s CurriculumModule) Add(ctx context.Context, param CurriculumAddParam) (interface{}, *helpers.Error) {
+
+	err := validateCurriculum(param.Year, param.Trimester, param.CreditHours)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Add/ValidationCurriculum", helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	_, err = models.GetOneProgram(ctx, s.db, param.ProgramID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Add/GetOneProgram", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	subject, err := models.GetOneSubject(ctx, s.db, param.SubjectID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Add/GetOneSubject", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	curriculums, err := models.GetAllCurriculumByProgram(ctx, s.db, param.ProgramID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Add/GetAllCurriculumByProgram", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	for _, curriculum := range curriculums {
+		if curriculum.SubjectID == param.SubjectID {
+			return nil, helpers.ErrorWrap(errors.New("Subject Already In Curriculum"), s.name,
+				"Add/ValidationDuplicate",
+				helpers.BadRequestMessage,
+				http.StatusBadRequest)
+		}
+	}
+
+	creditHours := param.CreditHours
+	if creditHours == 0 {
+		creditHours = subject.CreditHours
+	}
+
+	curriculum := models.CurriculumModel{
+		ProgramID:   param.ProgramID,
+		SubjectID:   param.SubjectID,
+		Year:        param.Year,
+		Trimester:   param.Trimester,
+		IsElective:  param.IsElective,
+		CreditHours: creditHours,
+		CreatedBy:   uuid.FromStringOrNil(ctx.Value("user_id").(string)),
+	}
+
+	err = curriculum.Insert(ctx, s.db)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Add/Insert", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	response, err := curriculum.Response(ctx, s.db, s.logger)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Add/CurriculumResponse", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	return response, nil
+}
+
+func (s CurriculumModule) Update(ctx context.Context, param CurriculumUpdateParam) (interface{}, *helpers.Error) {
+
+	err := validateCurriculum(param.Year, param.Trimester, param.CreditHours)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Update/ValidationCurriculum", helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	current, err := models.GetOneCurriculum(ctx, s.db, param.ID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Update/GetOneCurriculum", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	if current.ProgramID != param.ProgramID {
+		return nil, helpers.ErrorWrap(errors.New("Curriculum Does Not Belong To Program"), s.name,
+			"Update/ValidationProgram",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	creditHours := param.CreditHours
+	if creditHours == 0 {
+		subject, err := models.GetOneSubject(ctx, s.db, current.SubjectID)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "Update/GetOneSubject", helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+		creditHours = subject.CreditHours
+	}
+
+	curriculum := models.CurriculumModel{
+		ID:          param.ID,
+		Year:        param.Year,
+		Trimester:   param.Trimester,
+		IsElective:  param.IsElective,
+		CreditHours: creditHours,
+		UpdatedBy: uuid.NullUUID{
+			UUID:  uuid.FromStringOrNil(ctx.Value("user_id").(string)),
+			Valid: true,
+		},
+	}
+
+	err = curriculum.Update(ctx, s.db)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Update/Update", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	response, err := curriculum.Response(ctx, s.db, s.logger)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Update/CurriculumResponse", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	return response, nil
+}
+
+func (s CurriculumModule) Delete(ctx context.Context, param CurriculumDeleteParam) (interface{}, *helpers.Error) {
+
+	current, err := models.GetOneCurriculum(ctx, s.db, param.ID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Delete/GetOneCurriculum", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	if current.ProgramID != param.ProgramID {
+		return nil, helpers.ErrorWrap(errors.New("Curriculum Does Not Belong To Program"), s.name,
+			"Delete/ValidationProgram",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	curriculum := models.CurriculumModel{
+		ID: param.ID,
+		UpdatedBy: uuid.NullUUID{
+			UUID:  uuid.FromStringOrNil(ctx.Value("user_id").(string)),
+			Valid: true,
+		},
+	}
+
+	err = curriculum.Delete(ctx, s.db)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Delete/Delete", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	return nil, nil
+}
+
+func validateCurriculum(year int, trimester int, creditHours int) error {
+
+	if year < 1 {
+		return errors.New("Invalid Year")
+	}
+
+	if trimester < 1 || trimester > 3 {
+		return errors.New("Invalid Trimester")
+	}
+
+	if creditHours < 0 {
+		return errors.New("Invalid Credit Hours")
+	}
+
+	return nil
+}
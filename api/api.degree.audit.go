@@ -0,0 +1,232 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	uuid "github.com/satori/go.uuid"
+	"net/http"
+	"school/helpers"
+	"school/models"
+)
+
+const (
+	DEGREE_AUDIT_PASSED      = "passed"
+	DEGREE_AUDIT_IN_PROGRESS = "in_progress"
+	DEGREE_AUDIT_REMAINING   = "remaining"
+)
+
+type (
+	DegreeAuditParam struct {
+		StudentID uuid.UUID `json:"student_id"`
+	}
+
+	DegreeAuditSubjectResponse struct {
+		CurriculumID uuid.UUID `json:"curriculum_id"`
+		SubjectID    uuid.UUID `json:"subject_id"`
+		SubjectName  string    `json:"subject_name"`
+		Year         int       `json:"year"`
+		Trimester    int       `json:"trimester"`
+		IsElective   bool      `json:"is_elective"`
+		CreditHours  int       `json:"credit_hours"`
+		Status       string    `json:"status"`
+		Grade        string    `json:"grade,omitempty"`
+	}
+
+	DegreeAuditResponse struct {
+		Student                 models.StudentResponse       `json:"student"`
+		RequiredCredit          int                          `json:"required_credit"`
+		RequiredCreditEarned    int                          `json:"required_credit_earned"`
+		ElectiveCredit          int                          `json:"elective_credit"`
+		ElectiveCreditEarned    int                          `json:"elective_credit_earned"`
+		RemainingCredit         int                          `json:"remaining_credit"`
+		RemainingElectiveCredit int                          `json:"remaining_elective_credit"`
+		IsComplete              bool                         `json:"is_complete"`
+		Subjects                []DegreeAuditSubjectResponse `json:"subjects"`
+		Remaining               []DegreeAuditSubjectResponse `json:"remaining"`
+	}
+)
+
+// DegreeAudit compares the student's passed results against the curriculum of their program. Required
+// subjects that are not passed are reported as remaining, and elective credit counts towards the
+// program's elective credit.
+func (s CurriculumModule) DegreeAudit(ctx context.Context, param DegreeAuditParam) (interface{}, *helpers.Error) {
+
+	student, err := models.GetOneStudent(ctx, s.db, param.StudentID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "DegreeAudit/GetOneStudent", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	studentResponse, err := student.Response(ctx, s.db, s.logger)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "DegreeAudit/StudentResponse", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	response, err := buildDegreeAudit(ctx, s.db, student)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "DegreeAudit/buildDegreeAudit", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	response.Student = studentResponse
+
+	return response, nil
+}
+
+func (s CurriculumModule) DegreeAuditByOneStudent(ctx context.Context) (interface{}, *helpers.Error) {
+
+	studentID := uuid.FromStringOrNil(ctx.Value("user_id").(string))
+
+	return s.DegreeAudit(ctx, DegreeAuditParam{StudentID: studentID})
+}
+
+// buildDegreeAudit is shared by DegreeAudit and the graduation check. The student is left for the caller to
+// fill in.
+func buildDegreeAudit(ctx context.Context, db *sql.DB, student models.StudentModel) (DegreeAuditResponse, error) {
+
+	program, err := models.GetOneProgram(ctx, db, student.ProgramID)
+	if err != nil {
+		return DegreeAuditResponse{}, err
+	}
+
+	curriculums, err := models.GetAllCurriculumByProgram(ctx, db, program.ID)
+	if err != nil {
+		return DegreeAuditResponse{}, err
+	}
+
+	transcripts, err := models.GetAllTranscriptByStudent(ctx, db, student.ID)
+	if err != nil {
+		return DegreeAuditResponse{}, err
+	}
+
+	passedGrades := make(map[uuid.UUID]string)
+	for _, transcript := range transcripts {
+		if isTranscriptPassed(transcript) {
+			passedGrades[transcript.SubjectID] = transcript.Grade
+		}
+	}
+
+	inProgress, err := inProgressSubjects(ctx, db, student.ID)
+	if err != nil {
+		return DegreeAuditResponse{}, err
+	}
+
+	response := DegreeAuditResponse{
+		ElectiveCredit: program.ElectiveCredit,
+	}
+
+	for _, curriculum := range curriculums {
+		subject, err := models.GetOneSubject(ctx, db, curriculum.SubjectID)
+		if err != nil {
+			return DegreeAuditResponse{}, err
+		}
+
+		auditSubject := DegreeAuditSubjectResponse{
+			CurriculumID: curriculum.ID,
+			SubjectID:    curriculum.SubjectID,
+			SubjectName:  subject.Name,
+			Year:         curriculum.Year,
+			Trimester:    curriculum.Trimester,
+			IsElective:   curriculum.IsElective,
+			CreditHours:  curriculum.CreditHours,
+			Status:       DEGREE_AUDIT_REMAINING,
+		}
+
+		grade, passed := passedGrades[curriculum.SubjectID]
+		switch {
+		case passed:
+			auditSubject.Status = DEGREE_AUDIT_PASSED
+			auditSubject.Grade = grade
+		case inProgress[curriculum.SubjectID]:
+			auditSubject.Status = DEGREE_AUDIT_IN_PROGRESS
+		}
+
+		if !curriculum.IsElective {
+			response.RequiredCredit += curriculum.CreditHours
+		}
+
+		switch {
+		case passed && curriculum.IsElective:
+			response.ElectiveCreditEarned += curriculum.CreditHours
+		case passed:
+			response.RequiredCreditEarned += curriculum.CreditHours
+		case !curriculum.IsElective:
+			response.Remaining = append(response.Remaining, auditSubject)
+		}
+
+		response.Subjects = append(response.Subjects, auditSubject)
+	}
+
+	if response.ElectiveCreditEarned < response.ElectiveCredit {
+		response.RemainingElectiveCredit = response.ElectiveCredit - response.ElectiveCreditEarned
+	}
+
+	response.RemainingCredit = response.RequiredCredit - response.RequiredCreditEarned +
+		response.RemainingElectiveCredit
+	response.IsComplete = len(curriculums) > 0 && response.RemainingCredit == 0 && len(response.Remaining) == 0
+
+	return response, nil
+}
+
+// inProgressSubjects returns the subjects the student is enrolled in whose results are not published yet.
+func inProgressSubjects(ctx context.Context, db *sql.DB, studentID uuid.UUID) (map[uuid.UUID]bool, error) {
+
+	studentEnrolls, err := models.GetAllStudentEnrollByStudent(ctx, db, helpers.Filter{
+		FilterOption: helpers.FilterOption{
+			Limit:  999,
+			Offset: 0,
+		},
+		StudentID: studentID,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	inProgress := make(map[uuid.UUID]bool)
+	for _, studentEnroll := range studentEnrolls {
+		results, err := models.GetAllResultByStudentEnroll(ctx, db, helpers.Filter{
+			FilterOption: helpers.FilterOption{
+				Limit:  999,
+				Offset: 0,
+			},
+			StudentEnrollID: studentEnroll.ID,
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, result := range results {
+			if result.IsDelete || result.Status == models.RESULT_PUBLISHED ||
+				result.Grade == models.RESULT_GRADE_WITHDRAWN {
+				continue
+			}
+
+			session, err := models.GetOneSession(ctx, db, studentEnroll.SessionID)
+			if err != nil {
+				return nil, err
+			}
+
+			inProgress[session.SubjectID] = true
+		}
+	}
+
+	return inProgress, nil
+}
+
+// isTranscriptPassed reports whether a published result passes its subject: at or above the pass mark of
+// the scheme that graded it, or any grade but F on the legacy scale.
+func isTranscriptPassed(transcript models.TranscriptModel) bool {
+
+	if transcript.Grade == models.RESULT_GRADE_WITHDRAWN {
+		return false
+	}
+
+	if transcript.GradingSchemeID.Valid {
+		return transcript.Marks >= transcript.PassMark
+	}
+
+	return transcript.Grade != "F"
+}
@@ -0,0 +1,534 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/gomodule/redigo/redis"
+	uuid "github.com/satori/go.uuid"
+	"net/http"
+	"school/helpers"
+	"school/models"
+	"school/util"
+	"strings"
+)
+
+type (
+	GradingSchemeModule struct {
+		db     *sql.DB
+		cache  *redis.Pool
+		name   string
+		logger *helpers.Logger
+	}
+
+	GradingSchemeDetailParam struct {
+		ID uuid.UUID `json:"id"`
+	}
+
+	GradingBandParam struct {
+		Grade      string  `json:"grade" valid:"required"`
+		MinMarks   int     `json:"min_marks"`
+		GradePoint float64 `json:"grade_point"`
+	}
+
+	GradingSchemeAddParam struct {
+		Name        string             `json:"name" valid:"required"`
+		Description string             `json:"description"`
+		PassMark    int                `json:"pass_mark"`
+		IsPassFail  bool               `json:"is_pass_fail"`
+		IsDefault   bool               `json:"is_default"`
+		Bands       []GradingBandParam `json:"bands"`
+	}
+
+	GradingSchemeUpdateParam struct {
+		ID          uuid.UUID          `json:"id"`
+		Name        string             `json:"name" valid:"required"`
+		Description string             `json:"description"`
+		PassMark    int                `json:"pass_mark"`
+		IsPassFail  bool               `json:"is_pass_fail"`
+		IsDefault   bool               `json:"is_default"`
+		Bands       []GradingBandParam `json:"bands"`
+	}
+
+	GradingSchemeDeleteParam struct {
+		ID uuid.UUID `json:"id"`
+	}
+
+	GradingSchemeRegradeParam struct {
+		ID       uuid.UUID `json:"id"`
+		IntakeID uuid.UUID `json:"intake_id" valid:"required"`
+		DryRun   bool      `json:"dry_run"`
+		Force    bool      `json:"force"`
+	}
+
+	GradingSchemeRegradeResultResponse struct {
+		ResultID      uuid.UUID `json:"result_id"`
+		Marks         int       `json:"marks"`
+		OldGrade      string    `json:"old_grade"`
+		NewGrade      string    `json:"new_grade"`
+		OldGradePoint float64   `json:"old_grade_point"`
+		NewGradePoint float64   `json:"new_grade_point"`
+	}
+
+	GradingSchemeRegradeResponse struct {
+		IntakeID uuid.UUID                            `json:"intake_id"`
+		DryRun   bool                                 `json:"dry_run"`
+		Total    int                                  `json:"total"`
+		Changed  int                                  `json:"changed"`
+		Locked   int                                  `json:"locked"`
+		Results  []GradingSchemeRegradeResultResponse `json:"results"`
+	}
+)
+
+func NewGradingSchemeModule(db *sql.DB, cache *redis.Pool, logger *helpers.Logger) *GradingSchemeModule {
+	return &GradingSchemeModule{
+		db:     db,
+		cache:  cache,
+		name:   "module/gradingScheme",
+		logger: logger,
+	}
+}
+
+func (s GradingSchemeModule) List(ctx context.Context, filter helpers.Filter) (interface{}, *helpers.Error) {
+	gradingSchemes, err := models.GetAllGradingScheme(ctx, s.db, filter)
+
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "List/GetAllGradingScheme", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	var gradingSchemesResponse []models.GradingSchemeResponse
+	for _, gradingScheme := range gradingSchemes {
+		response, err := gradingScheme.Response(ctx, s.db, s.logger)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "List/GradingSchemeResponse", helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+		gradingSchemesResponse = append(gradingSchemesResponse, response)
+	}
+
+	return gradingSchemesResponse, nil
+}
+
+func (s GradingSchemeModule) Detail(ctx context.Context, param GradingSchemeDetailParam) (interface{}, *helpers.Error) {
+	gradingScheme, err := models.GetOneGradingScheme(ctx, s.db, param.ID)
+
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Detail/GetOneGradingScheme", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	response, err := gradingScheme.Response(ctx, s.db, s.logger)
+
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Detail/GradingSchemeResponse", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	return response, nil
+}
+
+func (s GradingSchemeModule) Add(ctx context.Context, param GradingSchemeAddParam) (interface{}, *helpers.Error) {
+
+	err := validateGradingBands(param.PassMark, param.IsPassFail, param.Bands)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Add/ValidationBand", helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	userID := uuid.FromStringOrNil(ctx.Value("user_id").(string))
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Add/BeginTx", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+	defer tx.Rollback()
+
+	gradingScheme := models.GradingSchemeModel{
+		Name:        param.Name,
+		Description: param.Description,
+		PassMark:    param.PassMark,
+		IsPassFail:  param.IsPassFail,
+		IsDefault:   param.IsDefault,
+		CreatedBy:   userID,
+	}
+
+	err = gradingScheme.Insert(ctx, tx)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Add/Insert", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	err = insertGradingBands(ctx, tx, gradingScheme, param.Bands, userID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Add/insertGradingBands", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	if gradingScheme.IsDefault {
+		err = gradingScheme.ClearDefault(ctx, tx)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "Add/ClearDefault", helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Add/Commit", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	response, err := gradingScheme.Response(ctx, s.db, s.logger)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Add/GradingSchemeResponse", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	return response, nil
+}
+
+func (s GradingSchemeModule) Update(ctx context.Context, param GradingSchemeUpdateParam) (interface{}, *helpers.Error) {
+
+	err := validateGradingBands(param.PassMark, param.IsPassFail, param.Bands)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Update/ValidationBand", helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	userID := uuid.FromStringOrNil(ctx.Value("user_id").(string))
+	updatedBy := uuid.NullUUID{
+		UUID:  userID,
+		Valid: true,
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Update/BeginTx", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+	defer tx.Rollback()
+
+	gradingScheme := models.GradingSchemeModel{
+		ID:          param.ID,
+		Name:        param.Name,
+		Description: param.Description,
+		PassMark:    param.PassMark,
+		IsPassFail:  param.IsPassFail,
+		IsDefault:   param.IsDefault,
+		UpdatedBy:   updatedBy,
+	}
+
+	err = gradingScheme.Update(ctx, tx)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Update/Update", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	err = models.DeleteGradingBandByScheme(ctx, tx, gradingScheme.ID, updatedBy)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Update/DeleteGradingBandByScheme", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	err = insertGradingBands(ctx, tx, gradingScheme, param.Bands, userID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Update/insertGradingBands", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	if gradingScheme.IsDefault {
+		err = gradingScheme.ClearDefault(ctx, tx)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "Update/ClearDefault", helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Update/Commit", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	response, err := gradingScheme.Response(ctx, s.db, s.logger)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Update/GradingSchemeResponse", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	return response, nil
+}
+
+func (s GradingSchemeModule) Delete(ctx context.Context, param GradingSchemeDeleteParam) (interface{}, *helpers.Error) {
+
+	gradingScheme := models.GradingSchemeModel{
+		ID: param.ID,
+		UpdatedBy: uuid.NullUUID{
+			UUID:  uuid.FromStringOrNil(ctx.Value("user_id").(string)),
+			Valid: true,
+		},
+	}
+
+	err := gradingScheme.Delete(ctx, s.db)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Delete/Delete", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	return nil, nil
+}
+
+// Regrade recomputes the grade of every graded result in the intake that resolves to this scheme.
+// With DryRun set the changes are only reported. Results that already left the lecturer are counted as
+// locked and kept, unless Force is set; forced changes are recorded in the history of their session.
+func (s GradingSchemeModule) Regrade(ctx context.Context, param GradingSchemeRegradeParam) (
+	interface{}, *helpers.Error) {
+
+	gradingScheme, err := models.GetOneGradingScheme(ctx, s.db, param.ID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Regrade/GetOneGradingScheme", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	results, err := models.GetAllResultByIntake(ctx, s.db, param.IntakeID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Regrade/GetAllResultByIntake", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	response := GradingSchemeRegradeResponse{
+		IntakeID: param.IntakeID,
+		DryRun:   param.DryRun,
+	}
+
+	var changed []models.ResultModel
+	var forced []models.ResultStatusHistoryModel
+	forcedSessions := make(map[string]bool)
+	for _, result := range results {
+		if result.Grade == models.RESULT_GRADE_WITHDRAWN {
+			continue
+		}
+
+		studentEnroll, err := models.GetOneStudentEnroll(ctx, s.db, result.StudentEnrollID)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "Regrade/GetOneStudentEnroll", helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+
+		regraded := result
+		err = gradeResult(ctx, s.db, studentEnroll.SessionID, &regraded)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "Regrade/gradeResult", helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+
+		if regraded.GradingSchemeID.UUID != param.ID {
+			continue
+		}
+
+		response.Total++
+
+		if regraded.Grade == result.Grade && regraded.GradePoint == result.GradePoint &&
+			regraded.GradingSchemeID == result.GradingSchemeID {
+			continue
+		}
+
+		if !isResultEditable(result.Status) {
+			if !param.Force {
+				response.Locked++
+				continue
+			}
+
+			key := studentEnroll.SessionID.String() + ":" + result.Status
+			if !forcedSessions[key] {
+				forcedSessions[key] = true
+				forced = append(forced, models.ResultStatusHistoryModel{
+					SessionID:  studentEnroll.SessionID,
+					FromStatus: result.Status,
+					ToStatus:   result.Status,
+					Comment:    "Regraded With Grading Scheme " + gradingScheme.Name,
+				})
+			}
+		}
+
+		response.Results = append(response.Results, GradingSchemeRegradeResultResponse{
+			ResultID:      result.ID,
+			Marks:         result.Marks,
+			OldGrade:      result.Grade,
+			NewGrade:      regraded.Grade,
+			OldGradePoint: result.GradePoint,
+			NewGradePoint: regraded.GradePoint,
+		})
+		changed = append(changed, regraded)
+	}
+
+	response.Changed = len(changed)
+
+	if param.DryRun || len(changed) == 0 {
+		return response, nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Regrade/BeginTx", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+	defer tx.Rollback()
+
+	for _, result := range changed {
+		result.UpdatedBy = uuid.NullUUID{
+			UUID:  uuid.FromStringOrNil(ctx.Value("user_id").(string)),
+			Valid: true,
+		}
+
+		err = result.Update(ctx, tx)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "Regrade/Update", helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+	}
+
+	for _, history := range forced {
+		history.CreatedBy = uuid.FromStringOrNil(ctx.Value("user_id").(string))
+
+		err = history.Insert(ctx, tx)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "Regrade/ResultStatusHistoryInsert",
+				helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Regrade/Commit", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	return response, nil
+}
+
+// validateGradingBands checks the bands cover 0 to 100 without duplicates and that the pass mark starts a band,
+// so the grade letter agrees with the pass mark. On a graded scheme the pass mark starts the lowest band that
+// earns grade points.
+func validateGradingBands(passMark int, isPassFail bool, bands []GradingBandParam) error {
+
+	if passMark < 0 || passMark > 100 {
+		return errors.New("Pass Mark Must Be Between 0 And 100")
+	}
+
+	if len(bands) == 0 {
+		return errors.New("Grading Scheme Requires At Least One Band")
+	}
+
+	grades := make(map[string]bool)
+	minMarks := make(map[int]bool)
+	for _, band := range bands {
+		grade := strings.ToUpper(strings.TrimSpace(band.Grade))
+		if grade == "" {
+			return errors.New("Band Grade Is Required")
+		}
+
+		if band.MinMarks < 0 || band.MinMarks > 100 {
+			return errors.New("Band Minimum Marks Must Be Between 0 And 100")
+		}
+
+		if band.GradePoint < 0 {
+			return errors.New("Band Grade Point Must Not Be Negative")
+		}
+
+		if grades[grade] || minMarks[band.MinMarks] {
+			return errors.New("Band Grades And Minimum Marks Must Be Unique")
+		}
+
+		grades[grade] = true
+		minMarks[band.MinMarks] = true
+	}
+
+	if !minMarks[0] {
+		return errors.New("Grading Scheme Requires A Band Starting At 0 Marks")
+	}
+
+	if !minMarks[passMark] {
+		return errors.New("Pass Mark Must Start A Band")
+	}
+
+	if isPassFail {
+		return nil
+	}
+
+	for _, band := range bands {
+		if (band.MinMarks >= passMark) != (band.GradePoint > 0) {
+			return errors.New("Bands From The Pass Mark Must Earn Grade Points And Bands Below It Must Not")
+		}
+	}
+
+	return nil
+}
+
+func insertGradingBands(ctx context.Context, tx *sql.Tx, gradingScheme models.GradingSchemeModel,
+	bands []GradingBandParam, userID uuid.UUID) error {
+
+	for _, param := range bands {
+		band := models.GradingBandModel{
+			GradingSchemeID: gradingScheme.ID,
+			Grade:           strings.ToUpper(strings.TrimSpace(param.Grade)),
+			MinMarks:        param.MinMarks,
+			GradePoint:      param.GradePoint,
+			CreatedBy:       userID,
+		}
+
+		err := band.Insert(ctx, tx)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// gradeResult sets the grade, grade point and scheme of the result from its marks using the scheme
+// resolved for the session. Without any scheme it falls back to the legacy util.GetGrade scale.
+func gradeResult(ctx context.Context, db *sql.DB, sessionID uuid.UUID, result *models.ResultModel) error {
+
+	gradingScheme, err := models.GetGradingSchemeBySession(ctx, db, sessionID)
+	if err == sql.ErrNoRows {
+		result.Grade = util.GetGrade(result.Marks)
+		result.GradePoint = util.GetGradePoint(result.Grade)
+		result.GradingSchemeID = uuid.NullUUID{}
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	bands, err := models.GetAllGradingBandByScheme(ctx, db, gradingScheme.ID)
+	if err != nil {
+		return err
+	}
+
+	return gradeFromScheme(gradingScheme, bands, result)
+}
+
+// gradeFromScheme sets the grade and grade point of the result's marks from the bands of the grading scheme,
+// ordered by minimum marks from the highest as GetAllGradingBandByScheme returns them.
+func gradeFromScheme(gradingScheme models.GradingSchemeModel, bands []models.GradingBandModel,
+	result *models.ResultModel) error {
+
+	band, ok := models.GetGradingBand(bands, result.Marks)
+	if !ok {
+		return errors.New("Marks Not Covered By Grading Scheme")
+	}
+
+	result.Grade = band.Grade
+	result.GradePoint = band.GradePoint
+	result.GradingSchemeID = uuid.NullUUID{
+		UUID:  gradingScheme.ID,
+		Valid: true,
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package api
+
+import (
+	uuid "github.com/satori/go.uuid"
+	"school/models"
+	"testing"
+)
+
+func TestGradeFromScheme(t *testing.T) {
+
+	gradingScheme := models.GradingSchemeModel{ID: uuid.NewV4(), PassMark: 50}
+	bands := []models.GradingBandModel{
+		{Grade: "A", MinMarks: 80, GradePoint: 4},
+		{Grade: "B", MinMarks: 65, GradePoint: 3},
+		{Grade: "C", MinMarks: 50, GradePoint: 2},
+		{Grade: "F", MinMarks: 0, GradePoint: 0},
+	}
+
+	cases := []struct {
+		marks      int
+		grade      string
+		gradePoint float64
+	}{
+		{100, "A", 4},
+		{80, "A", 4},
+		{79, "B", 3},
+		{50, "C", 2},
+		{49, "F", 0},
+		{0, "F", 0},
+	}
+
+	for _, c := range cases {
+		result := models.ResultModel{Marks: c.marks}
+
+		err := gradeFromScheme(gradingScheme, bands, &result)
+		if err != nil {
+			t.Fatalf("gradeFromScheme(%d) returned %v", c.marks, err)
+		}
+
+		if result.Grade != c.grade || result.GradePoint != c.gradePoint {
+			t.Fatalf("gradeFromScheme(%d) = %s %v, expected %s %v", c.marks, result.Grade, result.GradePoint,
+				c.grade, c.gradePoint)
+		}
+
+		if !result.GradingSchemeID.Valid || result.GradingSchemeID.UUID != gradingScheme.ID {
+			t.Fatalf("gradeFromScheme(%d) did not record the grading scheme", c.marks)
+		}
+	}
+
+	result := models.ResultModel{Marks: 10}
+	err := gradeFromScheme(gradingScheme, bands[:3], &result)
+	if err == nil {
+		t.Fatal("gradeFromScheme expected an error for marks below every band")
+	}
+
+}
+
+func TestValidateGradingBands(t *testing.T) {
+
+	bands := []GradingBandParam{
+		{Grade: "A", MinMarks: 80, GradePoint: 4},
+		{Grade: "C", MinMarks: 50, GradePoint: 2},
+		{Grade: "F", MinMarks: 0, GradePoint: 0},
+	}
+
+	passFail := []GradingBandParam{
+		{Grade: "P", MinMarks: 40},
+		{Grade: "F", MinMarks: 0},
+	}
+
+	cases := []struct {
+		name       string
+		passMark   int
+		isPassFail bool
+		bands      []GradingBandParam
+		valid      bool
+	}{
+		{"valid", 50, false, bands, true},
+		{"pass fail without grade points", 40, true, passFail, true},
+		{"pass mark out of range", 101, false, bands, false},
+		{"no bands", 50, false, nil, false},
+		{"pass mark not starting a band", 60, false, bands, false},
+		{"no band at 0", 50, false, bands[:2], false},
+		{"passing band without grade points", 40, false, passFail, false},
+		{"failing band with grade points", 50, false, []GradingBandParam{
+			{Grade: "A", MinMarks: 50, GradePoint: 4},
+			{Grade: "D", MinMarks: 0, GradePoint: 1},
+		}, false},
+		{"duplicate grade", 50, false, []GradingBandParam{
+			{Grade: "A", MinMarks: 50, GradePoint: 4},
+			{Grade: "a", MinMarks: 0, GradePoint: 0},
+		}, false},
+		{"duplicate minimum marks", 50, false, []GradingBandParam{
+			{Grade: "A", MinMarks: 50, GradePoint: 4},
+			{Grade: "B", MinMarks: 50, GradePoint: 3},
+			{Grade: "F", MinMarks: 0, GradePoint: 0},
+		}, false},
+		{"negative grade point", 50, false, []GradingBandParam{
+			{Grade: "A", MinMarks: 50, GradePoint: 4},
+			{Grade: "F", MinMarks: 0, GradePoint: -1},
+		}, false},
+	}
+
+	for _, c := range cases {
+		err := validateGradingBands(c.passMark, c.isPassFail, c.bands)
+		if (err == nil) != c.valid {
+			t.Fatalf("validateGradingBands(%s) = %v, expected valid %v", c.name, err, c.valid)
+		}
+	}
+
+}
@@ -0,0 +1,320 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/gomodule/redigo/redis"
+	uuid "github.com/satori/go.uuid"
+	"github.com/spf13/viper"
+	"net/http"
+	"school/helpers"
+	"school/models"
+	"school/util"
+	"time"
+)
+
+// GRADUATION_MIN_CGPA is the minimum CGPA to graduate when graduation.min_cgpa is not configured.
+const GRADUATION_MIN_CGPA = 2.0
+
+type (
+	GraduationModule struct {
+		db     *sql.DB
+		cache  *redis.Pool
+		name   string
+		logger *helpers.Logger
+	}
+
+	GraduationEligibilityParam struct {
+		IntakeID  uuid.UUID `json:"intake_id"`
+		ProgramID uuid.UUID `json:"program_id"`
+	}
+
+	GraduationConfirmParam struct {
+		IntakeID    uuid.UUID   `json:"intake_id"`
+		StudentIDs  []uuid.UUID `json:"student_ids"`
+		GraduatedAt time.Time   `json:"graduated_at"`
+	}
+
+	GraduationCandidateResponse struct {
+		StudentID         uuid.UUID `json:"student_id"`
+		StudentCode       string    `json:"student_code"`
+		Name              string    `json:"name"`
+		ProgramID         uuid.UUID `json:"program_id"`
+		Status            string    `json:"status"`
+		CreditHours       int       `json:"credit_hours"`
+		CGPA              float64   `json:"cgpa"`
+		Classification    string    `json:"classification"`
+		RemainingCredit   int       `json:"remaining_credit"`
+		RemainingSubjects int       `json:"remaining_subjects"`
+		IsGraduated       bool      `json:"is_graduated"`
+		IsEligible        bool      `json:"is_eligible"`
+		Reasons           []string  `json:"reasons,omitempty"`
+	}
+
+	GraduationEligibilityResponse struct {
+		IntakeID uuid.UUID                     `json:"intake_id"`
+		MinCGPA  float64                       `json:"min_cgpa"`
+		Total    int                           `json:"total"`
+		Eligible int                           `json:"eligible"`
+		Students []GraduationCandidateResponse `json:"students"`
+	}
+)
+
+func NewGraduationModule(db *sql.DB, cache *redis.Pool, logger *helpers.Logger) *GraduationModule {
+	return &GraduationModule{
+		db:     db,
+		cache:  cache,
+		name:   "module/graduation",
+		logger: logger,
+	}
+}
+
+func (s GraduationModule) List(ctx context.Context, filter helpers.Filter) (interface{}, *helpers.Error) {
+
+	graduations, err := models.GetAllGraduation(ctx, s.db, filter)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "List/GetAllGraduation", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	var graduationsResponse []models.GraduationResponse
+	for _, graduation := range graduations {
+		response, err := graduation.Response(ctx, s.db, s.logger)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "List/GraduationResponse", helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+		graduationsResponse = append(graduationsResponse, response)
+	}
+
+	return graduationsResponse, nil
+}
+
+// Eligibility evaluates every student enrolled in the intake against the credit, CGPA and required subject
+// rules of their program.
+func (s GraduationModule) Eligibility(ctx context.Context, param GraduationEligibilityParam) (
+	interface{}, *helpers.Error) {
+
+	studentIDs, err := models.GetAllGraduationCandidateByIntake(ctx, s.db, param.IntakeID, param.ProgramID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Eligibility/GetAllGraduationCandidateByIntake",
+			helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	response := GraduationEligibilityResponse{
+		IntakeID: param.IntakeID,
+		MinCGPA:  graduationMinCGPA(),
+	}
+
+	for _, studentID := range studentIDs {
+		candidate, err := s.evaluate(ctx, studentID)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "Eligibility/evaluate", helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+
+		response.Total++
+		if candidate.IsEligible {
+			response.Eligible++
+		}
+
+		response.Students = append(response.Students, candidate)
+	}
+
+	return response, nil
+}
+
+// Confirm graduates the given students of the intake and moves them to the graduated status. Every student
+// is evaluated again and nothing is recorded when one of them is not eligible.
+func (s GraduationModule) Confirm(ctx context.Context, param GraduationConfirmParam) (interface{}, *helpers.Error) {
+
+	if len(param.StudentIDs) == 0 {
+		return nil, helpers.ErrorWrap(errors.New("No Students To Graduate"), s.name, "Confirm/ValidationStudent",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	studentIDs, err := models.GetAllGraduationCandidateByIntake(ctx, s.db, param.IntakeID, uuid.Nil)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Confirm/GetAllGraduationCandidateByIntake",
+			helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	inIntake := make(map[uuid.UUID]bool)
+	for _, studentID := range studentIDs {
+		inIntake[studentID] = true
+	}
+
+	graduatedAt := param.GraduatedAt
+	if graduatedAt.IsZero() {
+		graduatedAt = time.Now()
+	}
+
+	var candidates []GraduationCandidateResponse
+	var ineligible []GraduationCandidateResponse
+	seen := make(map[uuid.UUID]bool)
+	for _, studentID := range param.StudentIDs {
+		if seen[studentID] {
+			continue
+		}
+
+		seen[studentID] = true
+
+		candidate, err := s.evaluate(ctx, studentID)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "Confirm/evaluate", helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+
+		if !inIntake[studentID] {
+			candidate.IsEligible = false
+			candidate.Reasons = append(candidate.Reasons, "Student Not Enrolled In Intake")
+		}
+
+		if !candidate.IsEligible {
+			ineligible = append(ineligible, candidate)
+		}
+
+		candidates = append(candidates, candidate)
+	}
+
+	if len(ineligible) > 0 {
+		return ineligible, helpers.ErrorWrap(errors.New("Students Not Eligible To Graduate"), s.name,
+			"Confirm/ValidationEligible",
+			helpers.GraduationNotEligibleMessage,
+			http.StatusUnprocessableEntity)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Confirm/BeginTx", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	defer tx.Rollback()
+
+	var graduations []models.GraduationModel
+	for _, candidate := range candidates {
+		graduation := models.GraduationModel{
+			StudentID:      candidate.StudentID,
+			IntakeID:       param.IntakeID,
+			CGPA:           candidate.CGPA,
+			CreditHours:    candidate.CreditHours,
+			Classification: candidate.Classification,
+			GraduatedAt:    graduatedAt,
+			CreatedBy:      uuid.FromStringOrNil(ctx.Value("user_id").(string)),
+		}
+
+		err = graduation.Insert(ctx, tx)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "Confirm/Insert", helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+
+		errStatus := transitionStudentStatus(ctx, tx, s.name, "Confirm", candidate.StudentID, candidate.Status,
+			models.STUDENT_GRADUATED, "Graduated", graduatedAt)
+		if errStatus != nil {
+			return nil, errStatus
+		}
+
+		graduations = append(graduations, graduation)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Confirm/Commit", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	var graduationsResponse []models.GraduationResponse
+	for _, graduation := range graduations {
+		response, err := graduation.Response(ctx, s.db, s.logger)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "Confirm/GraduationResponse", helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+		graduationsResponse = append(graduationsResponse, response)
+	}
+
+	return graduationsResponse, nil
+}
+
+func (s GraduationModule) evaluate(ctx context.Context, studentID uuid.UUID) (GraduationCandidateResponse, error) {
+
+	student, err := models.GetOneStudent(ctx, s.db, studentID)
+	if err != nil {
+		return GraduationCandidateResponse{}, err
+	}
+
+	transcripts, err := models.GetAllTranscriptByStudent(ctx, s.db, studentID)
+	if err != nil {
+		return GraduationCandidateResponse{}, err
+	}
+
+	transcript := buildTranscript(transcripts)
+
+	audit, err := buildDegreeAudit(ctx, s.db, student)
+	if err != nil {
+		return GraduationCandidateResponse{}, err
+	}
+
+	candidate := GraduationCandidateResponse{
+		StudentID:         student.ID,
+		StudentCode:       student.StudentCode,
+		Name:              student.Name,
+		ProgramID:         student.ProgramID,
+		Status:            student.Status,
+		CreditHours:       transcript.CreditHours,
+		CGPA:              transcript.CGPA,
+		Classification:    util.GetClassification(transcript.CGPA),
+		RemainingCredit:   audit.RemainingCredit,
+		RemainingSubjects: len(audit.Remaining),
+	}
+
+	_, err = models.GetOneGraduationByStudent(ctx, s.db, studentID)
+	if err != nil && err != sql.ErrNoRows {
+		return GraduationCandidateResponse{}, err
+	}
+
+	candidate.IsGraduated = err == nil
+
+	switch {
+	case candidate.IsGraduated:
+		candidate.Reasons = append(candidate.Reasons, "Student Has Already Graduated")
+	case student.Status != models.STUDENT_ENROLLED:
+		candidate.Reasons = append(candidate.Reasons, "Student Is Not Enrolled")
+	}
+
+	if len(audit.Subjects) == 0 {
+		candidate.Reasons = append(candidate.Reasons, "Program Has No Curriculum")
+	}
+
+	if candidate.RemainingSubjects > 0 {
+		candidate.Reasons = append(candidate.Reasons, "Required Subjects Not Passed")
+	}
+
+	if candidate.RemainingCredit > 0 {
+		candidate.Reasons = append(candidate.Reasons, "Credit Requirement Not Met")
+	}
+
+	if candidate.CGPA < graduationMinCGPA() {
+		candidate.Reasons = append(candidate.Reasons, "CGPA Below Minimum")
+	}
+
+	candidate.IsEligible = len(candidate.Reasons) == 0
+
+	return candidate, nil
+}
+
+func graduationMinCGPA() float64 {
+
+	minCGPA := viper.GetFloat64("graduation.min_cgpa")
+	if minCGPA <= 0 {
+		minCGPA = GRADUATION_MIN_CGPA
+	}
+
+	return minCGPA
+}
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"github.com/gomodule/redigo/redis"
+	uuid "github.com/satori/go.uuid"
+	"net/http"
+	"school/helpers"
+	"school/models"
+	"time"
+)
+
+type (
+	HolidayModule struct {
+		db     *sql.DB
+		cache  *redis.Pool
+		name   string
+		logger *helpers.Logger
+	}
+
+	HolidayAddParam struct {
+		Date time.Time `json:"date"`
+		Name string    `json:"name" valid:"required"`
+	}
+
+	HolidayDeleteParam struct {
+		ID uuid.UUID `json:"id"`
+	}
+)
+
+func NewHolidayModule(db *sql.DB, cache *redis.Pool, logger *helpers.Logger) *HolidayModule {
+	return &HolidayModule{
+		db:     db,
+		cache:  cache,
+		name:   "module/holiday",
+		logger: logger,
+	}
+}
+
+func (s HolidayModule) List(ctx context.Context, filter helpers.Filter) (interface{}, *helpers.Error) {
+	holidays, err := models.GetAllHoliday(ctx, s.db, filter)
+
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "List/GetAllHoliday", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	var holidaysResponse []models.HolidayResponse
+	for _, holiday := range holidays {
+		holidaysResponse = append(holidaysResponse, holiday.Response())
+	}
+
+	return holidaysResponse, nil
+}
+
+func (s HolidayModule) Add(ctx context.Context, param HolidayAddParam) (interface{}, *helpers.Error) {
+
+	holiday := models.HolidayModel{
+		Date:      param.Date,
+		Name:      param.Name,
+		CreatedBy: uuid.FromStringOrNil(ctx.Value("user_id").(string)),
+	}
+
+	err := holiday.Insert(ctx, s.db)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Add/Insert", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	return holiday.Response(), nil
+}
+
+func (s HolidayModule) Delete(ctx context.Context, param HolidayDeleteParam) (interface{}, *helpers.Error) {
+
+	holiday := models.HolidayModel{
+		ID: param.ID,
+		UpdatedBy: uuid.NullUUID{
+			UUID:  uuid.FromStringOrNil(ctx.Value("user_id").(string)),
+			Valid: true,
+		},
+	}
+
+	err := holiday.Delete(ctx, s.db)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Delete/Delete", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	return nil, nil
+
+}
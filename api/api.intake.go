@@ -9,7 +9,6 @@ import (
 	"net/http"
 	"school/helpers"
 	"school/models"
-	"school/util"
 	"time"
 )
 
@@ -26,16 +25,18 @@ type (
 	}
 
 	IntakeAddParam struct {
-		Year      string    `json:"year"valid:"required"`
+		Year      string    `json:"year" valid:"required"`
 		Month     int       `json:"month" valid:"required"`
+		Trimester int       `json:"trimester" valid:"required"`
 		StartDate time.Time `json:"start_date"`
 		EndDate   time.Time `json:"end_date"`
 	}
 
 	IntakeUpdateParam struct {
 		ID        uuid.UUID `json:"id"`
-		Year      string    `json:"year"valid:"required"`
+		Year      string    `json:"year" valid:"required"`
 		Month     int       `json:"month" valid:"required"`
+		Trimester int       `json:"trimester" valid:"required"`
 		StartDate time.Time `json:"start_date"`
 		EndDate   time.Time `json:"end_date"`
 	}
@@ -83,16 +84,21 @@ func (s IntakeModule) Detail(ctx context.Context, param IntakeDetailParam) (inte
 
 func (s IntakeModule) Add(ctx context.Context, param IntakeAddParam) (interface{}, *helpers.Error) {
 
-	if param.Month != 4 && param.Month != 7 && param.Month != 11 {
+	if param.Month < 1 || param.Month > 12 {
 		return nil, helpers.ErrorWrap(errors.New("Invalid Month"), s.name, "Add/Month",
 			helpers.IncorrectMonthMessage,
 			http.StatusBadRequest)
 	}
 
-	trimester := util.GetTrimester(param.Month)
+	// The trimester is given by the admin, intakes no longer start in fixed months it could be inferred from.
+	if param.Trimester < 1 || param.Trimester > 3 {
+		return nil, helpers.ErrorWrap(errors.New("Invalid Trimester"), s.name, "Add/Trimester",
+			helpers.IncorrectTrimesterMessage,
+			http.StatusBadRequest)
+	}
 
 	intake := models.IntakeModel{
-		Trimester: trimester,
+		Trimester: param.Trimester,
 		Year:      param.Year,
 		Month:     param.Month,
 		StartDate: param.StartDate,
@@ -111,16 +117,21 @@ func (s IntakeModule) Add(ctx context.Context, param IntakeAddParam) (interface{
 
 func (s IntakeModule) Update(ctx context.Context, param IntakeUpdateParam) (interface{}, *helpers.Error) {
 
-	if param.Month != 4 && param.Month != 7 && param.Month != 11 {
+	if param.Month < 1 || param.Month > 12 {
 		return nil, helpers.ErrorWrap(errors.New("Invalid Month"), s.name, "Update/Month",
 			helpers.IncorrectMonthMessage,
 			http.StatusBadRequest)
 	}
-	trimester := util.GetTrimester(param.Month)
+
+	if param.Trimester < 1 || param.Trimester > 3 {
+		return nil, helpers.ErrorWrap(errors.New("Invalid Trimester"), s.name, "Update/Trimester",
+			helpers.IncorrectTrimesterMessage,
+			http.StatusBadRequest)
+	}
 
 	intake := models.IntakeModel{
 		ID:        param.ID,
-		Trimester: trimester,
+		Trimester: param.Trimester,
 		Year:      param.Year,
 		Month:     param.Month,
 		StartDate: param.StartDate,
@@ -117,7 +117,11 @@ func (s LecturerModule) Detail(ctx context.Context, param LecturerDetailParam) (
 
 func (s LecturerModule) Add(ctx context.Context, param LecturerAddParam) (interface{}, *helpers.Error) {
 
-	password := util.RandomString(12)
+	password, err := util.RandomPassword(12)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Add/RandomPassword", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
 
 	lecturer := models.LecturerModel{
 		Name:      param.Name,
@@ -130,7 +134,7 @@ func (s LecturerModule) Add(ctx context.Context, param LecturerAddParam) (interf
 		CreatedBy: uuid.FromStringOrNil(ctx.Value("user_id").(string)),
 	}
 
-	err := lecturer.Insert(ctx, s.db)
+	err = lecturer.Insert(ctx, s.db)
 	if err != nil {
 		return nil, helpers.ErrorWrap(err, s.name, "Add/Insert", helpers.InternalServerError,
 			http.StatusInternalServerError)
@@ -0,0 +1,197 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/gomodule/redigo/redis"
+	uuid "github.com/satori/go.uuid"
+	"net/http"
+	"school/helpers"
+	"school/models"
+)
+
+type (
+	NotificationModule struct {
+		db     *sql.DB
+		cache  *redis.Pool
+		name   string
+		logger *helpers.Logger
+	}
+
+	NotificationListParam struct {
+		Role   string `json:"role"`
+		Filter helpers.Filter
+	}
+
+	NotificationParam struct {
+		ID   uuid.UUID `json:"id"`
+		Role string    `json:"role"`
+	}
+
+	NotificationUnreadResponse struct {
+		Unread int `json:"unread"`
+	}
+
+	NotificationReadAllResponse struct {
+		Read int64 `json:"read"`
+	}
+)
+
+func NewNotificationModule(db *sql.DB, cache *redis.Pool, logger *helpers.Logger) *NotificationModule {
+	return &NotificationModule{
+		db:     db,
+		cache:  cache,
+		name:   "module/notification",
+		logger: logger,
+	}
+}
+
+// List returns the logged in user's notifications, newest first. Filter status "read" or "unread" to narrow
+// them down.
+func (s NotificationModule) List(ctx context.Context, param NotificationListParam) (interface{}, *helpers.Error) {
+
+	notifications, err := models.GetAllNotificationByUser(ctx, s.db,
+		uuid.FromStringOrNil(ctx.Value("user_id").(string)), param.Role, param.Filter)
+
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "List/GetAllNotificationByUser", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	var notificationsResponse []models.NotificationResponse
+	for _, notification := range notifications {
+		notificationsResponse = append(notificationsResponse, notification.Response())
+	}
+
+	return notificationsResponse, nil
+}
+
+func (s NotificationModule) UnreadCount(ctx context.Context, param NotificationParam) (interface{}, *helpers.Error) {
+
+	unread, err := models.GetCountUnreadNotificationByUser(ctx, s.db,
+		uuid.FromStringOrNil(ctx.Value("user_id").(string)), param.Role)
+
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "UnreadCount/GetCountUnreadNotificationByUser",
+			helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	return NotificationUnreadResponse{Unread: unread}, nil
+}
+
+// Read marks one of the logged in user's notifications as read. A notification of another user is reported
+// as not found.
+func (s NotificationModule) Read(ctx context.Context, param NotificationParam) (interface{}, *helpers.Error) {
+
+	notification := models.NotificationModel{
+		ID:     param.ID,
+		UserID: uuid.FromStringOrNil(ctx.Value("user_id").(string)),
+		Role:   param.Role,
+	}
+
+	err := notification.Read(ctx, s.db)
+	if err == sql.ErrNoRows {
+		return nil, helpers.ErrorWrap(errors.New("Notification Not Found"), s.name, "Read/ValidationNotification",
+			helpers.BadRequestMessage,
+			http.StatusNotFound)
+	}
+
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Read/Read", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	return notification.Response(), nil
+}
+
+func (s NotificationModule) ReadAll(ctx context.Context, param NotificationParam) (interface{}, *helpers.Error) {
+
+	read, err := models.ReadAllNotificationByUser(ctx, s.db,
+		uuid.FromStringOrNil(ctx.Value("user_id").(string)), param.Role)
+
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "ReadAll/ReadAllNotificationByUser", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	return NotificationReadAllResponse{Read: read}, nil
+}
+
+// notifyUsers records the same notification for every user. It runs inside the caller's transaction so the
+// notifications only appear when the change they describe is committed.
+func notifyUsers(ctx context.Context, db helpers.Queryer, userIDs []uuid.UUID, role string,
+	notificationType string, title string, body string, referenceID uuid.UUID) error {
+
+	for _, userID := range userIDs {
+		notification := models.NotificationModel{
+			UserID: userID,
+			Role:   role,
+			Type:   notificationType,
+			Title:  title,
+			Body:   body,
+			ReferenceID: uuid.NullUUID{
+				UUID:  referenceID,
+				Valid: referenceID != uuid.Nil,
+			},
+		}
+
+		err := notification.Insert(ctx, db)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// mailStudents queues the notification as a mail to every student as well. Mail is best effort, a student
+// who cannot be mailed still has the notification.
+func mailStudents(ctx context.Context, db *sql.DB, logger *helpers.Logger, studentIDs []uuid.UUID, title string,
+	body string) {
+
+	for _, studentID := range studentIDs {
+		student, err := models.GetOneStudent(ctx, db, studentID)
+		if err != nil {
+			logger.Err.Printf(`api.notification.go/GetOneStudent/%s/%v`, studentID, err)
+			continue
+		}
+
+		err = helpers.QueueMail(ctx, helpers.Mail{
+			To:      student.Email,
+			Subject: title,
+			Body:    body,
+		})
+
+		if err != nil {
+			logger.Err.Printf(`api.notification.go/QueueMail/%s/%v`, student.Email, err)
+		}
+	}
+}
+
+// sessionStudentIDs returns the students currently enrolled in the session, without those who withdrew.
+func sessionStudentIDs(ctx context.Context, db *sql.DB, sessionID uuid.UUID) ([]uuid.UUID, error) {
+
+	studentEnrolls, err := models.GetAllStudentEnrollBySession(ctx, db, helpers.Filter{
+		FilterOption: helpers.FilterOption{
+			Limit:  999,
+			Offset: 0,
+			Dir:    "asc",
+		},
+		SessionID: sessionID,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	var studentIDs []uuid.UUID
+	for _, studentEnroll := range studentEnrolls {
+		if !studentEnroll.IsDelete && studentEnroll.Status != models.STUDENT_ENROLL_WITHDRAWN {
+			studentIDs = append(studentIDs, studentEnroll.StudentID)
+		}
+	}
+
+	return studentIDs, nil
+}
@@ -23,18 +23,22 @@ type (
 	}
 
 	ProgramAddParam struct {
-		FacultyID   uuid.UUID `json:"faculty_id"`
-		Name        string    `json:"name"`
-		Code        int       `json:"code"`
-		Description string    `json:"description"`
+		FacultyID       uuid.UUID `json:"faculty_id"`
+		Name            string    `json:"name"`
+		Code            int       `json:"code"`
+		Description     string    `json:"description"`
+		GradingSchemeID uuid.UUID `json:"grading_scheme_id"`
+		ElectiveCredit  int       `json:"elective_credit"`
 	}
 
 	ProgramUpdateParam struct {
-		ID          uuid.UUID `json:"id"`
-		FacultyID   uuid.UUID `json:"faculty_id"`
-		Name        string    `json:"name"`
-		Code        int       `json:"code"`
-		Description string    `json:"description"`
+		ID              uuid.UUID `json:"id"`
+		FacultyID       uuid.UUID `json:"faculty_id"`
+		Name            string    `json:"name"`
+		Code            int       `json:"code"`
+		Description     string    `json:"description"`
+		GradingSchemeID uuid.UUID `json:"grading_scheme_id"`
+		ElectiveCredit  int       `json:"elective_credit"`
 	}
 
 	ProgramDeleteParam struct {
@@ -97,7 +101,12 @@ func (s ProgramModule) Add(ctx context.Context, param ProgramAddParam) (interfac
 		Name:        param.Name,
 		Code:        param.Code,
 		Description: param.Description,
-		CreatedBy:   uuid.FromStringOrNil(ctx.Value("user_id").(string)),
+		GradingSchemeID: uuid.NullUUID{
+			UUID:  param.GradingSchemeID,
+			Valid: param.GradingSchemeID != uuid.Nil,
+		},
+		ElectiveCredit: param.ElectiveCredit,
+		CreatedBy:      uuid.FromStringOrNil(ctx.Value("user_id").(string)),
 	}
 
 	err := program.Insert(ctx, s.db)
@@ -123,6 +132,11 @@ func (s ProgramModule) Update(ctx context.Context, param ProgramUpdateParam) (in
 		Code:        param.Code,
 		Name:        param.Name,
 		Description: param.Description,
+		GradingSchemeID: uuid.NullUUID{
+			UUID:  param.GradingSchemeID,
+			Valid: param.GradingSchemeID != uuid.Nil,
+		},
+		ElectiveCredit: param.ElectiveCredit,
 		UpdatedBy: uuid.NullUUID{
 			UUID:  uuid.FromStringOrNil(ctx.Value("user_id").(string)),
 			Valid: true,
@@ -0,0 +1,372 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/gomodule/redigo/redis"
+	uuid "github.com/satori/go.uuid"
+	"github.com/spf13/viper"
+	"net/http"
+	"school/helpers"
+	"school/models"
+	"school/session"
+	"time"
+)
+
+// RESULT_APPEAL_WINDOW_DAYS is used when result.appeal_window_days is not configured.
+const RESULT_APPEAL_WINDOW_DAYS = 14
+
+type (
+	ResultAppealModule struct {
+		db     *sql.DB
+		cache  *redis.Pool
+		name   string
+		logger *helpers.Logger
+	}
+
+	ResultAppealAddParam struct {
+		ResultID uuid.UUID `json:"result_id" valid:"required"`
+		Reason   string    `json:"reason" valid:"required"`
+	}
+
+	ResultAppealReviewParam struct {
+		ID      uuid.UUID `json:"id"`
+		Status  string    `json:"status" valid:"required"`
+		Marks   int       `json:"marks"`
+		Comment string    `json:"comment"`
+	}
+)
+
+func NewResultAppealModule(db *sql.DB, cache *redis.Pool, logger *helpers.Logger) *ResultAppealModule {
+	return &ResultAppealModule{
+		db:     db,
+		cache:  cache,
+		name:   "module/result_appeal",
+		logger: logger,
+	}
+}
+
+func (s ResultAppealModule) List(ctx context.Context, filter helpers.Filter) (interface{}, *helpers.Error) {
+
+	resultAppeals, err := models.GetAllResultAppeal(ctx, s.db, filter)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "List/GetAllResultAppeal", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	var resultAppealsResponse []models.ResultAppealResponse
+	for _, resultAppeal := range resultAppeals {
+		response, err := resultAppeal.Response(ctx, s.db, s.logger)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "List/ResultAppealResponse", helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+
+		resultAppealsResponse = append(resultAppealsResponse, response)
+	}
+
+	return resultAppealsResponse, nil
+}
+
+func (s ResultAppealModule) ListByLecturer(ctx context.Context, filter helpers.Filter) (interface{}, *helpers.Error) {
+
+	filter.LecturerID = uuid.FromStringOrNil(ctx.Value("user_id").(string))
+
+	return s.List(ctx, filter)
+}
+
+func (s ResultAppealModule) ListByOneStudent(ctx context.Context, filter helpers.Filter) (interface{}, *helpers.Error) {
+
+	filter.StudentID = uuid.FromStringOrNil(ctx.Value("user_id").(string))
+
+	return s.List(ctx, filter)
+}
+
+// Add files an appeal against one of the student's published results. Appeals are only accepted within
+// result.appeal_window_days of the session's results being published.
+func (s ResultAppealModule) Add(ctx context.Context, param ResultAppealAddParam) (interface{}, *helpers.Error) {
+
+	studentID := uuid.FromStringOrNil(ctx.Value("user_id").(string))
+
+	result, err := models.GetOneResult(ctx, s.db, param.ResultID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Add/GetOneResult", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	studentEnroll, err := models.GetOneStudentEnroll(ctx, s.db, result.StudentEnrollID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Add/GetOneStudentEnroll", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	if result.IsDelete || studentEnroll.StudentID != studentID {
+		return nil, helpers.ErrorWrap(errors.New("Result Does Not Belong To Student"), s.name,
+			"Add/ValidationStudent",
+			helpers.ForbiddenMessage,
+			http.StatusForbidden)
+	}
+
+	if result.Status != models.RESULT_PUBLISHED {
+		return nil, helpers.ErrorWrap(errors.New("Result Is Not Published"), s.name, "Add/ValidationStatus",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	if result.Grade == models.RESULT_GRADE_WITHDRAWN {
+		return nil, helpers.ErrorWrap(errors.New("Withdrawn Result Cannot Be Appealed"), s.name,
+			"Add/ValidationWithdrawn",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	published, err := models.GetLastResultStatusHistoryBySession(ctx, s.db, studentEnroll.SessionID,
+		models.RESULT_PUBLISHED)
+
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Add/GetLastResultStatusHistoryBySession",
+			helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	windowDays := viper.GetInt("result.appeal_window_days")
+	if windowDays <= 0 {
+		windowDays = RESULT_APPEAL_WINDOW_DAYS
+	}
+
+	if time.Now().After(published.CreatedAt.AddDate(0, 0, windowDays)) {
+		return nil, helpers.ErrorWrap(errors.New("Appeal Window Has Closed"), s.name, "Add/ValidationWindow",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	pending, err := models.GetCountPendingResultAppealByResult(ctx, s.db, result.ID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Add/GetCountPendingResultAppealByResult",
+			helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	if pending > 0 {
+		return nil, helpers.ErrorWrap(errors.New("Result Already Has A Pending Appeal"), s.name,
+			"Add/ValidationPending",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	resultAppeal := models.ResultAppealModel{
+		ResultID:      result.ID,
+		StudentID:     studentID,
+		Reason:        param.Reason,
+		Status:        models.RESULT_APPEAL_PENDING,
+		OriginalMarks: result.Marks,
+		OriginalGrade: result.Grade,
+		CreatedBy:     studentID,
+	}
+
+	err = resultAppeal.Insert(ctx, s.db)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Add/Insert", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	response, err := resultAppeal.Response(ctx, s.db, s.logger)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Add/Response", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	return response, nil
+}
+
+func (s ResultAppealModule) ReviewByLecturer(ctx context.Context, param ResultAppealReviewParam) (
+	interface{}, *helpers.Error) {
+
+	resultAppeal, err := models.GetOneResultAppeal(ctx, s.db, param.ID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "ReviewByLecturer/GetOneResultAppeal",
+			helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	result, err := models.GetOneResult(ctx, s.db, resultAppeal.ResultID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "ReviewByLecturer/GetOneResult", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	studentEnroll, err := models.GetOneStudentEnroll(ctx, s.db, result.StudentEnrollID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "ReviewByLecturer/GetOneStudentEnroll",
+			helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	_, errSession := checkSessionLecturer(ctx, s.db, s.name, studentEnroll.SessionID)
+	if errSession != nil {
+		return nil, errSession
+	}
+
+	return s.Review(ctx, param)
+}
+
+// Review decides a pending appeal. An approved appeal regrades the result with the revised marks while the
+// appeal keeps the original marks and grade.
+func (s ResultAppealModule) Review(ctx context.Context, param ResultAppealReviewParam) (interface{}, *helpers.Error) {
+
+	if param.Status != models.RESULT_APPEAL_APPROVED && param.Status != models.RESULT_APPEAL_REJECTED {
+		return nil, helpers.ErrorWrap(errors.New("Status Must Be approved Or rejected"), s.name,
+			"Review/ValidationStatus",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	if param.Status == models.RESULT_APPEAL_APPROVED && (param.Marks < 0 || param.Marks > 100) {
+		return nil, helpers.ErrorWrap(errors.New("Marks Must Be Between 0 And 100"), s.name,
+			"Review/ValidationMarks",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	resultAppeal, err := models.GetOneResultAppeal(ctx, s.db, param.ID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Review/GetOneResultAppeal", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	if resultAppeal.Status != models.RESULT_APPEAL_PENDING {
+		return nil, helpers.ErrorWrap(errors.New("Appeal Is Already Reviewed"), s.name, "Review/ValidationPending",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	userID := uuid.FromStringOrNil(ctx.Value("user_id").(string))
+
+	resultAppeal.Status = param.Status
+	resultAppeal.ReviewComment = param.Comment
+	resultAppeal.ReviewedBy = uuid.NullUUID{
+		UUID:  userID,
+		Valid: true,
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Review/BeginTx", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+	defer tx.Rollback()
+
+	if param.Status == models.RESULT_APPEAL_APPROVED {
+		result, err := models.GetOneResult(ctx, s.db, resultAppeal.ResultID)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "Review/GetOneResult", helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+
+		studentEnroll, err := models.GetOneStudentEnroll(ctx, s.db, result.StudentEnrollID)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "Review/GetOneStudentEnroll", helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+
+		result.Marks = param.Marks
+		result.UpdatedBy = resultAppeal.ReviewedBy
+
+		err = gradeResult(ctx, s.db, studentEnroll.SessionID, &result)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "Review/gradeResult", helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+
+		err = result.Update(ctx, tx)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "Review/ResultUpdate", helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+
+		resultAppeal.RevisedMarks = sql.NullInt64{
+			Int64: int64(result.Marks),
+			Valid: true,
+		}
+		resultAppeal.RevisedGrade = sql.NullString{
+			String: result.Grade,
+			Valid:  true,
+		}
+	}
+
+	err = resultAppeal.Review(ctx, tx)
+	if err == sql.ErrNoRows {
+		return nil, helpers.ErrorWrap(errors.New("Appeal Is Already Reviewed"), s.name, "Review/ValidationPending",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Review/ResultAppealReview", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	err = s.notifyReview(ctx, tx, resultAppeal)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Review/NotifyReview", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Review/Commit", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	response, err := resultAppeal.Response(ctx, s.db, s.logger)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Review/Response", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	return response, nil
+}
+
+// notifyReview tells the student the decision on their appeal, with the revised grade when it was approved.
+func (s ResultAppealModule) notifyReview(ctx context.Context, tx *sql.Tx, resultAppeal models.ResultAppealModel) error {
+
+	result, err := models.GetOneResult(ctx, s.db, resultAppeal.ResultID)
+	if err != nil {
+		return err
+	}
+
+	studentEnroll, err := models.GetOneStudentEnroll(ctx, s.db, result.StudentEnrollID)
+	if err != nil {
+		return err
+	}
+
+	resultSession, err := models.GetOneSession(ctx, s.db, studentEnroll.SessionID)
+	if err != nil {
+		return err
+	}
+
+	subject, err := models.GetOneSubject(ctx, s.db, resultSession.SubjectID)
+	if err != nil {
+		return err
+	}
+
+	notificationType := models.NOTIFICATION_RESULT_APPEAL_REJECTED
+	title := "Result Appeal Rejected"
+	body := fmt.Sprintf("Your appeal against the result of %s has been rejected.", subject.Name)
+
+	if resultAppeal.Status == models.RESULT_APPEAL_APPROVED {
+		notificationType = models.NOTIFICATION_RESULT_REVISED
+		title = "Result Revised"
+		body = fmt.Sprintf("Your appeal against the result of %s has been approved. Your grade is now %s (%d).",
+			subject.Name, resultAppeal.RevisedGrade.String, resultAppeal.RevisedMarks.Int64)
+	}
+
+	if resultAppeal.ReviewComment != "" {
+		body += "\r\n\r\nComment : " + resultAppeal.ReviewComment
+	}
+
+	return notifyUsers(ctx, tx, []uuid.UUID{resultAppeal.StudentID}, session.STUDENT_ROLE, notificationType, title,
+		body, result.ID)
+}
@@ -3,12 +3,14 @@ package api
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 	"github.com/gomodule/redigo/redis"
 	uuid "github.com/satori/go.uuid"
 	"net/http"
 	"school/helpers"
 	"school/models"
-	"school/util"
+	"school/session"
 )
 
 type (
@@ -95,6 +97,7 @@ func (s ResultModule) ListByOneStudent(ctx context.Context, filter helpers.Filte
 			Offset: 0,
 		},
 		StudentID: studentID,
+		Status:    models.RESULT_PUBLISHED,
 	})
 
 	if err != nil {
@@ -145,11 +148,51 @@ func (s ResultModule) ListByOneStudent(ctx context.Context, filter helpers.Filte
 
 func (s ResultModule) Update(ctx context.Context, param ResultUpdateParam) (interface{}, *helpers.Error) {
 
-	grade := util.GetGrade(param.Marks)
+	if param.Marks < 0 || param.Marks > 100 {
+		return nil, helpers.ErrorWrap(errors.New("Marks Must Be Between 0 And 100"), s.name, "Update/ValidationMarks",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	current, err := models.GetOneResult(ctx, s.db, param.ID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Update/GetOneResult", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	if !isResultEditable(current.Status) {
+		return nil, helpers.ErrorWrap(errors.New("Result Is Locked"), s.name, "Update/ValidationStatus",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	if current.Grade == models.RESULT_GRADE_WITHDRAWN {
+		return nil, helpers.ErrorWrap(errors.New("Student Has Withdrawn"), s.name, "Update/ValidationWithdrawn",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	studentEnroll, err := models.GetOneStudentEnroll(ctx, s.db, current.StudentEnrollID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Update/GetOneStudentEnroll", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	assessments, err := models.GetAllAssessmentBySession(ctx, s.db, studentEnroll.SessionID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Update/GetAllAssessmentBySession", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	if len(assessments) > 0 {
+		return nil, helpers.ErrorWrap(errors.New("Marks Are Derived From Assessments"), s.name,
+			"Update/ValidationAssessment",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
 
 	result := models.ResultModel{
 		ID:    param.ID,
-		Grade: grade,
 		Marks: param.Marks,
 		UpdatedBy: uuid.NullUUID{
 			UUID:  uuid.FromStringOrNil(ctx.Value("user_id").(string)),
@@ -157,12 +200,37 @@ func (s ResultModule) Update(ctx context.Context, param ResultUpdateParam) (inte
 		},
 	}
 
-	err := result.Update(ctx, s.db)
+	err = gradeResult(ctx, s.db, studentEnroll.SessionID, &result)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Update/gradeResult", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Update/BeginTx", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+	defer tx.Rollback()
+
+	err = result.Update(ctx, tx)
 	if err != nil {
 		return nil, helpers.ErrorWrap(err, s.name, "Update/Update", helpers.InternalServerError,
 			http.StatusInternalServerError)
 	}
 
+	err = s.notifyUpdate(ctx, tx, studentEnroll, current, result)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Update/NotifyUpdate", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Update/Commit", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
 	response, err := result.Response(ctx, s.db, s.logger)
 	if err != nil {
 		return nil, helpers.ErrorWrap(err, s.name, "Update/ResultResponse", helpers.InternalServerError,
@@ -173,6 +241,37 @@ func (s ResultModule) Update(ctx context.Context, param ResultUpdateParam) (inte
 
 }
 
+// notifyUpdate tells the session's lecturer when someone else changed the marks of one of their results. Only
+// draft and returned results can be updated, so the student is not told until the results are published.
+func (s ResultModule) notifyUpdate(ctx context.Context, tx *sql.Tx, studentEnroll models.StudentEnrollModel,
+	current models.ResultModel, result models.ResultModel) error {
+
+	resultSession, err := models.GetOneSession(ctx, s.db, studentEnroll.SessionID)
+	if err != nil {
+		return err
+	}
+
+	if resultSession.LecturerID == result.UpdatedBy.UUID {
+		return nil
+	}
+
+	subject, err := models.GetOneSubject(ctx, s.db, resultSession.SubjectID)
+	if err != nil {
+		return err
+	}
+
+	student, err := models.GetOneStudent(ctx, s.db, studentEnroll.StudentID)
+	if err != nil {
+		return err
+	}
+
+	return notifyUsers(ctx, tx, []uuid.UUID{resultSession.LecturerID}, session.LECTURER_ROLE,
+		models.NOTIFICATION_RESULT_UPDATED, "Result Updated",
+		fmt.Sprintf("The %s result of %s has been changed from %d (%s) to %d (%s).", subject.Name, student.Name,
+			current.Marks, current.Grade, result.Marks, result.Grade),
+		result.ID)
+}
+
 func (s ResultModule) Delete(ctx context.Context, param ResultDeleteParam) (interface{}, *helpers.Error) {
 
 	result := models.ResultModel{
@@ -0,0 +1,361 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	uuid "github.com/satori/go.uuid"
+	"html"
+	"net/http"
+	"school/helpers"
+	"school/models"
+	"strconv"
+	"strings"
+)
+
+const (
+	RESULT_IMPORT_VALID   = "valid"
+	RESULT_IMPORT_UPDATED = "updated"
+	RESULT_IMPORT_SKIPPED = "skipped"
+	RESULT_IMPORT_ERROR   = "error"
+)
+
+type (
+	ResultExportParam struct {
+		SessionID uuid.UUID `json:"session_id"`
+	}
+
+	ResultImportParam struct {
+		SessionID uuid.UUID  `json:"session_id"`
+		DryRun    bool       `json:"dry_run"`
+		Records   [][]string `json:"records"`
+	}
+
+	ResultImportRowResponse struct {
+		Row         int    `json:"row"`
+		StudentCode string `json:"student_code"`
+		Marks       int    `json:"marks"`
+		Grade       string `json:"grade"`
+		Status      string `json:"status"`
+		Error       string `json:"error,omitempty"`
+	}
+
+	ResultImportResponse struct {
+		SessionID uuid.UUID                 `json:"session_id"`
+		DryRun    bool                      `json:"dry_run"`
+		Total     int                       `json:"total"`
+		Updated   int                       `json:"updated"`
+		Skipped   int                       `json:"skipped"`
+		Failed    int                       `json:"failed"`
+		Rows      []ResultImportRowResponse `json:"rows"`
+	}
+)
+
+// Export builds a CSV template of every student enrolled in the session with their current marks.
+func (s ResultModule) Export(ctx context.Context, param ResultExportParam) (*helpers.File, *helpers.Error) {
+
+	session, errSession := checkSessionLecturer(ctx, s.db, s.name, param.SessionID)
+	if errSession != nil {
+		return nil, errSession
+	}
+
+	studentEnrolls, err := models.GetAllStudentEnrollBySession(ctx, s.db, helpers.Filter{
+		FilterOption: helpers.FilterOption{
+			Limit:  999,
+			Offset: 0,
+		},
+		SessionID: param.SessionID,
+	})
+
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Export/GetAllStudentEnrollBySession", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	writer.Write([]string{"student_code", "student_name", "marks"})
+
+	for _, studentEnroll := range studentEnrolls {
+		if studentEnroll.IsDelete {
+			continue
+		}
+
+		student, err := models.GetOneStudent(ctx, s.db, studentEnroll.StudentID)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "Export/GetOneStudent", helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+
+		results, err := models.GetAllResultByStudentEnroll(ctx, s.db, helpers.Filter{
+			FilterOption: helpers.FilterOption{
+				Limit:  999,
+				Offset: 0,
+			},
+			StudentEnrollID: studentEnroll.ID,
+		})
+
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "Export/GetAllResultByStudentEnroll",
+				helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+
+		var marks string
+		for _, result := range results {
+			if !result.IsDelete && result.Grade != "" && result.Grade != models.RESULT_GRADE_WITHDRAWN {
+				marks = strconv.Itoa(result.Marks)
+			}
+		}
+
+		writer.Write([]string{student.StudentCode, html.UnescapeString(student.Name), marks})
+	}
+
+	writer.Flush()
+	err = writer.Error()
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Export/WriteCSV", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	return &helpers.File{
+		Name:        fmt.Sprintf("results-%s.csv", session.ID),
+		ContentType: "text/csv",
+		Content:     buffer.Bytes(),
+	}, nil
+}
+
+// Import sets the marks of every row keyed by student_code. Rows with empty marks are skipped. The whole
+// file is validated first and nothing is written when a row fails or DryRun is set.
+func (s ResultModule) Import(ctx context.Context, param ResultImportParam) (interface{}, *helpers.Error) {
+
+	_, errSession := checkSessionLecturer(ctx, s.db, s.name, param.SessionID)
+	if errSession != nil {
+		return nil, errSession
+	}
+
+	assessments, err := models.GetAllAssessmentBySession(ctx, s.db, param.SessionID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Import/GetAllAssessmentBySession", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	if len(assessments) > 0 {
+		return nil, helpers.ErrorWrap(errors.New("Marks Are Derived From Assessments"), s.name,
+			"Import/ValidationAssessment",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	errEditable := checkSessionResultEditable(ctx, s.db, s.name, param.SessionID)
+	if errEditable != nil {
+		return nil, errEditable
+	}
+
+	if len(param.Records) == 0 {
+		return nil, helpers.ErrorWrap(errors.New("Empty File"), s.name, "Import/ValidationFile",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	codeColumn, marksColumn := -1, -1
+	for i, column := range param.Records[0] {
+		switch strings.ToLower(strings.TrimSpace(column)) {
+		case "student_code":
+			codeColumn = i
+		case "marks":
+			marksColumn = i
+		}
+	}
+
+	if codeColumn < 0 || marksColumn < 0 {
+		return nil, helpers.ErrorWrap(errors.New("Header Must Contain student_code And marks"), s.name,
+			"Import/ValidationHeader",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	studentEnrolls, err := models.GetAllStudentEnrollBySession(ctx, s.db, helpers.Filter{
+		FilterOption: helpers.FilterOption{
+			Limit:  999,
+			Offset: 0,
+		},
+		SessionID: param.SessionID,
+	})
+
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Import/GetAllStudentEnrollBySession", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	enrollByCode := make(map[string]models.StudentEnrollModel)
+	for _, studentEnroll := range studentEnrolls {
+		if studentEnroll.IsDelete {
+			continue
+		}
+
+		student, err := models.GetOneStudent(ctx, s.db, studentEnroll.StudentID)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "Import/GetOneStudent", helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+
+		enrollByCode[student.StudentCode] = studentEnroll
+	}
+
+	response := ResultImportResponse{
+		SessionID: param.SessionID,
+		DryRun:    param.DryRun,
+	}
+
+	var updates []models.ResultModel
+	var updateRows []int
+	seen := make(map[string]bool)
+
+	for i, record := range param.Records[1:] {
+		row := ResultImportRowResponse{
+			Row:    i + 2,
+			Status: RESULT_IMPORT_VALID,
+		}
+
+		if codeColumn < len(record) {
+			row.StudentCode = strings.TrimSpace(record[codeColumn])
+		}
+
+		var marks string
+		if marksColumn < len(record) {
+			marks = strings.TrimSpace(record[marksColumn])
+		}
+
+		response.Total++
+
+		studentEnroll, enrolled := enrollByCode[row.StudentCode]
+
+		switch {
+		case row.StudentCode == "":
+			row.Error = "Student Code Is Required"
+		case seen[row.StudentCode]:
+			row.Error = "Duplicate Student Code"
+		case !enrolled:
+			row.Error = "Student Not Enrolled In Session"
+		case marks == "":
+			row.Status = RESULT_IMPORT_SKIPPED
+		default:
+			row.Marks, err = strconv.Atoi(marks)
+			if err != nil || row.Marks < 0 || row.Marks > 100 {
+				row.Error = "Marks Must Be A Whole Number Between 0 And 100"
+			}
+		}
+
+		seen[row.StudentCode] = true
+
+		if row.Error != "" {
+			row.Status = RESULT_IMPORT_ERROR
+			response.Failed++
+			response.Rows = append(response.Rows, row)
+			continue
+		}
+
+		if row.Status == RESULT_IMPORT_SKIPPED {
+			response.Skipped++
+			response.Rows = append(response.Rows, row)
+			continue
+		}
+
+		results, err := models.GetAllResultByStudentEnroll(ctx, s.db, helpers.Filter{
+			FilterOption: helpers.FilterOption{
+				Limit:  999,
+				Offset: 0,
+			},
+			StudentEnrollID: studentEnroll.ID,
+		})
+
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "Import/GetAllResultByStudentEnroll",
+				helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+
+		withdrawn := false
+		for _, result := range results {
+			if !result.IsDelete && result.Grade == models.RESULT_GRADE_WITHDRAWN {
+				withdrawn = true
+			}
+		}
+
+		if withdrawn {
+			row.Status = RESULT_IMPORT_ERROR
+			row.Error = "Student Has Withdrawn"
+			response.Failed++
+			response.Rows = append(response.Rows, row)
+			continue
+		}
+
+		for _, result := range results {
+			if result.IsDelete {
+				continue
+			}
+
+			result.Marks = row.Marks
+			result.UpdatedBy = uuid.NullUUID{
+				UUID:  uuid.FromStringOrNil(ctx.Value("user_id").(string)),
+				Valid: true,
+			}
+
+			err = gradeResult(ctx, s.db, param.SessionID, &result)
+			if err != nil {
+				return nil, helpers.ErrorWrap(err, s.name, "Import/gradeResult", helpers.InternalServerError,
+					http.StatusInternalServerError)
+			}
+
+			row.Grade = result.Grade
+			updates = append(updates, result)
+			updateRows = append(updateRows, len(response.Rows))
+		}
+
+		response.Rows = append(response.Rows, row)
+	}
+
+	if response.Failed > 0 {
+		return response, helpers.ErrorWrap(errors.New("Import Has Invalid Rows"), s.name, "Import/ValidationRow",
+			helpers.ImportValidationMessage,
+			http.StatusUnprocessableEntity)
+	}
+
+	if param.DryRun {
+		return response, nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Import/BeginTx", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+	defer tx.Rollback()
+
+	for i, result := range updates {
+		err = result.Update(ctx, tx)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "Import/Update", helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+
+		response.Rows[updateRows[i]].Status = RESULT_IMPORT_UPDATED
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Import/Commit", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	for _, row := range response.Rows {
+		if row.Status == RESULT_IMPORT_UPDATED {
+			response.Updated++
+		}
+	}
+
+	return response, nil
+}
@@ -0,0 +1,291 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	uuid "github.com/satori/go.uuid"
+	"github.com/spf13/viper"
+	"net/http"
+	"school/helpers"
+	"school/models"
+	"school/session"
+	"strings"
+)
+
+type (
+	ResultWorkflowParam struct {
+		SessionID uuid.UUID `json:"session_id"`
+		Comment   string    `json:"comment"`
+	}
+
+	ResultWorkflowResponse struct {
+		SessionID uuid.UUID                            `json:"session_id"`
+		Statuses  map[string]int                       `json:"statuses"`
+		Histories []models.ResultStatusHistoryResponse `json:"histories"`
+		Barred    []uuid.UUID                          `json:"barred,omitempty"`
+	}
+)
+
+// Submit hands the lecturer's draft or returned results of a session over for approval.
+func (s ResultModule) Submit(ctx context.Context, param ResultWorkflowParam) (interface{}, *helpers.Error) {
+
+	_, errSession := checkSessionLecturer(ctx, s.db, s.name, param.SessionID)
+	if errSession != nil {
+		return nil, errSession
+	}
+
+	ungraded, err := models.GetCountUngradedResultBySession(ctx, s.db, param.SessionID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Submit/GetCountUngradedResultBySession",
+			helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	if ungraded > 0 {
+		return nil, helpers.ErrorWrap(errors.New("Session Has Ungraded Results"), s.name, "Submit/ValidationGrade",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	return s.transition(ctx, "Submit", param,
+		[]string{models.RESULT_DRAFT, models.RESULT_RETURNED}, models.RESULT_SUBMITTED, nil)
+}
+
+func (s ResultModule) Approve(ctx context.Context, param ResultWorkflowParam) (interface{}, *helpers.Error) {
+
+	return s.transition(ctx, "Approve", param, []string{models.RESULT_SUBMITTED}, models.RESULT_APPROVED, nil)
+}
+
+func (s ResultModule) Return(ctx context.Context, param ResultWorkflowParam) (interface{}, *helpers.Error) {
+
+	if strings.TrimSpace(param.Comment) == "" {
+		return nil, helpers.ErrorWrap(errors.New("Comment Is Required"), s.name, "Return/ValidationComment",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	return s.transition(ctx, "Return", param, []string{models.RESULT_SUBMITTED}, models.RESULT_RETURNED, nil)
+}
+
+// Publish releases the approved results of a session. With attendance.bar_publish set, the results of students
+// below the attendance threshold stay approved and are listed as barred.
+func (s ResultModule) Publish(ctx context.Context, param ResultWorkflowParam) (interface{}, *helpers.Error) {
+
+	var barred []uuid.UUID
+	var excluded []string
+
+	if viper.GetBool("attendance.bar_publish") {
+		summaries, err := models.GetAllAttendanceSummaryBySession(ctx, s.db, param.SessionID)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "Publish/GetAllAttendanceSummaryBySession",
+				helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+
+		threshold := attendanceThreshold()
+		for _, summary := range summaries {
+			if summary.Percentage() < threshold {
+				barred = append(barred, summary.StudentID)
+				excluded = append(excluded, summary.StudentID.String())
+			}
+		}
+	}
+
+	response, err := s.transition(ctx, "Publish", param, []string{models.RESULT_APPROVED}, models.RESULT_PUBLISHED,
+		excluded)
+	if err != nil {
+		return nil, err
+	}
+
+	workflowResponse := response.(ResultWorkflowResponse)
+	workflowResponse.Barred = barred
+
+	return workflowResponse, nil
+}
+
+func (s ResultModule) History(ctx context.Context, param ResultWorkflowParam) (interface{}, *helpers.Error) {
+
+	statuses, err := models.GetCountResultBySessionAndStatus(ctx, s.db, param.SessionID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "History/GetCountResultBySessionAndStatus",
+			helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	histories, err := models.GetAllResultStatusHistoryBySession(ctx, s.db, param.SessionID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "History/GetAllResultStatusHistoryBySession",
+			helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	response := ResultWorkflowResponse{
+		SessionID: param.SessionID,
+		Statuses:  statuses,
+	}
+
+	for _, history := range histories {
+		response.Histories = append(response.Histories, history.Response())
+	}
+
+	return response, nil
+}
+
+func (s ResultModule) HistoryByLecturer(ctx context.Context, param ResultWorkflowParam) (
+	interface{}, *helpers.Error) {
+
+	_, errSession := checkSessionLecturer(ctx, s.db, s.name, param.SessionID)
+	if errSession != nil {
+		return nil, errSession
+	}
+
+	return s.History(ctx, param)
+}
+
+// transition moves every result of the session in one of the from statuses to the new status and
+// records the change in the session history.
+func (s ResultModule) transition(ctx context.Context, step string, param ResultWorkflowParam, from []string,
+	to string, excludeStudentIDs []string) (interface{}, *helpers.Error) {
+
+	statuses, err := models.GetCountResultBySessionAndStatus(ctx, s.db, param.SessionID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, step+"/GetCountResultBySessionAndStatus",
+			helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	var fromStatuses []string
+	for _, status := range from {
+		if statuses[status] > 0 {
+			fromStatuses = append(fromStatuses, status)
+		}
+	}
+
+	if len(fromStatuses) == 0 {
+		return nil, helpers.ErrorWrap(errors.New("No Results To "+step), s.name, step+"/ValidationStatus",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	userID := uuid.FromStringOrNil(ctx.Value("user_id").(string))
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, step+"/BeginTx", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+	defer tx.Rollback()
+
+	_, err = models.UpdateResultStatusBySession(ctx, tx, param.SessionID, fromStatuses, to, excludeStudentIDs,
+		uuid.NullUUID{
+			UUID:  userID,
+			Valid: true,
+		})
+
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, step+"/UpdateResultStatusBySession", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	history := models.ResultStatusHistoryModel{
+		SessionID:  param.SessionID,
+		FromStatus: strings.Join(fromStatuses, ","),
+		ToStatus:   to,
+		Comment:    param.Comment,
+		CreatedBy:  userID,
+	}
+
+	err = history.Insert(ctx, tx)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, step+"/ResultStatusHistoryInsert", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	err = s.notifyTransition(ctx, tx, param, to, excludeStudentIDs)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, step+"/NotifyTransition", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, step+"/Commit", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	return s.History(ctx, param)
+}
+
+// notifyTransition tells the students when their results are published and the lecturer when the results are
+// returned to them. Students are not told about results that are still being worked on.
+func (s ResultModule) notifyTransition(ctx context.Context, tx *sql.Tx, param ResultWorkflowParam, to string,
+	excludeStudentIDs []string) error {
+
+	if to != models.RESULT_PUBLISHED && to != models.RESULT_RETURNED {
+		return nil
+	}
+
+	resultSession, err := models.GetOneSession(ctx, s.db, param.SessionID)
+	if err != nil {
+		return err
+	}
+
+	subject, err := models.GetOneSubject(ctx, s.db, resultSession.SubjectID)
+	if err != nil {
+		return err
+	}
+
+	if to == models.RESULT_RETURNED {
+		return notifyUsers(ctx, tx, []uuid.UUID{resultSession.LecturerID}, session.LECTURER_ROLE,
+			models.NOTIFICATION_RESULT_RETURNED, "Results Returned",
+			fmt.Sprintf("The results of %s have been returned.\r\n\r\nComment : %s", subject.Name, param.Comment),
+			param.SessionID)
+	}
+
+	studentIDs, err := sessionStudentIDs(ctx, s.db, param.SessionID)
+	if err != nil {
+		return err
+	}
+
+	excluded := make(map[string]bool)
+	for _, studentID := range excludeStudentIDs {
+		excluded[studentID] = true
+	}
+
+	var publishedIDs []uuid.UUID
+	for _, studentID := range studentIDs {
+		if !excluded[studentID.String()] {
+			publishedIDs = append(publishedIDs, studentID)
+		}
+	}
+
+	return notifyUsers(ctx, tx, publishedIDs, session.STUDENT_ROLE, models.NOTIFICATION_RESULT_PUBLISHED,
+		"Result Published", fmt.Sprintf("Your result of %s has been published.", subject.Name), param.SessionID)
+}
+
+func isResultEditable(status string) bool {
+	return status == models.RESULT_DRAFT || status == models.RESULT_RETURNED
+}
+
+// checkSessionResultEditable rejects changes to the marks of a session once its results left the lecturer.
+func checkSessionResultEditable(ctx context.Context, db helpers.Queryer, name string, sessionID uuid.UUID) *helpers.Error {
+
+	statuses, err := models.GetCountResultBySessionAndStatus(ctx, db, sessionID)
+	if err != nil {
+		return helpers.ErrorWrap(err, name, "CheckSessionResultEditable/GetCountResultBySessionAndStatus",
+			helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	for status, count := range statuses {
+		if count > 0 && !isResultEditable(status) {
+			return helpers.ErrorWrap(errors.New("Results Are Locked"), name, "CheckSessionResultEditable/Validation",
+				helpers.BadRequestMessage,
+				http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"school/helpers"
+	"school/models"
+)
+
+func sessionConflictResponse(ctx context.Context, db *sql.DB, logger *helpers.Logger,
+	conflicts []models.SessionConflictModel) ([]models.SessionConflictResponse, error) {
+
+	var conflictsResponse []models.SessionConflictResponse
+	for _, conflict := range conflicts {
+		response, err := conflict.Response(ctx, db, logger)
+		if err != nil {
+			return nil, err
+		}
+		conflictsResponse = append(conflictsResponse, response)
+	}
+
+	return conflictsResponse, nil
+}
+
+func checkSessionConflict(ctx context.Context, db *sql.DB, logger *helpers.Logger, name string,
+	session models.SessionModel) (interface{}, *helpers.Error) {
+
+	conflicts, err := models.GetAllSessionConflict(ctx, db, session)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, name, "CheckSessionConflict/GetAllSessionConflict",
+			helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	if len(conflicts) == 0 {
+		return nil, nil
+	}
+
+	response, err := sessionConflictResponse(ctx, db, logger, conflicts)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, name, "CheckSessionConflict/SessionConflictResponse",
+			helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	return response, helpers.ErrorWrap(errors.New("Session Clashes With Existing Sessions"), name,
+		"CheckSessionConflict/Validation",
+		helpers.SessionConflictMessage,
+		http.StatusConflict)
+}
+
+func (s SessionModule) ListConflict(ctx context.Context, filter helpers.Filter) (interface{}, *helpers.Error) {
+
+	if filter.Limit == 0 {
+		filter.Limit = 999
+	}
+
+	conflicts, err := models.GetAllSessionConflictByIntake(ctx, s.db, filter)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "ListConflict/GetAllSessionConflictByIntake",
+			helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	response, err := sessionConflictResponse(ctx, s.db, s.logger, conflicts)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "ListConflict/SessionConflictResponse",
+			helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	return response, nil
+}
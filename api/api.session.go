@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"github.com/gomodule/redigo/redis"
 	uuid "github.com/satori/go.uuid"
 	"net/http"
@@ -32,6 +33,7 @@ type (
 		Day         int       `json:"day"`
 		StartTime   time.Time `json:"start_time"`
 		EndTime     time.Time `json:"end_time"`
+		Capacity    int       `json:"capacity"`
 	}
 
 	SessionUpdateParam struct {
@@ -44,11 +46,17 @@ type (
 		Day         int       `json:"day"`
 		StartTime   time.Time `json:"start_time"`
 		EndTime     time.Time `json:"end_time"`
+		Capacity    int       `json:"capacity"`
 	}
 
 	SessionDeleteParam struct {
 		ID uuid.UUID `json:"id"`
 	}
+
+	SessionWithWarningResponse struct {
+		models.SessionResponse
+		Warnings []string `json:"warnings,omitempty"`
+	}
 )
 
 func NewSessionModule(db *sql.DB, cache *redis.Pool, logger *helpers.Logger) *SessionModule {
@@ -131,6 +139,12 @@ func (s SessionModule) ListByLecturer(ctx context.Context, filter helpers.Filter
 
 func (s SessionModule) Add(ctx context.Context, param SessionAddParam) (interface{}, *helpers.Error) {
 
+	capacity, err := s.capacity(ctx, param.ClassroomID, param.Capacity)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Add/Capacity", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
 	session := models.SessionModel{
 
 		SubjectID:   param.SubjectID,
@@ -141,10 +155,22 @@ func (s SessionModule) Add(ctx context.Context, param SessionAddParam) (interfac
 		Day:         param.Day,
 		StartTime:   param.StartTime,
 		EndTime:     param.EndTime,
+		Capacity:    capacity,
 		CreatedBy:   uuid.FromStringOrNil(ctx.Value("user_id").(string)),
 	}
 
-	err := session.Insert(ctx, s.db)
+	conflicts, conflictErr := checkSessionConflict(ctx, s.db, s.logger, s.name, session)
+	if conflictErr != nil {
+		return conflicts, conflictErr
+	}
+
+	warnings, err := s.teachingLoadWarnings(ctx, session)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Add/teachingLoadWarnings", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	err = session.Insert(ctx, s.db)
 	if err != nil {
 		return nil, helpers.ErrorWrap(err, s.name, "Add/Insert", helpers.InternalServerError,
 			http.StatusInternalServerError)
@@ -157,11 +183,20 @@ func (s SessionModule) Add(ctx context.Context, param SessionAddParam) (interfac
 			http.StatusInternalServerError)
 	}
 
-	return response, nil
+	return SessionWithWarningResponse{
+		SessionResponse: response,
+		Warnings:        warnings,
+	}, nil
 }
 
 func (s SessionModule) Update(ctx context.Context, param SessionUpdateParam) (interface{}, *helpers.Error) {
 
+	capacity, err := s.capacity(ctx, param.ClassroomID, param.Capacity)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Update/Capacity", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
 	session := models.SessionModel{
 		ID:          param.ID,
 		SubjectID:   param.SubjectID,
@@ -172,13 +207,25 @@ func (s SessionModule) Update(ctx context.Context, param SessionUpdateParam) (in
 		Day:         param.Day,
 		StartTime:   param.StartTime,
 		EndTime:     param.EndTime,
+		Capacity:    capacity,
 		UpdatedBy: uuid.NullUUID{
 			UUID:  uuid.FromStringOrNil(ctx.Value("user_id").(string)),
 			Valid: true,
 		},
 	}
 
-	err := session.Update(ctx, s.db)
+	conflicts, conflictErr := checkSessionConflict(ctx, s.db, s.logger, s.name, session)
+	if conflictErr != nil {
+		return conflicts, conflictErr
+	}
+
+	warnings, err := s.teachingLoadWarnings(ctx, session)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Update/teachingLoadWarnings", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	err = session.Update(ctx, s.db)
 	if err != nil {
 		return nil, helpers.ErrorWrap(err, s.name, "Update/Update", helpers.InternalServerError,
 			http.StatusInternalServerError)
@@ -190,7 +237,10 @@ func (s SessionModule) Update(ctx context.Context, param SessionUpdateParam) (in
 			http.StatusInternalServerError)
 	}
 
-	return response, nil
+	return SessionWithWarningResponse{
+		SessionResponse: response,
+		Warnings:        warnings,
+	}, nil
 
 }
 
@@ -213,3 +263,38 @@ func (s SessionModule) Delete(ctx context.Context, param SessionDeleteParam) (in
 	return nil, nil
 
 }
+
+func (s SessionModule) capacity(ctx context.Context, classroomID uuid.UUID, capacity int) (int, error) {
+
+	if capacity > 0 {
+		return capacity, nil
+	}
+
+	classroom, err := models.GetOneClassroom(ctx, s.db, classroomID)
+	if err != nil {
+		return 0, err
+	}
+
+	return classroom.Capacity, nil
+}
+
+// checkSessionLecturer loads the session and makes sure it is taught by the logged in lecturer.
+func checkSessionLecturer(ctx context.Context, db *sql.DB, name string, sessionID uuid.UUID) (
+	models.SessionModel, *helpers.Error) {
+
+	session, err := models.GetOneSession(ctx, db, sessionID)
+	if err != nil {
+		return models.SessionModel{}, helpers.ErrorWrap(err, name, "CheckSessionLecturer/GetOneSession",
+			helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	if session.LecturerID != uuid.FromStringOrNil(ctx.Value("user_id").(string)) {
+		return models.SessionModel{}, helpers.ErrorWrap(errors.New("Session Does Not Belong To Lecturer"), name,
+			"CheckSessionLecturer/Validation",
+			helpers.ForbiddenMessage,
+			http.StatusForbidden)
+	}
+
+	return session, nil
+}
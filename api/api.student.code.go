@@ -0,0 +1,148 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	uuid "github.com/satori/go.uuid"
+	"github.com/spf13/viper"
+	"net/http"
+	"school/helpers"
+	"school/models"
+	"school/util"
+)
+
+// STUDENT_CODE_FORMAT is the student number format when student.code_format is not configured. See
+// util.FormatStudentCode for the tokens.
+const STUDENT_CODE_FORMAT = "1{YY}{PROGRAM}0{FACULTY}{SEQ:3}"
+
+type (
+	StudentCodeRepairParam struct {
+		DryRun bool `json:"dry_run"`
+	}
+
+	StudentCodeRepairResponse struct {
+		StudentID uuid.UUID `json:"student_id"`
+		Name      string    `json:"name"`
+		OldCode   string    `json:"old_code"`
+		NewCode   string    `json:"new_code"`
+	}
+)
+
+// RepairCodes gives a fresh code to every student sharing a code with an older student. The oldest holder
+// keeps the code. With DryRun the new codes are reported but not saved.
+func (s StudentModule) RepairCodes(ctx context.Context, param StudentCodeRepairParam) (
+	interface{}, *helpers.Error) {
+
+	students, err := models.GetAllDuplicateStudentCode(ctx, s.db)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "RepairCodes/GetAllDuplicateStudentCode",
+			helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "RepairCodes/BeginTx", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	defer tx.Rollback()
+
+	var repairs []StudentCodeRepairResponse
+	var previousCode string
+	for _, student := range students {
+		if student.StudentCode != previousCode {
+			previousCode = student.StudentCode
+			continue
+		}
+
+		code, err := s.allocateStudentCode(ctx, tx, student.ProgramID, student.CreatedAt.Year())
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "RepairCodes/allocateStudentCode",
+				helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+
+		repaired := models.StudentModel{
+			ID:          student.ID,
+			StudentCode: code,
+			UpdatedBy: uuid.NullUUID{
+				UUID:  uuid.FromStringOrNil(ctx.Value("user_id").(string)),
+				Valid: true,
+			},
+		}
+
+		err = repaired.CodeUpdate(ctx, tx)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "RepairCodes/CodeUpdate", helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+
+		repairs = append(repairs, StudentCodeRepairResponse{
+			StudentID: student.ID,
+			Name:      student.Name,
+			OldCode:   student.StudentCode,
+			NewCode:   code,
+		})
+	}
+
+	if param.DryRun {
+		return repairs, nil
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "RepairCodes/Commit", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	return repairs, nil
+}
+
+// allocateStudentCode takes the next number of the program's sequence for the year and formats it.
+// Numbers whose code is already held, for example by a student created before the sequence existed, are
+// skipped.
+func (s StudentModule) allocateStudentCode(ctx context.Context, tx *sql.Tx, programID uuid.UUID, year int) (
+	string, error) {
+
+	program, err := models.GetOneProgram(ctx, s.db, programID)
+	if err != nil {
+		return "", err
+	}
+
+	faculty, err := models.GetOneFaculty(ctx, s.db, program.FacultyID)
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		sequence, err := models.NextStudentCodeSequence(ctx, tx, programID, year)
+		if err != nil {
+			return "", err
+		}
+
+		code, err := util.FormatStudentCode(studentCodeFormat(), year, program.Code, faculty.Code, sequence)
+		if err != nil {
+			return "", err
+		}
+
+		count, err := models.CountStudentByCode(ctx, tx, code)
+		if err != nil {
+			return "", err
+		}
+
+		if count == 0 {
+			return code, nil
+		}
+	}
+}
+
+func studentCodeFormat() string {
+
+	format := viper.GetString("student.code_format")
+	if format == "" {
+		format = STUDENT_CODE_FORMAT
+	}
+
+	return format
+}
@@ -10,9 +10,14 @@ import (
 	"net/http"
 	"school/helpers"
 	"school/models"
+	"school/session"
 	"time"
 )
 
+// ENROLLMENT_WINDOW_DAYS is the enrollment window before an intake starts when its calendar has no
+// enrollment period.
+const ENROLLMENT_WINDOW_DAYS = 5
+
 type (
 	StudentEnrollModule struct {
 		db     *sql.DB
@@ -29,10 +34,24 @@ type (
 		SessionID uuid.UUID `json:"session_id" value:"required"`
 	}
 
+	StudentEnrollAddLateParam struct {
+		SessionID uuid.UUID `json:"session_id"`
+		StudentID uuid.UUID `json:"student_id"`
+		Reason    string    `json:"reason" valid:"required"`
+	}
+
 	StudentEnrollDeleteParam struct {
 		ID uuid.UUID `json:"id"`
 	}
 
+	StudentEnrollDropParam struct {
+		ID uuid.UUID `json:"id"`
+	}
+
+	StudentEnrollWithdrawParam struct {
+		ID uuid.UUID `json:"id"`
+	}
+
 	StudentEnrollListBySessionParam struct {
 		SessionID uuid.UUID `json:"session_id"`
 	}
@@ -40,6 +59,11 @@ type (
 	StudentEnrollListByStudentParam struct {
 		StudentID uuid.UUID `json:"student_id"`
 	}
+
+	StudentEnrollListResponse struct {
+		StudentEnrolls []models.StudentEnrollResponse   `json:"student_enrolls"`
+		Waitlists      []models.StudentWaitlistResponse `json:"waitlists"`
+	}
 )
 
 func NewStudentEnrollModule(db *sql.DB, cache *redis.Pool, logger *helpers.Logger) *StudentEnrollModule {
@@ -73,95 +97,270 @@ func (s StudentEnrollModule) Detail(ctx context.Context, param StudentEnrollDeta
 func (s StudentEnrollModule) Add(ctx context.Context, param StudentEnrollAddParam) (interface{}, *helpers.Error) {
 
 	studentID := uuid.FromStringOrNil(ctx.Value("user_id").(string))
-	student, err := models.GetOneStudent(ctx, s.db, studentID)
+
+	session, err := models.GetOneSession(ctx, s.db, param.SessionID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Add/GetOneSession", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	intake, err := models.GetOneIntake(ctx, s.db, session.IntakeID)
 	if err != nil {
-		return nil, helpers.ErrorWrap(err, s.name, "Add/GetOneStudent", helpers.InternalServerError,
+		return nil, helpers.ErrorWrap(err, s.name, "Add/GetOneIntake", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	enrollPeriods, err := models.GetAllAcademicPeriodByIntake(ctx, s.db, intake.ID, models.ACADEMIC_PERIOD_ENROLLMENT)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Add/GetAllAcademicPeriodByIntake", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	addDropPeriods, err := models.GetAllAcademicPeriodByIntake(ctx, s.db, intake.ID, models.ACADEMIC_PERIOD_ADD_DROP)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Add/GetAllAcademicPeriodByIntake", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	// Intakes without an enrollment or add/drop period in the calendar keep the default window before the
+	// start date.
+	enrollPeriods = append(enrollPeriods, addDropPeriods...)
+	if len(enrollPeriods) == 0 {
+		enrollPeriods = append(enrollPeriods, models.AcademicPeriodModel{
+			StartDate: intake.StartDate.AddDate(0, 0, -ENROLLMENT_WINDOW_DAYS),
+			EndDate:   intake.StartDate.AddDate(0, 0, -1),
+		})
+	}
+
+	if !isInAcademicPeriods(enrollPeriods, time.Now()) {
+		return nil, helpers.ErrorWrap(errors.New("Invalid Time To Enroll"), s.name, "Add/ValidationDate",
+			helpers.InternalServerError,
 			http.StatusInternalServerError)
 	}
 
-	studentProgramID := student.ProgramID
+	return s.enroll(ctx, "Add", session, studentID, "")
+}
+
+// AddLate enrolls a student outside of the intake's enrollment windows. The reason is kept on the
+// enrollment; every other enrollment rule still applies.
+func (s StudentEnrollModule) AddLate(ctx context.Context, param StudentEnrollAddLateParam) (
+	interface{}, *helpers.Error) {
 
 	session, err := models.GetOneSession(ctx, s.db, param.SessionID)
 	if err != nil {
-		return nil, helpers.ErrorWrap(err, s.name, "Add/GetOneSession", helpers.InternalServerError,
+		return nil, helpers.ErrorWrap(err, s.name, "AddLate/GetOneSession", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	return s.enroll(ctx, "AddLate", session, param.StudentID, param.Reason)
+}
+
+func (s StudentEnrollModule) enroll(ctx context.Context, step string, session models.SessionModel,
+	studentID uuid.UUID, lateReason string) (interface{}, *helpers.Error) {
+
+	eligibility, errEligible := s.checkEnrollEligible(ctx, step, session, studentID)
+	if errEligible != nil {
+		return eligibility, errEligible
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, step+"/BeginTx", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	defer tx.Rollback()
+
+	capacity, err := models.GetSessionCapacityForUpdate(ctx, tx, session.ID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, step+"/GetSessionCapacityForUpdate", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	enrolled, err := models.CountStudentEnrollBySession(ctx, tx, session.ID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, step+"/CountStudentEnrollBySession", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	if capacity > 0 && enrolled >= capacity {
+		return s.addWaitlist(ctx, tx, session.ID, studentID)
+	}
+
+	studentEnroll, err := s.insertStudentEnroll(ctx, tx, session.ID, studentID, lateReason)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, step+"/InsertStudentEnroll", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	err = s.notifyStudentEnroll(ctx, tx, session.ID, studentID, models.NOTIFICATION_ENROLLMENT_ACCEPTED,
+		"Enrollment Accepted", "are enrolled in")
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, step+"/NotifyStudentEnroll", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, step+"/Commit", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	response, err := studentEnroll.Response(ctx, s.db, s.logger)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, step+"/Response", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	return response, nil
+}
+
+// checkEnrollEligible applies the rules that depend on the student rather than on the seats left: their
+// status, an existing enrollment, the program, the prerequisites and clashes with their timetable. A
+// rejected student gets a 4xx error, the unmet prerequisites or clashing sessions are returned with it.
+func (s StudentEnrollModule) checkEnrollEligible(ctx context.Context, step string, session models.SessionModel,
+	studentID uuid.UUID) (interface{}, *helpers.Error) {
+
+	student, err := models.GetOneStudent(ctx, s.db, studentID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, step+"/GetOneStudent", helpers.InternalServerError,
 			http.StatusInternalServerError)
 	}
 
-	studentEnroll, err := models.GetOneStudentEnrollBySessionAndStudentID(ctx, s.db, param.SessionID, studentID)
+	if student.Status != models.STUDENT_ENROLLED {
+		return nil, helpers.ErrorWrap(errors.New("Student Is "+student.Status), s.name, step+"/ValidationStatus",
+			helpers.StudentStatusMessage,
+			http.StatusForbidden)
+	}
+
+	studentEnroll, err := models.GetOneStudentEnrollBySessionAndStudentID(ctx, s.db, session.ID, studentID)
 	if err != nil && err != sql.ErrNoRows {
-		return nil, helpers.ErrorWrap(err, s.name, "Add/GetOneStudentEnrollBySessionAndStudentID",
+		return nil, helpers.ErrorWrap(err, s.name, step+"/GetOneStudentEnrollBySessionAndStudentID",
 			helpers.InternalServerError,
 			http.StatusInternalServerError)
 	}
 
-	if studentEnroll.SessionID == param.SessionID {
+	if studentEnroll.SessionID == session.ID {
 		return nil, helpers.ErrorWrap(errors.New("You have already enroll this session"), s.name,
-			"Add/ValidationSession",
+			step+"/ValidationSession",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	if session.ProgramID != student.ProgramID {
+		return nil, helpers.ErrorWrap(errors.New("This Session Is Not For Your Program"), s.name,
+			step+"/ValidationProgram",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+	unmet, prerequisiteErr := checkSubjectPrerequisite(ctx, s.db, s.logger, s.name, studentID, session.SubjectID)
+	if prerequisiteErr != nil {
+		return unmet, prerequisiteErr
+	}
+
+	conflicts, err := models.GetAllSessionConflictByStudent(ctx, s.db, studentID, session)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, step+"/GetAllSessionConflictByStudent",
 			helpers.InternalServerError,
 			http.StatusInternalServerError)
 	}
 
-	sessionProgramID := session.ProgramID
+	if len(conflicts) > 0 {
+		conflictsResponse, err := sessionConflictResponse(ctx, s.db, s.logger, conflicts)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, step+"/SessionConflictResponse", helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+
+		return conflictsResponse, helpers.ErrorWrap(errors.New("Session Clashes With Your Enrolled Sessions"),
+			s.name, step+"/ValidationConflict",
+			helpers.SessionConflictMessage,
+			http.StatusConflict)
+	}
+
+	return nil, nil
+}
 
-	fmt.Println(sessionProgramID)
-	fmt.Println(studentProgramID)
+func (s StudentEnrollModule) insertStudentEnroll(ctx context.Context, tx *sql.Tx, sessionID uuid.UUID,
+	studentID uuid.UUID, lateReason string) (models.StudentEnrollModel, error) {
 
-	intake, err := models.GetOneIntake(ctx, s.db, session.IntakeID)
+	studentEnroll := models.StudentEnrollModel{
+		SessionID:  sessionID,
+		StudentID:  studentID,
+		LateReason: lateReason,
+		CreatedBy:  uuid.FromStringOrNil(ctx.Value("user_id").(string)),
+	}
+
+	err := studentEnroll.Insert(ctx, tx)
 	if err != nil {
-		return nil, helpers.ErrorWrap(err, s.name, "Add/GetOneIntake", helpers.InternalServerError,
-			http.StatusInternalServerError)
+		return models.StudentEnrollModel{}, err
 	}
 
-	startDate := intake.StartDate
+	result := models.ResultModel{
+		StudentEnrollID: studentEnroll.ID,
+		CreatedBy:       uuid.FromStringOrNil(ctx.Value("user_id").(string)),
+	}
 
-	enrollDateStart := startDate.AddDate(0, 0, -5)
-	enrollDateEnd := startDate.Add(-1 * time.Second)
+	err = result.Insert(ctx, tx)
+	if err != nil {
+		return models.StudentEnrollModel{}, err
+	}
 
-	//fmt.Println(enrollDateStart)
-	//fmt.Println(enrollDateEnd)
+	return studentEnroll, nil
+}
 
-	now := time.Now()
-	//)fmt.Println(now)
+func (s StudentEnrollModule) addWaitlist(ctx context.Context, tx *sql.Tx, sessionID uuid.UUID,
+	studentID uuid.UUID) (interface{}, *helpers.Error) {
 
-	if now.After(enrollDateEnd) || now.Before(enrollDateStart) {
-		return nil, helpers.ErrorWrap(errors.New("Invalid Time To Enroll"), s.name, "Add/ValidationDate",
+	_, err := models.GetOneStudentWaitlistBySessionAndStudentID(ctx, tx, sessionID, studentID)
+	if err == nil {
+		return nil, helpers.ErrorWrap(errors.New("You are already on the waitlist of this session"), s.name,
+			"AddWaitlist/ValidationWaitlist",
 			helpers.InternalServerError,
 			http.StatusInternalServerError)
 	}
 
-	if sessionProgramID != studentProgramID {
-		return nil, helpers.ErrorWrap(errors.New("This Session Is Not For Your Program"), s.name,
-			"Add/ValidationProgram",
+	if err != sql.ErrNoRows {
+		return nil, helpers.ErrorWrap(err, s.name, "AddWaitlist/GetOneStudentWaitlistBySessionAndStudentID",
 			helpers.InternalServerError,
 			http.StatusInternalServerError)
 	}
-	////fmt.Println(now.Before(enrollDateEnd) && now.After(enrollDateStart))
-	studentEnroll = models.StudentEnrollModel{
-		SessionID: param.SessionID,
-		StudentID: uuid.FromStringOrNil(ctx.Value("user_id").(string)),
+
+	waitlist := models.StudentWaitlistModel{
+		SessionID: sessionID,
+		StudentID: studentID,
 		CreatedBy: uuid.FromStringOrNil(ctx.Value("user_id").(string)),
 	}
 
-	err = studentEnroll.Insert(ctx, s.db)
+	err = waitlist.Insert(ctx, tx)
+	if helpers.IsUniqueViolation(err) {
+		return nil, helpers.ErrorWrap(errors.New("You are already on the waitlist of this session"), s.name,
+			"AddWaitlist/ValidationWaitlist",
+			helpers.BadRequestMessage,
+			http.StatusConflict)
+	}
+
 	if err != nil {
-		return nil, helpers.ErrorWrap(err, s.name, "Add/StudentEnrollInsert", helpers.InternalServerError,
+		return nil, helpers.ErrorWrap(err, s.name, "AddWaitlist/Insert", helpers.InternalServerError,
 			http.StatusInternalServerError)
 	}
 
-	result := models.ResultModel{
-		StudentEnrollID: studentEnroll.ID,
-		CreatedBy:       uuid.FromStringOrNil(ctx.Value("user_id").(string)),
+	err = s.notifyStudentEnroll(ctx, tx, sessionID, studentID, models.NOTIFICATION_ENROLLMENT_WAITLISTED,
+		"Added To Waitlist", "are on the waitlist of")
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "AddWaitlist/NotifyStudentEnroll", helpers.InternalServerError,
+			http.StatusInternalServerError)
 	}
 
-	err = result.Insert(ctx, s.db)
+	err = tx.Commit()
 	if err != nil {
-		return nil, helpers.ErrorWrap(err, s.name, "Add/ResultInsert", helpers.InternalServerError,
+		return nil, helpers.ErrorWrap(err, s.name, "AddWaitlist/Commit", helpers.InternalServerError,
 			http.StatusInternalServerError)
 	}
 
-	response, err := studentEnroll.Response(ctx, s.db, s.logger)
+	response, err := waitlist.Response(ctx, s.db, s.logger)
 	if err != nil {
-		return nil, helpers.ErrorWrap(err, s.name, "Add/Response", helpers.InternalServerError,
+		return nil, helpers.ErrorWrap(err, s.name, "AddWaitlist/Response", helpers.InternalServerError,
 			http.StatusInternalServerError)
 	}
 
@@ -198,7 +397,34 @@ func (s StudentEnrollModule) ListByStudent(ctx context.Context, filter helpers.F
 		studentResponse = append(studentResponse, response)
 	}
 
-	return studentResponse, nil
+	waitlists, err := models.GetAllStudentWaitlistByStudent(ctx, s.db, helpers.Filter{
+		FilterOption: helpers.FilterOption{
+			Limit:  999,
+			Offset: 0,
+		},
+
+		StudentID: studentID,
+	})
+
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "List/GetAllStudentWaitlistByStudent", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	var waitlistResponse []models.StudentWaitlistResponse
+	for _, waitlist := range waitlists {
+		response, err := waitlist.Response(ctx, s.db, s.logger)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "List/waitlistResponse", helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+		waitlistResponse = append(waitlistResponse, response)
+	}
+
+	return StudentEnrollListResponse{
+		StudentEnrolls: studentResponse,
+		Waitlists:      waitlistResponse,
+	}, nil
 }
 
 func (s StudentEnrollModule) ListBySession(ctx context.Context, filter helpers.Filter,
@@ -234,17 +460,9 @@ func (s StudentEnrollModule) ListBySession(ctx context.Context, filter helpers.F
 
 func (s StudentEnrollModule) Delete(ctx context.Context, param StudentEnrollDeleteParam) (interface{}, *helpers.Error) {
 
-	studentEnroll := models.StudentEnrollModel{
-		ID: param.ID,
-		UpdatedBy: uuid.NullUUID{
-			UUID:  uuid.FromStringOrNil(ctx.Value("user_id").(string)),
-			Valid: true,
-		},
-	}
-
-	err := studentEnroll.Delete(ctx, s.db)
+	current, err := models.GetOneStudentEnroll(ctx, s.db, param.ID)
 	if err != nil {
-		return nil, helpers.ErrorWrap(err, s.name, "Delete/Delete", helpers.InternalServerError,
+		return nil, helpers.ErrorWrap(err, s.name, "Delete/GetOneStudentEnroll", helpers.InternalServerError,
 			http.StatusInternalServerError)
 	}
 
@@ -262,6 +480,34 @@ func (s StudentEnrollModule) Delete(ctx context.Context, param StudentEnrollDele
 			http.StatusInternalServerError)
 	}
 
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Delete/BeginTx", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	defer tx.Rollback()
+
+	capacity, err := models.GetSessionCapacityForUpdate(ctx, tx, current.SessionID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Delete/GetSessionCapacityForUpdate", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	studentEnroll := models.StudentEnrollModel{
+		ID: param.ID,
+		UpdatedBy: uuid.NullUUID{
+			UUID:  uuid.FromStringOrNil(ctx.Value("user_id").(string)),
+			Valid: true,
+		},
+	}
+
+	err = studentEnroll.Delete(ctx, tx)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Delete/Delete", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
 	for _, result := range results {
 		result := models.ResultModel{
 			ID: result.ID,
@@ -271,13 +517,330 @@ func (s StudentEnrollModule) Delete(ctx context.Context, param StudentEnrollDele
 			},
 		}
 
-		err = result.Delete(ctx, s.db)
+		err = result.Delete(ctx, tx)
 		if err != nil {
-			return nil, helpers.ErrorWrap(err, s.name, "Add/ResultDelete", helpers.InternalServerError,
+			return nil, helpers.ErrorWrap(err, s.name, "Delete/ResultDelete", helpers.InternalServerError,
 				http.StatusInternalServerError)
 		}
 	}
 
+	err = s.notifyStudentEnroll(ctx, tx, current.SessionID, current.StudentID,
+		models.NOTIFICATION_ENROLLMENT_CANCELLED, "Enrollment Cancelled", "are no longer enrolled in")
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Delete/NotifyStudentEnroll", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	err = s.promoteWaitlist(ctx, tx, current.SessionID, capacity)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Delete/PromoteWaitlist", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Delete/Commit", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
 	return nil, nil
 
 }
+
+func (s StudentEnrollModule) promoteWaitlist(ctx context.Context, tx *sql.Tx, sessionID uuid.UUID, capacity int) error {
+
+	enrolled, err := models.CountStudentEnrollBySession(ctx, tx, sessionID)
+	if err != nil {
+		return err
+	}
+
+	if capacity > 0 && enrolled >= capacity {
+		return nil
+	}
+
+	classSession, err := models.GetOneSession(ctx, s.db, sessionID)
+	if err != nil {
+		return err
+	}
+
+	updatedBy := uuid.NullUUID{
+		UUID:  uuid.FromStringOrNil(ctx.Value("user_id").(string)),
+		Valid: true,
+	}
+
+	// The students ahead in the queue who can no longer enroll, because their status changed or they took a
+	// clashing session while waiting, are taken off the waitlist and the next one is tried.
+	var waitlist models.StudentWaitlistModel
+	for {
+		waitlist, err = models.GetFirstStudentWaitlistBySession(ctx, tx, sessionID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil
+			}
+			return err
+		}
+
+		_, errEligible := s.checkEnrollEligible(ctx, "PromoteWaitlist", classSession, waitlist.StudentID)
+		if errEligible == nil {
+			break
+		}
+
+		if errEligible.StatusCode >= http.StatusInternalServerError {
+			return errEligible.Err
+		}
+
+		waitlist.UpdatedBy = updatedBy
+		err = waitlist.Delete(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		err = s.notifyStudentEnroll(ctx, tx, sessionID, waitlist.StudentID, models.NOTIFICATION_ENROLLMENT_CANCELLED,
+			"Removed From Waitlist", "can no longer enroll and have been removed from the waitlist of")
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = s.insertStudentEnroll(ctx, tx, sessionID, waitlist.StudentID, "")
+	if err != nil {
+		return err
+	}
+
+	err = s.notifyStudentEnroll(ctx, tx, sessionID, waitlist.StudentID, models.NOTIFICATION_ENROLLMENT_ACCEPTED,
+		"Enrollment Accepted", "have been moved from the waitlist into")
+	if err != nil {
+		return err
+	}
+
+	waitlist.UpdatedBy = updatedBy
+
+	return waitlist.Delete(ctx, tx)
+}
+
+// notifyStudentEnroll tells the student about a change to their enrollment in the session, inside the
+// transaction making the change.
+func (s StudentEnrollModule) notifyStudentEnroll(ctx context.Context, tx *sql.Tx, sessionID uuid.UUID,
+	studentID uuid.UUID, notificationType string, title string, change string) error {
+
+	classSession, err := models.GetOneSession(ctx, s.db, sessionID)
+	if err != nil {
+		return err
+	}
+
+	subject, err := models.GetOneSubject(ctx, s.db, classSession.SubjectID)
+	if err != nil {
+		return err
+	}
+
+	return notifyUsers(ctx, tx, []uuid.UUID{studentID}, session.STUDENT_ROLE, notificationType, title,
+		fmt.Sprintf("You %s %s.", change, subject.Name), sessionID)
+}
+
+// Drop removes the student's own enrollment while the intake's add/drop period is open, the same way an
+// admin delete does.
+func (s StudentEnrollModule) Drop(ctx context.Context, param StudentEnrollDropParam) (interface{}, *helpers.Error) {
+
+	studentEnroll, intake, errEnroll := s.checkOwnStudentEnroll(ctx, "Drop", param.ID)
+	if errEnroll != nil {
+		return nil, errEnroll
+	}
+
+	addDropPeriods, err := models.GetAllAcademicPeriodByIntake(ctx, s.db, intake.ID, models.ACADEMIC_PERIOD_ADD_DROP)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Drop/GetAllAcademicPeriodByIntake", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	if !isInAcademicPeriods(addDropPeriods, time.Now()) {
+		return nil, helpers.ErrorWrap(errors.New("Add/Drop Period Is Closed"), s.name, "Drop/ValidationDate",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	return s.Delete(ctx, StudentEnrollDeleteParam{ID: studentEnroll.ID})
+}
+
+// Withdraw keeps the student's enrollment after add/drop and records a W grade on its results instead of
+// deleting them. The seat goes to the waitlist and the student's remaining classes are dropped. It is only
+// allowed inside the intake's withdraw period.
+func (s StudentEnrollModule) Withdraw(ctx context.Context, param StudentEnrollWithdrawParam) (
+	interface{}, *helpers.Error) {
+
+	studentEnroll, intake, errEnroll := s.checkOwnStudentEnroll(ctx, "Withdraw", param.ID)
+	if errEnroll != nil {
+		return nil, errEnroll
+	}
+
+	now := time.Now()
+
+	addDropPeriods, err := models.GetAllAcademicPeriodByIntake(ctx, s.db, intake.ID, models.ACADEMIC_PERIOD_ADD_DROP)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Withdraw/GetAllAcademicPeriodByIntake",
+			helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	if isInAcademicPeriods(addDropPeriods, now) {
+		return nil, helpers.ErrorWrap(errors.New("Drop The Session During Add/Drop"), s.name,
+			"Withdraw/ValidationAddDrop",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	withdrawPeriods, err := models.GetAllAcademicPeriodByIntake(ctx, s.db, intake.ID, models.ACADEMIC_PERIOD_WITHDRAW)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Withdraw/GetAllAcademicPeriodByIntake",
+			helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	if !isInAcademicPeriods(withdrawPeriods, now) {
+		return nil, helpers.ErrorWrap(errors.New("Withdraw Period Is Closed"), s.name, "Withdraw/ValidationDate",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	if studentEnroll.Status == models.STUDENT_ENROLL_WITHDRAWN {
+		return nil, helpers.ErrorWrap(errors.New("You Have Already Withdrawn From This Session"), s.name,
+			"Withdraw/ValidationWithdrawn",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	results, err := models.GetAllResultByStudentEnroll(ctx, s.db, helpers.Filter{
+		FilterOption: helpers.FilterOption{
+			Limit:  999,
+			Offset: 0,
+		},
+		StudentEnrollID: studentEnroll.ID,
+	})
+
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Withdraw/GetAllResultByStudentEnroll", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Withdraw/BeginTx", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	defer tx.Rollback()
+
+	capacity, err := models.GetSessionCapacityForUpdate(ctx, tx, studentEnroll.SessionID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Withdraw/GetSessionCapacityForUpdate", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	for _, result := range results {
+		if result.IsDelete {
+			continue
+		}
+
+		if result.Grade == models.RESULT_GRADE_WITHDRAWN {
+			return nil, helpers.ErrorWrap(errors.New("You Have Already Withdrawn From This Session"), s.name,
+				"Withdraw/ValidationWithdrawn",
+				helpers.BadRequestMessage,
+				http.StatusBadRequest)
+		}
+
+		if !isResultEditable(result.Status) {
+			return nil, helpers.ErrorWrap(errors.New("Result Is Locked"), s.name, "Withdraw/ValidationStatus",
+				helpers.BadRequestMessage,
+				http.StatusBadRequest)
+		}
+
+		result.Marks = 0
+		result.Grade = models.RESULT_GRADE_WITHDRAWN
+		result.GradePoint = 0
+		result.GradingSchemeID = uuid.NullUUID{}
+		result.UpdatedBy = uuid.NullUUID{
+			UUID:  uuid.FromStringOrNil(ctx.Value("user_id").(string)),
+			Valid: true,
+		}
+
+		err = result.Update(ctx, tx)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "Withdraw/ResultUpdate", helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+	}
+
+	studentEnroll.UpdatedBy = uuid.NullUUID{
+		UUID:  uuid.FromStringOrNil(ctx.Value("user_id").(string)),
+		Valid: true,
+	}
+
+	err = studentEnroll.Withdraw(ctx, tx)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Withdraw/Withdraw", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	err = models.DeleteAttendanceByStudentFrom(ctx, tx, studentEnroll.SessionID, studentEnroll.StudentID,
+		truncateDate(now))
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Withdraw/DeleteAttendanceByStudentFrom",
+			helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	err = s.promoteWaitlist(ctx, tx, studentEnroll.SessionID, capacity)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Withdraw/PromoteWaitlist", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Withdraw/Commit", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	response, err := studentEnroll.Response(ctx, s.db, s.logger)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Withdraw/Response", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	return response, nil
+}
+
+// checkOwnStudentEnroll loads an enrollment of the current student together with the intake of its
+// session.
+func (s StudentEnrollModule) checkOwnStudentEnroll(ctx context.Context, step string, studentEnrollID uuid.UUID) (
+	models.StudentEnrollModel, models.IntakeModel, *helpers.Error) {
+
+	studentEnroll, err := models.GetOneStudentEnroll(ctx, s.db, studentEnrollID)
+	if err != nil {
+		return models.StudentEnrollModel{}, models.IntakeModel{}, helpers.ErrorWrap(err, s.name,
+			step+"/GetOneStudentEnroll", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	if studentEnroll.IsDelete || studentEnroll.StudentID != uuid.FromStringOrNil(ctx.Value("user_id").(string)) {
+		return models.StudentEnrollModel{}, models.IntakeModel{}, helpers.ErrorWrap(
+			errors.New("Enrollment Does Not Belong To Student"), s.name, step+"/ValidationStudent",
+			helpers.ForbiddenMessage,
+			http.StatusForbidden)
+	}
+
+	session, err := models.GetOneSession(ctx, s.db, studentEnroll.SessionID)
+	if err != nil {
+		return models.StudentEnrollModel{}, models.IntakeModel{}, helpers.ErrorWrap(err, s.name,
+			step+"/GetOneSession", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	intake, err := models.GetOneIntake(ctx, s.db, session.IntakeID)
+	if err != nil {
+		return models.StudentEnrollModel{}, models.IntakeModel{}, helpers.ErrorWrap(err, s.name,
+			step+"/GetOneIntake", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	return studentEnroll, intake, nil
+}
@@ -168,6 +168,12 @@ func (s StudentModule) Login(ctx context.Context, param StudentLoginParam) (inte
 			http.StatusInternalServerError)
 	}
 
+	if !canStudentLogin(student.Status) {
+		return nil, helpers.ErrorWrap(errors.New("Student Is "+student.Status), s.name, "Login/ValidationStatus",
+			helpers.StudentStatusMessage,
+			http.StatusForbidden)
+	}
+
 	session := session.Session{
 		UserID:     student.ID,
 		SessionKey: fmt.Sprintf(`%s:%s`, session.USER_SESSION, uuid.NewV4()),
@@ -286,36 +292,26 @@ func (s StudentModule) Detail(ctx context.Context, param StudentDetailParam) (in
 
 func (s StudentModule) Add(ctx context.Context, param StudentAddParam) (interface{}, *helpers.Error) {
 
-	password := util.RandomString(12)
-
-	program, err := models.GetOneProgram(ctx, s.db, param.ProgramID)
-
+	password, err := util.RandomPassword(12)
 	if err != nil {
-		return nil, helpers.ErrorWrap(err, s.name, "Add/GetOneProgram", helpers.InternalServerError,
+		return nil, helpers.ErrorWrap(err, s.name, "Add/RandomPassword", helpers.InternalServerError,
 			http.StatusInternalServerError)
 	}
 
-	programCode := program.Code
-
-	facultyID := program.FacultyID
-
-	faculty, err := models.GetOneFaculty(ctx, s.db, facultyID)
-
+	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
-		return nil, helpers.ErrorWrap(err, s.name, "Add/GetOneFaculty", helpers.InternalServerError,
+		return nil, helpers.ErrorWrap(err, s.name, "Add/BeginTx", helpers.InternalServerError,
 			http.StatusInternalServerError)
 	}
 
-	facultyCode := faculty.Code
+	defer tx.Rollback()
 
-	yearCode, err := util.GetYearCode()
+	studentCode, err := s.allocateStudentCode(ctx, tx, param.ProgramID, time.Now().Year())
 	if err != nil {
-		return nil, helpers.ErrorWrap(err, s.name, "Add/GetYearCode", helpers.InternalServerError,
+		return nil, helpers.ErrorWrap(err, s.name, "Add/allocateStudentCode", helpers.InternalServerError,
 			http.StatusInternalServerError)
 	}
 
-	studentCode := fmt.Sprintf("%d%d%d%d%d%s", 1, yearCode, programCode, 0, facultyCode, "000")
-
 	student := models.StudentModel{
 		Name:        param.Name,
 		ProgramID:   param.ProgramID,
@@ -326,15 +322,28 @@ func (s StudentModule) Add(ctx context.Context, param StudentAddParam) (interfac
 		Password:    password,
 		Email:       param.Email,
 		PhoneNo:     param.PhoneNo,
+		Status:      models.STUDENT_ENROLLED,
 		CreatedBy:   uuid.FromStringOrNil(ctx.Value("user_id").(string)),
 	}
 
-	err = student.Insert(ctx, s.db)
+	err = student.Insert(ctx, tx)
+	if helpers.IsUniqueViolation(err) {
+		return nil, helpers.ErrorWrap(errors.New("Email Already Registered"), s.name, "Add/ValidationEmail",
+			helpers.BadRequestMessage,
+			http.StatusConflict)
+	}
+
 	if err != nil {
 		return nil, helpers.ErrorWrap(err, s.name, "Add/Insert", helpers.InternalServerError,
 			http.StatusInternalServerError)
 	}
 
+	err = tx.Commit()
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Add/Commit", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
 	response, err := student.Response(ctx, s.db, s.logger)
 	if err != nil {
 		return nil, helpers.ErrorWrap(err, s.name, "Add/Response", helpers.InternalServerError,
@@ -377,19 +386,38 @@ func (s StudentModule) Update(ctx context.Context, param StudentUpdateParam) (in
 
 }
 
+// Delete withdraws the student. The record and its history are kept.
 func (s StudentModule) Delete(ctx context.Context, param StudentDeleteParam) (interface{}, *helpers.Error) {
 
-	student := models.StudentModel{
-		ID: param.ID,
-		UpdatedBy: uuid.NullUUID{
-			UUID:  uuid.FromStringOrNil(ctx.Value("user_id").(string)),
-			Valid: true,
-		},
+	student, err := models.GetOneStudent(ctx, s.db, param.ID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Delete/GetOneStudent", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Delete/BeginTx", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	defer tx.Rollback()
+
+	errStatus := transitionStudentStatus(ctx, tx, s.name, "Delete", student.ID, student.Status,
+		models.STUDENT_WITHDRAWN, "Student Record Deleted", time.Now())
+	if errStatus != nil {
+		return nil, errStatus
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Delete/Commit", helpers.InternalServerError,
+			http.StatusInternalServerError)
 	}
 
-	err := student.Delete(ctx, s.db)
+	err = session.DeleteUserSession(ctx, student.ID, session.STUDENT_ROLE)
 	if err != nil {
-		return nil, helpers.ErrorWrap(err, s.name, "Delete/Delete", helpers.InternalServerError,
+		return nil, helpers.ErrorWrap(err, s.name, "Delete/DeleteUserSession", helpers.InternalServerError,
 			http.StatusInternalServerError)
 	}
 
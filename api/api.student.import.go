@@ -0,0 +1,413 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"github.com/asaskevich/govalidator"
+	"github.com/gomodule/redigo/redis"
+	uuid "github.com/satori/go.uuid"
+	"github.com/spf13/viper"
+	"net/http"
+	"school/helpers"
+	"school/models"
+	"school/util"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	STUDENT_IMPORT_VALID   = "valid"
+	STUDENT_IMPORT_CREATED = "created"
+	STUDENT_IMPORT_ERROR   = "error"
+
+	STUDENT_CREDENTIAL_DOWNLOAD = "download"
+	STUDENT_CREDENTIAL_EMAIL    = "email"
+
+	// STUDENT_CREDENTIAL_TTL is used when student.credential_ttl (seconds) is not configured.
+	STUDENT_CREDENTIAL_TTL = 3600
+)
+
+type (
+	StudentImportParam struct {
+		DryRun   bool       `json:"dry_run"`
+		Delivery string     `json:"delivery"`
+		Records  [][]string `json:"records"`
+	}
+
+	StudentCredentialParam struct {
+		Token string `json:"token"`
+	}
+
+	StudentImportRowResponse struct {
+		Row         int    `json:"row"`
+		Name        string `json:"name"`
+		Email       string `json:"email"`
+		StudentCode string `json:"student_code,omitempty"`
+		Status      string `json:"status"`
+		Error       string `json:"error,omitempty"`
+	}
+
+	StudentImportResponse struct {
+		DryRun           bool                       `json:"dry_run"`
+		Delivery         string                     `json:"delivery"`
+		Total            int                        `json:"total"`
+		Created          int                        `json:"created"`
+		Failed           int                        `json:"failed"`
+		Queued           int                        `json:"queued"`
+		CredentialToken  string                     `json:"credential_token,omitempty"`
+		CredentialExpiry int                        `json:"credential_expiry,omitempty"`
+		Rows             []StudentImportRowResponse `json:"rows"`
+	}
+)
+
+// Import creates a student for every row of the file with a generated initial password. The whole file is
+// validated first, and nothing is written when a row fails or DryRun is set. The passwords are either kept
+// for a single download of CredentialToken or mailed to each student; mails that cannot be queued fall
+// back to the download.
+func (s StudentModule) Import(ctx context.Context, param StudentImportParam) (interface{}, *helpers.Error) {
+
+	delivery := param.Delivery
+	if delivery == "" {
+		delivery = STUDENT_CREDENTIAL_DOWNLOAD
+	}
+
+	if delivery != STUDENT_CREDENTIAL_DOWNLOAD && delivery != STUDENT_CREDENTIAL_EMAIL {
+		return nil, helpers.ErrorWrap(errors.New("Invalid Delivery"), s.name, "Import/ValidationDelivery",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	if len(param.Records) == 0 {
+		return nil, helpers.ErrorWrap(errors.New("Empty File"), s.name, "Import/ValidationFile",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	columns := make(map[string]int)
+	for i, column := range param.Records[0] {
+		columns[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+
+	for _, column := range []string{"name", "email", "program", "date_of_birth", "gender", "phone_no"} {
+		if _, ok := columns[column]; !ok {
+			return nil, helpers.ErrorWrap(errors.New("Header Must Contain "+column), s.name,
+				"Import/ValidationHeader",
+				helpers.BadRequestMessage,
+				http.StatusBadRequest)
+		}
+	}
+
+	programs, err := models.GetAllProgram(ctx, s.db, helpers.Filter{
+		FilterOption: helpers.FilterOption{
+			Limit:  999,
+			Offset: 0,
+		},
+	})
+
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Import/GetAllProgram", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	response := StudentImportResponse{
+		DryRun:   param.DryRun,
+		Delivery: delivery,
+	}
+
+	var students []models.StudentModel
+	var studentRows []int
+	seen := make(map[string]bool)
+
+	for i, record := range param.Records[1:] {
+		value := func(column string) string {
+			index, ok := columns[column]
+			if !ok || index >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[index])
+		}
+
+		row := StudentImportRowResponse{
+			Row:    i + 2,
+			Name:   value("name"),
+			Email:  value("email"),
+			Status: STUDENT_IMPORT_VALID,
+		}
+
+		response.Total++
+
+		student := models.StudentModel{
+			Name:      row.Name,
+			Address:   value("address"),
+			Email:     row.Email,
+			PhoneNo:   value("phone_no"),
+			Status:    models.STUDENT_ENROLLED,
+			CreatedBy: uuid.FromStringOrNil(ctx.Value("user_id").(string)),
+		}
+
+		program, errProgram := findImportProgram(programs, value("program"))
+		dateOfBirth, errDateOfBirth := parseImportDate(value("date_of_birth"))
+		gender, errGender := parseImportGender(value("gender"))
+
+		email := strings.ToLower(row.Email)
+
+		switch {
+		case len(row.Name) < 3 || len(row.Name) > 50:
+			row.Error = "Name Must Be Between 3 And 50 Characters"
+		case !govalidator.IsEmail(row.Email):
+			row.Error = "Invalid Email"
+		case seen[email]:
+			row.Error = "Duplicate Email In File"
+		case errProgram != nil:
+			row.Error = errProgram.Error()
+		case errDateOfBirth != nil:
+			row.Error = "Invalid Date Of Birth"
+		case errGender != nil:
+			row.Error = "Gender Must Be Male Or Female"
+		case len(student.PhoneNo) < 10 || len(student.PhoneNo) > 15:
+			row.Error = "Phone No Must Be Between 10 And 15 Characters"
+		}
+
+		if row.Email != "" {
+			seen[email] = true
+		}
+
+		if row.Error == "" {
+			count, err := models.CountStudentByEmail(ctx, s.db, row.Email)
+			if err != nil {
+				return nil, helpers.ErrorWrap(err, s.name, "Import/CountStudentByEmail", helpers.InternalServerError,
+					http.StatusInternalServerError)
+			}
+
+			if count > 0 {
+				row.Error = "Email Already Registered"
+			}
+		}
+
+		if row.Error != "" {
+			row.Status = STUDENT_IMPORT_ERROR
+			response.Failed++
+			response.Rows = append(response.Rows, row)
+			continue
+		}
+
+		student.ProgramID = program.ID
+		student.DateOfBirth = dateOfBirth
+		student.Gender = gender
+
+		students = append(students, student)
+		studentRows = append(studentRows, len(response.Rows))
+		response.Rows = append(response.Rows, row)
+	}
+
+	if response.Failed > 0 {
+		return response, helpers.ErrorWrap(errors.New("Import Has Invalid Rows"), s.name, "Import/ValidationRow",
+			helpers.ImportValidationMessage,
+			http.StatusUnprocessableEntity)
+	}
+
+	if param.DryRun {
+		return response, nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Import/BeginTx", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	defer tx.Rollback()
+
+	now := time.Now()
+	var passwords []string
+	for i := range students {
+		students[i].StudentCode, err = s.allocateStudentCode(ctx, tx, students[i].ProgramID, now.Year())
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "Import/allocateStudentCode", helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+
+		password, err := util.RandomPassword(12)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "Import/RandomPassword", helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+
+		students[i].Password = password
+
+		err = students[i].Insert(ctx, tx)
+		if helpers.IsUniqueViolation(err) {
+			return nil, helpers.ErrorWrap(errors.New("Email Already Registered: "+students[i].Email), s.name,
+				"Import/ValidationEmail",
+				helpers.ImportValidationMessage,
+				http.StatusConflict)
+		}
+
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "Import/Insert", helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+
+		passwords = append(passwords, password)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Import/Commit", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	writer.Write([]string{"student_code", "name", "email", "password"})
+
+	download := 0
+	for i, student := range students {
+		row := &response.Rows[studentRows[i]]
+		row.StudentCode = student.StudentCode
+		row.Status = STUDENT_IMPORT_CREATED
+		response.Created++
+
+		if delivery == STUDENT_CREDENTIAL_EMAIL {
+			err = helpers.QueueMail(ctx, studentCredentialMail(student, passwords[i]))
+			if err == nil {
+				response.Queued++
+				continue
+			}
+
+			s.logger.Err.Printf(`api.student.import.go/QueueMail/%s/%v`, student.Email, err)
+		}
+
+		writer.Write([]string{student.StudentCode, student.Name, student.Email, passwords[i]})
+		download++
+	}
+
+	if download == 0 {
+		return response, nil
+	}
+
+	writer.Flush()
+	err = writer.Error()
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Import/WriteCSV", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	ttl := viper.GetInt("student.credential_ttl")
+	if ttl <= 0 {
+		ttl = STUDENT_CREDENTIAL_TTL
+	}
+
+	token, err := util.RandomToken()
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Import/RandomToken", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	err = helpers.SetDataToCacheWithExpiry(ctx, studentCredentialKey(token), buffer.String(), ttl)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Import/SetDataToCacheWithExpiry", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	response.CredentialToken = token
+	response.CredentialExpiry = ttl
+
+	return response, nil
+}
+
+// Credentials returns the initial passwords of an import. The file is removed on the first download.
+func (s StudentModule) Credentials(ctx context.Context, param StudentCredentialParam) (
+	*helpers.File, *helpers.Error) {
+
+	content, err := helpers.PopDataFromCache(ctx, studentCredentialKey(param.Token))
+	if err == redis.ErrNil {
+		return nil, helpers.ErrorWrap(errors.New("Credentials Expired Or Already Downloaded"), s.name,
+			"Credentials/ValidationToken",
+			helpers.BadRequestMessage,
+			http.StatusNotFound)
+	}
+
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Credentials/PopDataFromCache", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	return &helpers.File{
+		Name:        fmt.Sprintf("student-credentials-%s.csv", time.Now().Format("20060102150405")),
+		ContentType: "text/csv",
+		Content:     []byte(content),
+	}, nil
+}
+
+func studentCredentialKey(token string) string {
+	return fmt.Sprintf("student_credential:%s", token)
+}
+
+func studentCredentialMail(student models.StudentModel, password string) helpers.Mail {
+	return helpers.Mail{
+		To:      student.Email,
+		Subject: "Your Student Account",
+		Body: fmt.Sprintf("Dear %s,\r\n\r\nYour student account has been created.\r\n\r\n"+
+			"Student Code : %s\r\nPassword : %s\r\n\r\nPlease change your password after your first login.",
+			student.Name, student.StudentCode, password),
+	}
+}
+
+// findImportProgram matches the program column by id or by program code.
+func findImportProgram(programs []models.ProgramModel, value string) (models.ProgramModel, error) {
+
+	if value == "" {
+		return models.ProgramModel{}, errors.New("Program Is Required")
+	}
+
+	var matches []models.ProgramModel
+	for _, program := range programs {
+		if program.ID.String() == strings.ToLower(value) || strconv.Itoa(program.Code) == value {
+			matches = append(matches, program)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return models.ProgramModel{}, errors.New("Program Not Found")
+	case 1:
+		return matches[0], nil
+	default:
+		return models.ProgramModel{}, errors.New("Program Code Is Ambiguous, Use Program Id")
+	}
+}
+
+// parseImportDate accepts YYYY-MM-DD, DD/MM/YYYY or a spreadsheet date serial.
+func parseImportDate(value string) (time.Time, error) {
+
+	for _, layout := range []string{"2006-01-02", "02/01/2006"} {
+		date, err := time.Parse(layout, value)
+		if err == nil {
+			return date, nil
+		}
+	}
+
+	serial, err := strconv.ParseFloat(value, 64)
+	if err != nil || serial < 1 {
+		return time.Time{}, errors.New("Invalid Date")
+	}
+
+	return time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC).AddDate(0, 0, int(serial)), nil
+}
+
+func parseImportGender(value string) (int, error) {
+
+	switch strings.ToLower(value) {
+	case "0", "m", "male":
+		return 0, nil
+	case "1", "f", "female":
+		return 1, nil
+	}
+
+	return 0, errors.New("Invalid Gender")
+}
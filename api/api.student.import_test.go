@@ -0,0 +1,98 @@
+package api
+
+import (
+	uuid "github.com/satori/go.uuid"
+	"school/models"
+	"strings"
+	"testing"
+)
+
+func TestParseImportDate(t *testing.T) {
+
+	cases := map[string]string{
+		"2004-02-29": "2004-02-29",
+		"29/02/2004": "2004-02-29",
+		"45000":      "2023-03-15",
+		"45000.75":   "2023-03-15",
+	}
+
+	for value, expected := range cases {
+		date, err := parseImportDate(value)
+		if err != nil {
+			t.Fatalf("parseImportDate(%s) returned %v", value, err)
+		}
+
+		if got := date.Format("2006-01-02"); got != expected {
+			t.Fatalf("parseImportDate(%s) = %s, expected %s", value, got, expected)
+		}
+	}
+
+	for _, value := range []string{"", "2004-02-30", "02/29/2004", "0", "-3", "yesterday"} {
+		if _, err := parseImportDate(value); err == nil {
+			t.Fatalf("parseImportDate(%s) expected an error", value)
+		}
+	}
+
+}
+
+func TestParseImportGender(t *testing.T) {
+
+	cases := map[string]int{
+		"0":      0,
+		"M":      0,
+		"male":   0,
+		"1":      1,
+		"f":      1,
+		"Female": 1,
+	}
+
+	for value, expected := range cases {
+		gender, err := parseImportGender(value)
+		if err != nil || gender != expected {
+			t.Fatalf("parseImportGender(%s) = %d %v, expected %d", value, gender, err, expected)
+		}
+	}
+
+	for _, value := range []string{"", "2", "x", "other"} {
+		if _, err := parseImportGender(value); err == nil {
+			t.Fatalf("parseImportGender(%s) expected an error", value)
+		}
+	}
+
+}
+
+func TestFindImportProgram(t *testing.T) {
+
+	computing := models.ProgramModel{ID: uuid.NewV4(), Code: 12}
+	business := models.ProgramModel{ID: uuid.NewV4(), Code: 7}
+	accounting := models.ProgramModel{ID: uuid.NewV4(), Code: 7}
+	programs := []models.ProgramModel{computing, business, accounting}
+
+	cases := map[string]uuid.UUID{
+		"12":                                    computing.ID,
+		business.ID.String():                    business.ID,
+		strings.ToUpper(accounting.ID.String()): accounting.ID,
+	}
+
+	for value, expected := range cases {
+		program, err := findImportProgram(programs, value)
+		if err != nil || program.ID != expected {
+			t.Fatalf("findImportProgram(%s) = %s %v, expected %s", value, program.ID, err, expected)
+		}
+	}
+
+	errorCases := map[string]string{
+		"":                    "Program Is Required",
+		"99":                  "Program Not Found",
+		uuid.NewV4().String(): "Program Not Found",
+		"7":                   "Program Code Is Ambiguous, Use Program Id",
+	}
+
+	for value, expected := range errorCases {
+		_, err := findImportProgram(programs, value)
+		if err == nil || err.Error() != expected {
+			t.Fatalf("findImportProgram(%s) = %v, expected %s", value, err, expected)
+		}
+	}
+
+}
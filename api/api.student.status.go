@@ -0,0 +1,230 @@
+package api
+
+import (
+	"context"
+	"errors"
+	uuid "github.com/satori/go.uuid"
+	"net/http"
+	"school/helpers"
+	"school/models"
+	"school/session"
+	"time"
+)
+
+type (
+	StudentStatusParam struct {
+		ID          uuid.UUID `json:"id"`
+		Status      string    `json:"status" valid:"required"`
+		Reason      string    `json:"reason" valid:"required"`
+		EffectiveAt time.Time `json:"effective_at"`
+	}
+
+	StudentStatusHistoryParam struct {
+		ID uuid.UUID `json:"id"`
+	}
+
+	StudentStatusBackfillResponse struct {
+		Withdrawn []uuid.UUID `json:"withdrawn"`
+	}
+)
+
+// studentStatusTransitions lists the statuses a student may move to from each status. Graduated and
+// expelled students cannot be moved any further.
+var studentStatusTransitions = map[string][]string{
+	models.STUDENT_APPLICANT: {models.STUDENT_ENROLLED, models.STUDENT_WITHDRAWN},
+	models.STUDENT_ENROLLED: {models.STUDENT_ON_LEAVE, models.STUDENT_SUSPENDED, models.STUDENT_WITHDRAWN,
+		models.STUDENT_GRADUATED, models.STUDENT_EXPELLED},
+	models.STUDENT_ON_LEAVE:  {models.STUDENT_ENROLLED, models.STUDENT_WITHDRAWN},
+	models.STUDENT_SUSPENDED: {models.STUDENT_ENROLLED, models.STUDENT_WITHDRAWN, models.STUDENT_EXPELLED},
+	models.STUDENT_WITHDRAWN: {models.STUDENT_ENROLLED},
+}
+
+// UpdateStatus moves the student along the lifecycle and records the reason and effective date in the
+// student's history.
+func (s StudentModule) UpdateStatus(ctx context.Context, param StudentStatusParam) (interface{}, *helpers.Error) {
+
+	student, err := models.GetOneStudent(ctx, s.db, param.ID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "UpdateStatus/GetOneStudent", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	effectiveAt := param.EffectiveAt
+	if effectiveAt.IsZero() {
+		effectiveAt = time.Now()
+	}
+
+	if effectiveAt.After(time.Now()) {
+		return nil, helpers.ErrorWrap(errors.New("Effective Date Cannot Be In The Future"), s.name,
+			"UpdateStatus/ValidationEffectiveAt",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "UpdateStatus/BeginTx", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	defer tx.Rollback()
+
+	errStatus := transitionStudentStatus(ctx, tx, s.name, "UpdateStatus", student.ID, student.Status,
+		param.Status, param.Reason, effectiveAt)
+	if errStatus != nil {
+		return nil, errStatus
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "UpdateStatus/Commit", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	if !canStudentLogin(param.Status) {
+		err = session.DeleteUserSession(ctx, student.ID, session.STUDENT_ROLE)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "UpdateStatus/DeleteUserSession", helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+	}
+
+	return s.Detail(ctx, StudentDetailParam{ID: param.ID})
+}
+
+func (s StudentModule) StatusHistory(ctx context.Context, param StudentStatusHistoryParam) (
+	interface{}, *helpers.Error) {
+
+	histories, err := models.GetAllStudentStatusHistoryByStudent(ctx, s.db, param.ID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "StatusHistory/GetAllStudentStatusHistoryByStudent",
+			helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	var historiesResponse []models.StudentStatusHistoryResponse
+	for _, history := range histories {
+		historiesResponse = append(historiesResponse, history.Response())
+	}
+
+	return historiesResponse, nil
+}
+
+// BackfillStatus moves the students deleted before the lifecycle statuses existed to withdrawn and records
+// the move in their history. It has to run once on an existing database.
+func (s StudentModule) BackfillStatus(ctx context.Context) (interface{}, *helpers.Error) {
+
+	userID := uuid.FromStringOrNil(ctx.Value("user_id").(string))
+	now := time.Now()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "BackfillStatus/BeginTx", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	defer tx.Rollback()
+
+	studentIDs, err := models.WithdrawInactiveStudent(ctx, tx, uuid.NullUUID{UUID: userID, Valid: true})
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "BackfillStatus/WithdrawInactiveStudent",
+			helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	for _, studentID := range studentIDs {
+		history := models.StudentStatusHistoryModel{
+			StudentID:   studentID,
+			FromStatus:  models.STUDENT_ENROLLED,
+			ToStatus:    models.STUDENT_WITHDRAWN,
+			Reason:      "Student Record Deleted",
+			EffectiveAt: now,
+			CreatedBy:   userID,
+		}
+
+		err = history.Insert(ctx, tx)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "BackfillStatus/StudentStatusHistoryInsert",
+				helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "BackfillStatus/Commit", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	return StudentStatusBackfillResponse{Withdrawn: studentIDs}, nil
+}
+
+// transitionStudentStatus checks the move against studentStatusTransitions, then updates the student and
+// records the history entry with the caller's transaction.
+func transitionStudentStatus(ctx context.Context, db helpers.Queryer, name string, step string,
+	studentID uuid.UUID, from string, to string, reason string, effectiveAt time.Time) *helpers.Error {
+
+	if !isStudentStatusTransitionAllowed(from, to) {
+		return helpers.ErrorWrap(errors.New("Student Cannot Move From "+from+" To "+to), name,
+			step+"/ValidationStatus",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	userID := uuid.FromStringOrNil(ctx.Value("user_id").(string))
+
+	student := models.StudentModel{
+		ID:     studentID,
+		Status: to,
+		UpdatedBy: uuid.NullUUID{
+			UUID:  userID,
+			Valid: true,
+		},
+	}
+
+	err := student.StatusUpdate(ctx, db)
+	if err != nil {
+		return helpers.ErrorWrap(err, name, step+"/StatusUpdate", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	history := models.StudentStatusHistoryModel{
+		StudentID:   studentID,
+		FromStatus:  from,
+		ToStatus:    to,
+		Reason:      reason,
+		EffectiveAt: effectiveAt,
+		CreatedBy:   userID,
+	}
+
+	err = history.Insert(ctx, db)
+	if err != nil {
+		return helpers.ErrorWrap(err, name, step+"/StudentStatusHistoryInsert", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+func isStudentStatusTransitionAllowed(from string, to string) bool {
+
+	for _, status := range studentStatusTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// canStudentLogin reports whether a student in the status may sign in. Graduates keep access to their
+// results and transcript.
+func canStudentLogin(status string) bool {
+
+	switch status {
+	case models.STUDENT_APPLICANT, models.STUDENT_ENROLLED, models.STUDENT_ON_LEAVE, models.STUDENT_GRADUATED:
+		return true
+	}
+
+	return false
+}
@@ -23,16 +23,20 @@ type (
 	}
 
 	SubjectAddParam struct {
-		Name        string `json:"name" valid:"required"`
-		Description string `json:"description" valid:"required"`
-		Duration    int    `json:"duration" valid:"required"`
+		Name            string    `json:"name" valid:"required"`
+		Description     string    `json:"description" valid:"required"`
+		Duration        int       `json:"duration" valid:"required"`
+		CreditHours     int       `json:"credit_hours" valid:"required"`
+		GradingSchemeID uuid.UUID `json:"grading_scheme_id"`
 	}
 
 	SubjectUpdateParam struct {
-		ID          uuid.UUID `json:"id"`
-		Name        string    `json:"name" valid:"required"`
-		Description string    `json:"description" valid:"required"`
-		Duration    int       `json:"duration" valid:"required"`
+		ID              uuid.UUID `json:"id"`
+		Name            string    `json:"name" valid:"required"`
+		Description     string    `json:"description" valid:"required"`
+		Duration        int       `json:"duration" valid:"required"`
+		CreditHours     int       `json:"credit_hours" valid:"required"`
+		GradingSchemeID uuid.UUID `json:"grading_scheme_id"`
 	}
 
 	SubjectDeleteParam struct {
@@ -81,7 +85,12 @@ func (s SubjectModule) Add(ctx context.Context, param SubjectAddParam) (interfac
 		Name:        param.Name,
 		Description: param.Description,
 		Duration:    param.Duration,
-		CreatedBy:   uuid.FromStringOrNil(ctx.Value("user_id").(string)),
+		CreditHours: param.CreditHours,
+		GradingSchemeID: uuid.NullUUID{
+			UUID:  param.GradingSchemeID,
+			Valid: param.GradingSchemeID != uuid.Nil,
+		},
+		CreatedBy: uuid.FromStringOrNil(ctx.Value("user_id").(string)),
 	}
 
 	err := subject.Insert(ctx, s.db)
@@ -100,6 +109,11 @@ func (s SubjectModule) Update(ctx context.Context, param SubjectUpdateParam) (in
 		Name:        param.Name,
 		Description: param.Description,
 		Duration:    param.Duration,
+		CreditHours: param.CreditHours,
+		GradingSchemeID: uuid.NullUUID{
+			UUID:  param.GradingSchemeID,
+			Valid: param.GradingSchemeID != uuid.Nil,
+		},
 		UpdatedBy: uuid.NullUUID{
 			UUID:  uuid.FromStringOrNil(ctx.Value("user_id").(string)),
 			Valid: true,
@@ -0,0 +1,371 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/gomodule/redigo/redis"
+	uuid "github.com/satori/go.uuid"
+	"net/http"
+	"school/helpers"
+	"school/models"
+	"school/util"
+	"strings"
+)
+
+type (
+	SubjectPrerequisiteModule struct {
+		db     *sql.DB
+		cache  *redis.Pool
+		name   string
+		logger *helpers.Logger
+	}
+
+	SubjectPrerequisiteListParam struct {
+		SubjectID uuid.UUID `json:"subject_id"`
+	}
+
+	SubjectPrerequisiteAddParam struct {
+		SubjectID             uuid.UUID `json:"subject_id"`
+		PrerequisiteSubjectID uuid.UUID `json:"prerequisite_subject_id" valid:"required"`
+		MinGrade              string    `json:"min_grade" valid:"required"`
+	}
+
+	SubjectPrerequisiteUpdateParam struct {
+		ID        uuid.UUID `json:"id"`
+		SubjectID uuid.UUID `json:"subject_id"`
+		MinGrade  string    `json:"min_grade" valid:"required"`
+	}
+
+	SubjectPrerequisiteDeleteParam struct {
+		ID        uuid.UUID `json:"id"`
+		SubjectID uuid.UUID `json:"subject_id"`
+	}
+)
+
+func NewSubjectPrerequisiteModule(db *sql.DB, cache *redis.Pool, logger *helpers.Logger) *SubjectPrerequisiteModule {
+	return &SubjectPrerequisiteModule{
+		db:     db,
+		cache:  cache,
+		name:   "module/subjectPrerequisite",
+		logger: logger,
+	}
+}
+
+func (s SubjectPrerequisiteModule) ListBySubject(ctx context.Context, param SubjectPrerequisiteListParam) (
+	interface{}, *helpers.Error) {
+
+	prerequisites, err := models.GetAllSubjectPrerequisiteBySubject(ctx, s.db, helpers.Filter{
+		FilterOption: helpers.FilterOption{
+			Limit:  999,
+			Offset: 0,
+		},
+		SubjectID: param.SubjectID,
+	})
+
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "ListBySubject/GetAllSubjectPrerequisiteBySubject",
+			helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	var prerequisitesResponse []models.SubjectPrerequisiteResponse
+	for _, prerequisite := range prerequisites {
+		response, err := prerequisite.Response(ctx, s.db, s.logger)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, "ListBySubject/SubjectPrerequisiteResponse",
+				helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+		prerequisitesResponse = append(prerequisitesResponse, response)
+	}
+
+	return prerequisitesResponse, nil
+}
+
+func (s SubjectPrerequisiteModule) Add(ctx context.Context, param SubjectPrerequisiteAddParam) (
+	interface{}, *helpers.Error) {
+
+	minGrade := strings.ToUpper(param.MinGrade)
+
+	valid, err := isValidGrade(ctx, s.db, minGrade)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Add/isValidGrade", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	if !valid {
+		return nil, helpers.ErrorWrap(errors.New("Invalid Minimum Grade"), s.name, "Add/ValidationGrade",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	subject, err := models.GetOneSubject(ctx, s.db, param.SubjectID)
+	if err == sql.ErrNoRows || (err == nil && subject.IsDelete) {
+		return nil, helpers.ErrorWrap(errors.New("Subject Not Found"), s.name, "Add/ValidationSubject",
+			helpers.BadRequestMessage,
+			http.StatusNotFound)
+	}
+
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Add/GetOneSubject", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	prerequisiteSubject, err := models.GetOneSubject(ctx, s.db, param.PrerequisiteSubjectID)
+	if err == sql.ErrNoRows || (err == nil && prerequisiteSubject.IsDelete) {
+		return nil, helpers.ErrorWrap(errors.New("Prerequisite Subject Not Found"), s.name,
+			"Add/ValidationPrerequisiteSubject",
+			helpers.BadRequestMessage,
+			http.StatusNotFound)
+	}
+
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Add/GetOnePrerequisiteSubject", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	prerequisites, err := models.GetAllSubjectPrerequisiteBySubject(ctx, s.db, helpers.Filter{
+		FilterOption: helpers.FilterOption{
+			Limit:  9999,
+			Offset: 0,
+		},
+	})
+
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Add/GetAllSubjectPrerequisiteBySubject",
+			helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	graph := make(map[string][]string)
+	for _, prerequisite := range prerequisites {
+		if prerequisite.SubjectID == param.SubjectID &&
+			prerequisite.PrerequisiteSubjectID == param.PrerequisiteSubjectID {
+			return nil, helpers.ErrorWrap(errors.New("Prerequisite Already Exists"), s.name,
+				"Add/ValidationDuplicate",
+				helpers.BadRequestMessage,
+				http.StatusBadRequest)
+		}
+
+		subjectID := prerequisite.SubjectID.String()
+		graph[subjectID] = append(graph[subjectID], prerequisite.PrerequisiteSubjectID.String())
+	}
+
+	if util.HasPath(graph, param.PrerequisiteSubjectID.String(), param.SubjectID.String()) {
+		return nil, helpers.ErrorWrap(errors.New("Prerequisite Would Create A Cycle"), s.name,
+			"Add/ValidationCycle",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	prerequisite := models.SubjectPrerequisiteModel{
+		SubjectID:             param.SubjectID,
+		PrerequisiteSubjectID: param.PrerequisiteSubjectID,
+		MinGrade:              minGrade,
+		CreatedBy:             uuid.FromStringOrNil(ctx.Value("user_id").(string)),
+	}
+
+	err = prerequisite.Insert(ctx, s.db)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Add/Insert", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	response, err := prerequisite.Response(ctx, s.db, s.logger)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Add/SubjectPrerequisiteResponse", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	return response, nil
+}
+
+func (s SubjectPrerequisiteModule) Update(ctx context.Context, param SubjectPrerequisiteUpdateParam) (
+	interface{}, *helpers.Error) {
+
+	minGrade := strings.ToUpper(param.MinGrade)
+
+	valid, err := isValidGrade(ctx, s.db, minGrade)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Update/isValidGrade", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	if !valid {
+		return nil, helpers.ErrorWrap(errors.New("Invalid Minimum Grade"), s.name, "Update/ValidationGrade",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	current, err := models.GetOneSubjectPrerequisite(ctx, s.db, param.ID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Update/GetOneSubjectPrerequisite", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	if current.SubjectID != param.SubjectID {
+		return nil, helpers.ErrorWrap(errors.New("Prerequisite Does Not Belong To Subject"), s.name,
+			"Update/ValidationSubject",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	prerequisite := models.SubjectPrerequisiteModel{
+		ID:       param.ID,
+		MinGrade: minGrade,
+		UpdatedBy: uuid.NullUUID{
+			UUID:  uuid.FromStringOrNil(ctx.Value("user_id").(string)),
+			Valid: true,
+		},
+	}
+
+	err = prerequisite.Update(ctx, s.db)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Update/Update", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	response, err := prerequisite.Response(ctx, s.db, s.logger)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Update/SubjectPrerequisiteResponse", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	return response, nil
+}
+
+func (s SubjectPrerequisiteModule) Delete(ctx context.Context, param SubjectPrerequisiteDeleteParam) (
+	interface{}, *helpers.Error) {
+
+	current, err := models.GetOneSubjectPrerequisite(ctx, s.db, param.ID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Delete/GetOneSubjectPrerequisite", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	if current.SubjectID != param.SubjectID {
+		return nil, helpers.ErrorWrap(errors.New("Prerequisite Does Not Belong To Subject"), s.name,
+			"Delete/ValidationSubject",
+			helpers.BadRequestMessage,
+			http.StatusBadRequest)
+	}
+
+	prerequisite := models.SubjectPrerequisiteModel{
+		ID: param.ID,
+		UpdatedBy: uuid.NullUUID{
+			UUID:  uuid.FromStringOrNil(ctx.Value("user_id").(string)),
+			Valid: true,
+		},
+	}
+
+	err = prerequisite.Delete(ctx, s.db)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Delete/Delete", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	return nil, nil
+}
+
+func checkSubjectPrerequisite(ctx context.Context, db *sql.DB, logger *helpers.Logger, name string,
+	studentID uuid.UUID, subjectID uuid.UUID) (interface{}, *helpers.Error) {
+
+	prerequisites, err := models.GetAllSubjectPrerequisiteBySubject(ctx, db, helpers.Filter{
+		FilterOption: helpers.FilterOption{
+			Limit:  999,
+			Offset: 0,
+		},
+		SubjectID: subjectID,
+	})
+
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, name, "CheckSubjectPrerequisite/GetAllSubjectPrerequisiteBySubject",
+			helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	var unmetResponse []models.SubjectPrerequisiteResponse
+	for _, prerequisite := range prerequisites {
+		results, err := models.GetAllResultByStudentAndSubject(ctx, db, studentID, prerequisite.PrerequisiteSubjectID)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, name, "CheckSubjectPrerequisite/GetAllResultByStudentAndSubject",
+				helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+
+		passed := false
+		for _, result := range results {
+			passed, err = isGradeMet(ctx, db, result, prerequisite.MinGrade)
+			if err != nil {
+				return nil, helpers.ErrorWrap(err, name, "CheckSubjectPrerequisite/isGradeMet",
+					helpers.InternalServerError,
+					http.StatusInternalServerError)
+			}
+
+			if passed {
+				break
+			}
+		}
+
+		if passed {
+			continue
+		}
+
+		response, err := prerequisite.Response(ctx, db, logger)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, name, "CheckSubjectPrerequisite/SubjectPrerequisiteResponse",
+				helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+		unmetResponse = append(unmetResponse, response)
+	}
+
+	if len(unmetResponse) == 0 {
+		return nil, nil
+	}
+
+	return unmetResponse, helpers.ErrorWrap(errors.New("Prerequisites Not Met"), name,
+		"CheckSubjectPrerequisite/Validation",
+		helpers.PrerequisiteNotMetMessage,
+		http.StatusUnprocessableEntity)
+}
+
+func isValidGrade(ctx context.Context, db *sql.DB, grade string) (bool, error) {
+
+	if util.GetGradeRank(grade) > 0 {
+		return true, nil
+	}
+
+	count, err := models.GetCountGradingBandByGrade(ctx, db, grade)
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// isGradeMet compares the result against the minimum marks of the grade in the scheme that graded it,
+// falling back to the legacy grade ranks when the scheme has no such grade.
+func isGradeMet(ctx context.Context, db *sql.DB, result models.ResultModel, minGrade string) (bool, error) {
+
+	if result.GradingSchemeID.Valid {
+		bands, err := models.GetAllGradingBandByScheme(ctx, db, result.GradingSchemeID.UUID)
+		if err != nil {
+			return false, err
+		}
+
+		for _, band := range bands {
+			if band.Grade == minGrade {
+				return result.Marks >= band.MinMarks, nil
+			}
+		}
+	}
+
+	minRank := util.GetGradeRank(minGrade)
+	if minRank == 0 {
+		return false, nil
+	}
+
+	return util.GetGradeRank(util.GetGrade(result.Marks)) >= minRank, nil
+}
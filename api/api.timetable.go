@@ -0,0 +1,203 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	uuid "github.com/satori/go.uuid"
+	"github.com/spf13/viper"
+	"net/http"
+	"school/helpers"
+	"school/models"
+	"school/util"
+	"sort"
+)
+
+// TEACHING_MAX_WEEKLY_HOURS is the weekly contact hours of a lecturer in one intake above which a warning
+// is raised, when teaching.max_weekly_hours is not configured.
+const TEACHING_MAX_WEEKLY_HOURS = 18.0
+
+type (
+	TimetableParam struct {
+		IntakeID uuid.UUID `json:"intake_id"`
+	}
+
+	TimetableDayResponse struct {
+		Day      int                      `json:"day"`
+		Name     string                   `json:"name"`
+		Hours    float64                  `json:"hours"`
+		Sessions []models.SessionResponse `json:"sessions"`
+	}
+
+	TimetableResponse struct {
+		IntakeID uuid.UUID              `json:"intake_id"`
+		Hours    float64                `json:"hours"`
+		Days     []TimetableDayResponse `json:"days"`
+	}
+
+	TeachingLoadParam struct {
+		IntakeID uuid.UUID `json:"intake_id"`
+	}
+
+	TeachingLoadResponse struct {
+		LecturerID     uuid.UUID `json:"lecturer_id"`
+		LecturerName   string    `json:"lecturer_name"`
+		IntakeID       uuid.UUID `json:"intake_id"`
+		Sessions       int       `json:"sessions"`
+		WeeklyHours    float64   `json:"weekly_hours"`
+		MaxWeeklyHours float64   `json:"max_weekly_hours"`
+		IsOverloaded   bool      `json:"is_overloaded"`
+	}
+)
+
+// TimetableByLecturer arranges the logged in lecturer's sessions of the intake by day and start time.
+func (s SessionModule) TimetableByLecturer(ctx context.Context, param TimetableParam) (interface{}, *helpers.Error) {
+
+	return s.timetable(ctx, "TimetableByLecturer", helpers.Filter{
+		IntakeID:   param.IntakeID,
+		LecturerID: uuid.FromStringOrNil(ctx.Value("user_id").(string)),
+	})
+}
+
+// TimetableByStudent arranges the sessions of the intake the logged in student is enrolled in by day and
+// start time.
+func (s SessionModule) TimetableByStudent(ctx context.Context, param TimetableParam) (interface{}, *helpers.Error) {
+
+	return s.timetable(ctx, "TimetableByStudent", helpers.Filter{
+		IntakeID:  param.IntakeID,
+		StudentID: uuid.FromStringOrNil(ctx.Value("user_id").(string)),
+	})
+}
+
+// TeachingLoad sums the weekly contact hours of every lecturer per intake and flags the lecturers above
+// the configured maximum.
+func (s SessionModule) TeachingLoad(ctx context.Context, param TeachingLoadParam) (interface{}, *helpers.Error) {
+
+	sessions, err := models.GetAllSessionTimetable(ctx, s.db, helpers.Filter{IntakeID: param.IntakeID})
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "TeachingLoad/GetAllSessionTimetable",
+			helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	maxWeeklyHours := teachingMaxWeeklyHours()
+
+	loads := make(map[string]*TeachingLoadResponse)
+	var keys []string
+	for _, session := range sessions {
+		key := fmt.Sprintf("%s:%s", session.LecturerID, session.IntakeID)
+
+		load, ok := loads[key]
+		if !ok {
+			lecturer, err := models.GetOneLecturer(ctx, s.db, session.LecturerID)
+			if err != nil {
+				return nil, helpers.ErrorWrap(err, s.name, "TeachingLoad/GetOneLecturer",
+					helpers.InternalServerError,
+					http.StatusInternalServerError)
+			}
+
+			load = &TeachingLoadResponse{
+				LecturerID:     session.LecturerID,
+				LecturerName:   lecturer.Name,
+				IntakeID:       session.IntakeID,
+				MaxWeeklyHours: maxWeeklyHours,
+			}
+
+			loads[key] = load
+			keys = append(keys, key)
+		}
+
+		load.Sessions++
+		load.WeeklyHours += sessionHours(session)
+		load.IsOverloaded = load.WeeklyHours > maxWeeklyHours
+	}
+
+	var response []TeachingLoadResponse
+	for _, key := range keys {
+		response = append(response, *loads[key])
+	}
+
+	sort.SliceStable(response, func(i, j int) bool {
+		return response[i].WeeklyHours > response[j].WeeklyHours
+	})
+
+	return response, nil
+}
+
+func (s SessionModule) timetable(ctx context.Context, step string, filter helpers.Filter) (
+	interface{}, *helpers.Error) {
+
+	sessions, err := models.GetAllSessionTimetable(ctx, s.db, filter)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, step+"/GetAllSessionTimetable", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	response := TimetableResponse{
+		IntakeID: filter.IntakeID,
+	}
+
+	for _, session := range sessions {
+		sessionResponse, err := session.Response(ctx, s.db, s.logger)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, s.name, step+"/SessionResponse", helpers.InternalServerError,
+				http.StatusInternalServerError)
+		}
+
+		if len(response.Days) == 0 || response.Days[len(response.Days)-1].Day != session.Day {
+			response.Days = append(response.Days, TimetableDayResponse{
+				Day:  session.Day,
+				Name: util.GetDay(session.Day),
+			})
+		}
+
+		day := &response.Days[len(response.Days)-1]
+		day.Sessions = append(day.Sessions, sessionResponse)
+		day.Hours += sessionHours(session)
+		response.Hours += sessionHours(session)
+	}
+
+	return response, nil
+}
+
+// teachingLoadWarnings reports when the session takes its lecturer above the maximum weekly hours in the
+// session's intake. The session itself is not counted twice on update.
+func (s SessionModule) teachingLoadWarnings(ctx context.Context, session models.SessionModel) ([]string, error) {
+
+	sessions, err := models.GetAllSessionTimetable(ctx, s.db, helpers.Filter{
+		IntakeID:   session.IntakeID,
+		LecturerID: session.LecturerID,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	weeklyHours := sessionHours(session)
+	for _, current := range sessions {
+		if current.ID != session.ID {
+			weeklyHours += sessionHours(current)
+		}
+	}
+
+	maxWeeklyHours := teachingMaxWeeklyHours()
+	if weeklyHours <= maxWeeklyHours {
+		return nil, nil
+	}
+
+	return []string{fmt.Sprintf("Lecturer Teaching Load Of %.1f Hours Exceeds Maximum Of %.1f Hours",
+		weeklyHours, maxWeeklyHours)}, nil
+}
+
+func sessionHours(session models.SessionModel) float64 {
+	return session.EndTime.Sub(session.StartTime).Hours()
+}
+
+func teachingMaxWeeklyHours() float64 {
+
+	maxWeeklyHours := viper.GetFloat64("teaching.max_weekly_hours")
+	if maxWeeklyHours <= 0 {
+		maxWeeklyHours = TEACHING_MAX_WEEKLY_HOURS
+	}
+
+	return maxWeeklyHours
+}
@@ -0,0 +1,226 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"github.com/gomodule/redigo/redis"
+	uuid "github.com/satori/go.uuid"
+	"github.com/spf13/viper"
+	"math"
+	"net/http"
+	"school/helpers"
+	"school/models"
+	"school/util"
+	"time"
+)
+
+const (
+	// TRANSCRIPT_PROBATION_CGPA is the CGPA below which a student is on probation, when
+	// transcript.probation_cgpa is not configured.
+	TRANSCRIPT_PROBATION_CGPA = 2.0
+
+	// TRANSCRIPT_DISMISSAL_CGPA is the CGPA below which a student is dismissed, when transcript.dismissal_cgpa
+	// is not configured.
+	TRANSCRIPT_DISMISSAL_CGPA = 1.5
+)
+
+type (
+	TranscriptModule struct {
+		db     *sql.DB
+		cache  *redis.Pool
+		name   string
+		logger *helpers.Logger
+	}
+
+	TranscriptDetailParam struct {
+		StudentID uuid.UUID `json:"student_id"`
+	}
+
+	TranscriptSubjectResponse struct {
+		ResultID    uuid.UUID `json:"result_id"`
+		SubjectID   uuid.UUID `json:"subject_id"`
+		SubjectName string    `json:"subject_name"`
+		CreditHours int       `json:"credit_hours"`
+		Marks       int       `json:"marks"`
+		Grade       string    `json:"grade"`
+		GradePoint  float64   `json:"grade_point"`
+		IsPassFail  bool      `json:"is_pass_fail"`
+	}
+
+	TranscriptTermResponse struct {
+		IntakeID         uuid.UUID                   `json:"intake_id"`
+		Year             string                      `json:"year"`
+		Trimester        int                         `json:"trimester"`
+		StartDate        time.Time                   `json:"start_date"`
+		Subjects         []TranscriptSubjectResponse `json:"subjects"`
+		CreditHours      int                         `json:"credit_hours"`
+		GPA              float64                     `json:"gpa"`
+		CumulativeCredit int                         `json:"cumulative_credit"`
+		CGPA             float64                     `json:"cgpa"`
+		Standing         string                      `json:"standing"`
+	}
+
+	TranscriptResponse struct {
+		Student     models.StudentResponse   `json:"student"`
+		Terms       []TranscriptTermResponse `json:"terms"`
+		CreditHours int                      `json:"credit_hours"`
+		CGPA        float64                  `json:"cgpa"`
+		Standing    string                   `json:"standing"`
+	}
+)
+
+func NewTranscriptModule(db *sql.DB, cache *redis.Pool, logger *helpers.Logger) *TranscriptModule {
+	return &TranscriptModule{
+		db:     db,
+		cache:  cache,
+		name:   "module/transcript",
+		logger: logger,
+	}
+}
+
+func (s TranscriptModule) Detail(ctx context.Context, param TranscriptDetailParam) (interface{}, *helpers.Error) {
+
+	student, err := models.GetOneStudent(ctx, s.db, param.StudentID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Detail/GetOneStudent", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	studentResponse, err := student.Response(ctx, s.db, s.logger)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Detail/StudentResponse", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	transcripts, err := models.GetAllTranscriptByStudent(ctx, s.db, param.StudentID)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, s.name, "Detail/GetAllTranscriptByStudent", helpers.InternalServerError,
+			http.StatusInternalServerError)
+	}
+
+	response := buildTranscript(transcripts)
+	response.Student = studentResponse
+
+	return response, nil
+}
+
+func (s TranscriptModule) DetailByOneStudent(ctx context.Context) (interface{}, *helpers.Error) {
+
+	studentID := uuid.FromStringOrNil(ctx.Value("user_id").(string))
+
+	return s.Detail(ctx, TranscriptDetailParam{StudentID: studentID})
+}
+
+// transcriptAttempt is what the latest attempt of a subject adds to the cumulative totals, so that a retake
+// can take it back out.
+type transcriptAttempt struct {
+	credit    int
+	points    float64
+	gpaCredit int
+}
+
+// buildTranscript groups the results by intake and works out the GPA of every term and the running CGPA.
+// Only passed subjects earn credit. When a subject is taken again, the latest attempt replaces the earlier one
+// in the cumulative credit and CGPA, while the earlier term keeps its own GPA.
+func buildTranscript(transcripts []models.TranscriptModel) TranscriptResponse {
+
+	probationCGPA := viper.GetFloat64("transcript.probation_cgpa")
+	if probationCGPA <= 0 {
+		probationCGPA = TRANSCRIPT_PROBATION_CGPA
+	}
+
+	dismissalCGPA := viper.GetFloat64("transcript.dismissal_cgpa")
+	if dismissalCGPA <= 0 {
+		dismissalCGPA = TRANSCRIPT_DISMISSAL_CGPA
+	}
+
+	var terms []TranscriptTermResponse
+	var termPoints, cumulativePoints float64
+	var termGPACredit, cumulativeCredit, cumulativeGPACredit int
+	attempts := make(map[uuid.UUID]transcriptAttempt)
+
+	for _, transcript := range transcripts {
+		if len(terms) == 0 || terms[len(terms)-1].IntakeID != transcript.IntakeID {
+			terms = append(terms, TranscriptTermResponse{
+				IntakeID:  transcript.IntakeID,
+				Year:      transcript.IntakeYear,
+				Trimester: transcript.IntakeTrimester,
+				StartDate: transcript.IntakeStartDate,
+			})
+			termPoints = 0
+			termGPACredit = 0
+		}
+
+		term := &terms[len(terms)-1]
+
+		gradePoint := transcript.GradePoint
+		if !transcript.GradingSchemeID.Valid {
+			gradePoint = util.GetGradePoint(transcript.Grade)
+		}
+
+		term.Subjects = append(term.Subjects, TranscriptSubjectResponse{
+			ResultID:    transcript.ResultID,
+			SubjectID:   transcript.SubjectID,
+			SubjectName: transcript.SubjectName,
+			CreditHours: transcript.CreditHours,
+			Marks:       transcript.Marks,
+			Grade:       transcript.Grade,
+			GradePoint:  gradePoint,
+			IsPassFail:  transcript.IsPassFail,
+		})
+
+		// withdrawn subjects stay on the transcript as W without earning credit, counting in the GPA or
+		// replacing an earlier attempt
+		if transcript.Grade != models.RESULT_GRADE_WITHDRAWN {
+			var attempt transcriptAttempt
+			if isTranscriptPassed(transcript) {
+				attempt.credit = transcript.CreditHours
+			}
+
+			// pass/fail subjects earn credit but are left out of the GPA
+			if !transcript.IsPassFail {
+				attempt.points = gradePoint * float64(transcript.CreditHours)
+				attempt.gpaCredit = transcript.CreditHours
+			}
+
+			previous := attempts[transcript.SubjectID]
+			attempts[transcript.SubjectID] = attempt
+
+			term.CreditHours += attempt.credit
+			termPoints += attempt.points
+			termGPACredit += attempt.gpaCredit
+
+			cumulativeCredit += attempt.credit - previous.credit
+			cumulativePoints += attempt.points - previous.points
+			cumulativeGPACredit += attempt.gpaCredit - previous.gpaCredit
+		}
+
+		term.GPA = averagePoint(termPoints, termGPACredit)
+		term.CumulativeCredit = cumulativeCredit
+		term.CGPA = averagePoint(cumulativePoints, cumulativeGPACredit)
+		if cumulativeGPACredit > 0 {
+			term.Standing = util.GetAcademicStanding(term.CGPA, probationCGPA, dismissalCGPA)
+		}
+	}
+
+	response := TranscriptResponse{
+		Terms:       terms,
+		CreditHours: cumulativeCredit,
+		CGPA:        averagePoint(cumulativePoints, cumulativeGPACredit),
+	}
+
+	if cumulativeGPACredit > 0 {
+		response.Standing = util.GetAcademicStanding(response.CGPA, probationCGPA, dismissalCGPA)
+	}
+
+	return response
+}
+
+func averagePoint(points float64, creditHours int) float64 {
+
+	if creditHours == 0 {
+		return 0
+	}
+
+	return math.Round(points/float64(creditHours)*100) / 100
+}
@@ -0,0 +1,144 @@
+package api
+
+import (
+	uuid "github.com/satori/go.uuid"
+	"school/models"
+	"testing"
+)
+
+func TestBuildTranscript(t *testing.T) {
+
+	firstIntake := uuid.NewV4()
+	secondIntake := uuid.NewV4()
+	thirdIntake := uuid.NewV4()
+
+	math := uuid.NewV4()
+	art := uuid.NewV4()
+	ethics := uuid.NewV4()
+	history := uuid.NewV4()
+	physics := uuid.NewV4()
+
+	result := func(intakeID uuid.UUID, subjectID uuid.UUID, creditHours int, grade string) models.TranscriptModel {
+		return models.TranscriptModel{
+			IntakeID:    intakeID,
+			SubjectID:   subjectID,
+			CreditHours: creditHours,
+			Grade:       grade,
+		}
+	}
+
+	passFail := models.TranscriptModel{
+		IntakeID:        firstIntake,
+		SubjectID:       ethics,
+		CreditHours:     2,
+		Marks:           60,
+		Grade:           "P",
+		GradingSchemeID: uuid.NullUUID{UUID: uuid.NewV4(), Valid: true},
+		IsPassFail:      true,
+		PassMark:        50,
+	}
+
+	type term struct {
+		creditHours      int
+		gpa              float64
+		cumulativeCredit int
+		cgpa             float64
+		standing         string
+	}
+
+	cases := []struct {
+		name        string
+		transcripts []models.TranscriptModel
+		terms       []term
+		creditHours int
+		cgpa        float64
+		standing    string
+	}{
+		{
+			name: "failed, pass/fail and withdrawn subjects",
+			transcripts: []models.TranscriptModel{
+				result(firstIntake, math, 3, "F"),
+				result(firstIntake, art, 3, "A"),
+				passFail,
+				result(firstIntake, history, 3, models.RESULT_GRADE_WITHDRAWN),
+			},
+			terms:       []term{{5, 2.0, 5, 2.0, "Good Standing"}},
+			creditHours: 5,
+			cgpa:        2.0,
+			standing:    "Good Standing",
+		},
+		{
+			name: "retake replaces the earlier attempt",
+			transcripts: []models.TranscriptModel{
+				result(firstIntake, math, 3, "F"),
+				result(firstIntake, art, 3, "A"),
+				result(secondIntake, math, 3, "B"),
+				result(secondIntake, physics, 4, "D"),
+				result(thirdIntake, art, 3, models.RESULT_GRADE_WITHDRAWN),
+			},
+			terms: []term{
+				{3, 2.0, 3, 2.0, "Good Standing"},
+				{7, 1.86, 10, 2.5, "Good Standing"},
+				{0, 0, 10, 2.5, "Good Standing"},
+			},
+			creditHours: 10,
+			cgpa:        2.5,
+			standing:    "Good Standing",
+		},
+		{
+			name: "probation",
+			transcripts: []models.TranscriptModel{
+				result(firstIntake, math, 3, "D"),
+				result(firstIntake, art, 3, "C"),
+			},
+			terms:       []term{{6, 1.5, 6, 1.5, "Probation"}},
+			creditHours: 6,
+			cgpa:        1.5,
+			standing:    "Probation",
+		},
+		{
+			name: "dismissal",
+			transcripts: []models.TranscriptModel{
+				result(firstIntake, math, 3, "F"),
+				result(firstIntake, art, 3, "D"),
+			},
+			terms:       []term{{3, 0.5, 3, 0.5, "Dismissal"}},
+			creditHours: 3,
+			cgpa:        0.5,
+			standing:    "Dismissal",
+		},
+		{
+			name: "withdrawn only",
+			transcripts: []models.TranscriptModel{
+				result(firstIntake, history, 3, models.RESULT_GRADE_WITHDRAWN),
+			},
+			terms: []term{{0, 0, 0, 0, ""}},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			response := buildTranscript(c.transcripts)
+
+			if len(response.Terms) != len(c.terms) {
+				t.Fatalf("buildTranscript() has %d terms, expected %d", len(response.Terms), len(c.terms))
+			}
+
+			for i, expected := range c.terms {
+				got := response.Terms[i]
+				if got.CreditHours != expected.creditHours || got.GPA != expected.gpa ||
+					got.CumulativeCredit != expected.cumulativeCredit || got.CGPA != expected.cgpa ||
+					got.Standing != expected.standing {
+					t.Fatalf("buildTranscript() term %d = %d %v %d %v %s, expected %v", i, got.CreditHours,
+						got.GPA, got.CumulativeCredit, got.CGPA, got.Standing, expected)
+				}
+			}
+
+			if response.CreditHours != c.creditHours || response.CGPA != c.cgpa || response.Standing != c.standing {
+				t.Fatalf("buildTranscript() = %d %v %s, expected %d %v %s", response.CreditHours, response.CGPA,
+					response.Standing, c.creditHours, c.cgpa, c.standing)
+			}
+		})
+	}
+
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	uuid "github.com/satori/go.uuid"
+	"github.com/spf13/cobra"
+	"os"
+	"school/api"
+	"school/helpers"
+)
+
+var backfillCreatedBy string
+
+// backfillStudentStatusCmd moves the students deleted before the lifecycle statuses existed to withdrawn. It
+// has to run once on an existing database, those students otherwise keep the default enrolled status.
+var backfillStudentStatusCmd = &cobra.Command{
+	Use:   "backfill-student-status",
+	Short: "Move every inactive student still marked as enrolled to withdrawn",
+	PreRun: func(cmd *cobra.Command, args []string) {
+		initDB()
+		api.Init(dbPool, cachePool, logger)
+		helpers.Init(logger, cachePool)
+	},
+
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.WithValue(context.Background(), "user_id", backfillCreatedBy)
+
+		studentService := api.NewStudentModule(dbPool, cachePool, logger)
+		response, errBackfill := studentService.BackfillStatus(ctx)
+
+		if errBackfill != nil {
+			fmt.Println(fmt.Sprintf(`Error Backfill Student Status : %v`, errBackfill.Err))
+			os.Exit(1)
+		}
+
+		output, _ := json.MarshalIndent(response, "", "  ")
+		fmt.Println(string(output))
+	},
+}
+
+func init() {
+	backfillStudentStatusCmd.Flags().StringVar(&backfillCreatedBy, "created-by", uuid.Nil.String(),
+		"admin id recorded as updater")
+
+	rootCmd.AddCommand(backfillStudentStatusCmd)
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	uuid "github.com/satori/go.uuid"
+	"github.com/spf13/cobra"
+	"os"
+	"school/api"
+	"school/helpers"
+)
+
+var (
+	generateIntakeID  string
+	generateCreatedBy string
+	generateDryRun    bool
+)
+
+// generateClassesCmd creates every class occurrence of an intake, the same as
+// POST /intakes/{id}/classes/generate.
+var generateClassesCmd = &cobra.Command{
+	Use:   "generate-classes",
+	Short: "Generate the class occurrences and attendance rows of an intake",
+	PreRun: func(cmd *cobra.Command, args []string) {
+		initDB()
+		api.Init(dbPool, cachePool, logger)
+		helpers.Init(logger, cachePool)
+	},
+
+	Run: func(cmd *cobra.Command, args []string) {
+		intakeID, err := uuid.FromString(generateIntakeID)
+		if err != nil {
+			fmt.Println(fmt.Sprintf(`Invalid Intake : %v`, err))
+			os.Exit(1)
+		}
+
+		ctx := context.WithValue(context.Background(), "user_id", generateCreatedBy)
+
+		classService := api.NewClassModule(dbPool, cachePool, logger)
+		response, errGenerate := classService.Generate(ctx, api.ClassGenerateParam{
+			IntakeID: intakeID,
+			DryRun:   generateDryRun,
+		})
+
+		if errGenerate != nil {
+			fmt.Println(fmt.Sprintf(`Error Generate Classes : %v`, errGenerate.Err))
+			os.Exit(1)
+		}
+
+		output, _ := json.MarshalIndent(response, "", "  ")
+		fmt.Println(string(output))
+	},
+}
+
+func init() {
+	generateClassesCmd.Flags().StringVar(&generateIntakeID, "intake", "", "intake id to generate classes for")
+	generateClassesCmd.Flags().StringVar(&generateCreatedBy, "created-by", uuid.Nil.String(),
+		"admin id recorded as creator")
+	generateClassesCmd.Flags().BoolVar(&generateDryRun, "dry-run", false, "report the classes without creating them")
+	generateClassesCmd.MarkFlagRequired("intake")
+
+	rootCmd.AddCommand(generateClassesCmd)
+}
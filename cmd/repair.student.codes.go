@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	uuid "github.com/satori/go.uuid"
+	"github.com/spf13/cobra"
+	"os"
+	"school/api"
+	"school/helpers"
+)
+
+var (
+	repairCreatedBy string
+	repairDryRun    bool
+)
+
+// repairStudentCodesCmd gives a new code to students sharing one. It has to run before the unique index on
+// student.student_code can be created on an existing database.
+var repairStudentCodesCmd = &cobra.Command{
+	Use:   "repair-student-codes",
+	Short: "Give a new code to every student sharing a student code with an older student",
+	PreRun: func(cmd *cobra.Command, args []string) {
+		initDB()
+		api.Init(dbPool, cachePool, logger)
+		helpers.Init(logger, cachePool)
+	},
+
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.WithValue(context.Background(), "user_id", repairCreatedBy)
+
+		studentService := api.NewStudentModule(dbPool, cachePool, logger)
+		response, errRepair := studentService.RepairCodes(ctx, api.StudentCodeRepairParam{
+			DryRun: repairDryRun,
+		})
+
+		if errRepair != nil {
+			fmt.Println(fmt.Sprintf(`Error Repair Student Codes : %v`, errRepair.Err))
+			os.Exit(1)
+		}
+
+		output, _ := json.MarshalIndent(response, "", "  ")
+		fmt.Println(string(output))
+	},
+}
+
+func init() {
+	repairStudentCodesCmd.Flags().StringVar(&repairCreatedBy, "created-by", uuid.Nil.String(),
+		"admin id recorded as updater")
+	repairStudentCodesCmd.Flags().BoolVar(&repairDryRun, "dry-run", false, "report the new codes without saving them")
+
+	rootCmd.AddCommand(repairStudentCodesCmd)
+}
@@ -37,6 +37,7 @@ var rootCmd = &cobra.Command{
 		initLogger()
 		api.Init(dbPool, cachePool, logger)
 		helpers.Init(logger, cachePool)
+		initMailer()
 		routers.Init(dbPool, cachePool, logger)
 		middleware.Init(dbPool, cachePool, logger)
 
@@ -167,6 +168,23 @@ func initCache() {
 	cachePool = helpers.ConnectToCache(cacheOptions)
 }
 
+// initMailer sets up the mailer named by mailer.driver. Only the file mailer is available, writing mails to
+// mailer.dir.
+func initMailer() {
+	switch viper.GetString("mailer.driver") {
+	case "", "file":
+		dir := viper.GetString("mailer.dir")
+		if dir == "" {
+			dir = "mail"
+		}
+
+		helpers.InitMailer(helpers.FileMailer{Dir: dir}, 1000)
+	default:
+		logger.Err.Println(fmt.Sprintf("err mailer : unknown driver %s", viper.GetString("mailer.driver")))
+		os.Exit(0)
+	}
+}
+
 func initLogger() {
 	logger = helpers.NewLogger()
 	logger.Out.Formatter = new(logrus.JSONFormatter)
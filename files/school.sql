@@ -26,6 +26,38 @@ CREATE TABLE faculty (
 	FAMILY "primary" (id, code, abbreviation, name, description, is_delete, created_by, created_at, updated_by, updated_at)
 );
 
+CREATE TABLE grading_scheme (
+	id UUID NOT NULL DEFAULT gen_random_uuid(),
+	name STRING NOT NULL,
+	description STRING NOT NULL DEFAULT '':::STRING,
+	pass_mark INT8 NOT NULL DEFAULT 0:::INT8,
+	is_pass_fail BOOL NOT NULL DEFAULT false,
+	is_default BOOL NOT NULL DEFAULT false,
+	is_delete BOOL NOT NULL DEFAULT false,
+	created_by UUID NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now():::TIMESTAMPTZ,
+	updated_by UUID NULL,
+	updated_at TIMESTAMPTZ NULL,
+	CONSTRAINT "primary" PRIMARY KEY (id ASC),
+	FAMILY "primary" (id, name, description, pass_mark, is_pass_fail, is_default, is_delete, created_by, created_at, updated_by, updated_at)
+);
+
+CREATE TABLE grading_band (
+	id UUID NOT NULL DEFAULT gen_random_uuid(),
+	grading_scheme_id UUID NOT NULL,
+	grade STRING NOT NULL,
+	min_marks INT8 NOT NULL,
+	grade_point FLOAT8 NOT NULL DEFAULT 0.0:::FLOAT8,
+	is_delete BOOL NOT NULL DEFAULT false,
+	created_by UUID NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now():::TIMESTAMPTZ,
+	updated_by UUID NULL,
+	updated_at TIMESTAMPTZ NULL,
+	CONSTRAINT "primary" PRIMARY KEY (id ASC),
+	INDEX grading_band_grading_scheme_id_idx (grading_scheme_id ASC, min_marks DESC),
+	FAMILY "primary" (id, grading_scheme_id, grade, min_marks, grade_point, is_delete, created_by, created_at, updated_by, updated_at)
+);
+
 CREATE TABLE program (
 	id UUID NOT NULL DEFAULT gen_random_uuid(),
 	faculty_id UUID NOT NULL,
@@ -37,9 +69,11 @@ CREATE TABLE program (
 	created_at TIMESTAMPTZ NOT NULL DEFAULT now():::TIMESTAMPTZ,
 	updated_by UUID NULL,
 	updated_at TIMESTAMPTZ NULL,
+	grading_scheme_id UUID NULL,
+	elective_credit INT8 NOT NULL DEFAULT 0:::INT8,
 	CONSTRAINT "primary" PRIMARY KEY (id ASC),
 	INDEX program_faculty_id_idx (faculty_id ASC),
-	FAMILY "primary" (id, faculty_id, name, code, description, is_delete, created_by, created_at, updated_by, updated_at)
+	FAMILY "primary" (id, faculty_id, name, code, description, is_delete, created_by, created_at, updated_by, updated_at, grading_scheme_id, elective_credit)
 );
 
 CREATE TABLE student (
@@ -58,10 +92,14 @@ CREATE TABLE student (
 	password STRING NOT NULL DEFAULT '':::STRING,
 	student_code STRING NOT NULL DEFAULT '':::STRING,
 	program_id UUID NOT NULL,
+	status STRING NOT NULL DEFAULT 'enrolled':::STRING,
 	CONSTRAINT "primary" PRIMARY KEY (id ASC),
 	INDEX student_program_id_idx (program_id ASC),
 	INDEX student_auto_index_student_fk (program_id ASC),
-	FAMILY "primary" (id, name, address, date_of_birth, gender, email, phone_no, is_active, created_by, created_at, updated_by, updated_at, password, student_code, program_id)
+	INDEX student_status_idx (status ASC),
+	UNIQUE INDEX student_student_code_key (student_code ASC),
+	UNIQUE INDEX student_email_key (lower(email) ASC),
+	FAMILY "primary" (id, name, address, date_of_birth, gender, email, phone_no, is_active, created_by, created_at, updated_by, updated_at, password, student_code, program_id, status)
 );
 
 CREATE TABLE subject (
@@ -74,8 +112,136 @@ CREATE TABLE subject (
 	created_at TIMESTAMPTZ NOT NULL DEFAULT now():::TIMESTAMPTZ,
 	updated_by UUID NULL,
 	updated_at TIMESTAMPTZ NULL,
+	credit_hours INT8 NOT NULL DEFAULT 0:::INT8,
+	grading_scheme_id UUID NULL,
+	CONSTRAINT "primary" PRIMARY KEY (id ASC),
+	FAMILY "primary" (id, name, description, duration, is_delete, created_by, created_at, updated_by, updated_at, credit_hours, grading_scheme_id)
+);
+
+CREATE TABLE subject_prerequisite (
+	id UUID NOT NULL DEFAULT gen_random_uuid(),
+	subject_id UUID NOT NULL,
+	prerequisite_subject_id UUID NOT NULL,
+	min_grade STRING NOT NULL,
+	is_delete BOOL NOT NULL DEFAULT false,
+	created_by UUID NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now():::TIMESTAMPTZ,
+	updated_by UUID NULL,
+	updated_at TIMESTAMPTZ NULL,
+	CONSTRAINT "primary" PRIMARY KEY (id ASC),
+	INDEX subject_prerequisite_subject_id_idx (subject_id ASC),
+	INDEX subject_prerequisite_prerequisite_subject_id_idx (prerequisite_subject_id ASC),
+	FAMILY "primary" (id, subject_id, prerequisite_subject_id, min_grade, is_delete, created_by, created_at, updated_by, updated_at)
+);
+
+CREATE TABLE curriculum (
+	id UUID NOT NULL DEFAULT gen_random_uuid(),
+	program_id UUID NOT NULL,
+	subject_id UUID NOT NULL,
+	year INT8 NOT NULL,
+	trimester INT8 NOT NULL,
+	is_elective BOOL NOT NULL DEFAULT false,
+	credit_hours INT8 NOT NULL DEFAULT 0:::INT8,
+	is_delete BOOL NOT NULL DEFAULT false,
+	created_by UUID NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now():::TIMESTAMPTZ,
+	updated_by UUID NULL,
+	updated_at TIMESTAMPTZ NULL,
+	CONSTRAINT "primary" PRIMARY KEY (id ASC),
+	INDEX curriculum_program_id_idx (program_id ASC, year ASC, trimester ASC),
+	INDEX curriculum_subject_id_idx (subject_id ASC),
+	FAMILY "primary" (id, program_id, subject_id, year, trimester, is_elective, credit_hours, is_delete, created_by, created_at, updated_by, updated_at)
+);
+
+CREATE TABLE graduation (
+	id UUID NOT NULL DEFAULT gen_random_uuid(),
+	student_id UUID NOT NULL,
+	intake_id UUID NOT NULL,
+	cgpa FLOAT8 NOT NULL DEFAULT 0.0:::FLOAT8,
+	credit_hours INT8 NOT NULL DEFAULT 0:::INT8,
+	classification STRING NOT NULL DEFAULT '':::STRING,
+	graduated_at TIMESTAMPTZ NOT NULL,
+	is_delete BOOL NOT NULL DEFAULT false,
+	created_by UUID NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now():::TIMESTAMPTZ,
+	updated_by UUID NULL,
+	updated_at TIMESTAMPTZ NULL,
+	CONSTRAINT "primary" PRIMARY KEY (id ASC),
+	UNIQUE INDEX graduation_student_id_key (student_id ASC) WHERE is_delete = false,
+	INDEX graduation_intake_id_idx (intake_id ASC),
+	FAMILY "primary" (id, student_id, intake_id, cgpa, credit_hours, classification, graduated_at, is_delete, created_by, created_at, updated_by, updated_at)
+);
+
+CREATE TABLE student_status_history (
+	id UUID NOT NULL DEFAULT gen_random_uuid(),
+	student_id UUID NOT NULL,
+	from_status STRING NOT NULL,
+	to_status STRING NOT NULL,
+	reason STRING NOT NULL DEFAULT '':::STRING,
+	effective_at TIMESTAMPTZ NOT NULL,
+	created_by UUID NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now():::TIMESTAMPTZ,
+	CONSTRAINT "primary" PRIMARY KEY (id ASC),
+	INDEX student_status_history_student_id_idx (student_id ASC),
+	FAMILY "primary" (id, student_id, from_status, to_status, reason, effective_at, created_by, created_at)
+);
+
+CREATE TABLE student_code_sequence (
+	program_id UUID NOT NULL,
+	year INT8 NOT NULL,
+	last_value INT8 NOT NULL DEFAULT 0:::INT8,
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT now():::TIMESTAMPTZ,
+	CONSTRAINT "primary" PRIMARY KEY (program_id ASC, year ASC),
+	FAMILY "primary" (program_id, year, last_value, updated_at)
+);
+
+CREATE TABLE calendar_token (
+	id UUID NOT NULL DEFAULT gen_random_uuid(),
+	user_id UUID NOT NULL,
+	role STRING NOT NULL,
+	token STRING NOT NULL,
+	is_delete BOOL NOT NULL DEFAULT false,
+	created_by UUID NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now():::TIMESTAMPTZ,
+	updated_by UUID NULL,
+	updated_at TIMESTAMPTZ NULL,
+	CONSTRAINT "primary" PRIMARY KEY (id ASC),
+	UNIQUE INDEX calendar_token_token_key (token ASC),
+	INDEX calendar_token_user_id_idx (user_id ASC, role ASC),
+	FAMILY "primary" (id, user_id, role, token, is_delete, created_by, created_at, updated_by, updated_at)
+);
+
+CREATE TABLE classroom_booking (
+	id UUID NOT NULL DEFAULT gen_random_uuid(),
+	classroom_id UUID NOT NULL,
+	title STRING NOT NULL,
+	date DATE NOT NULL,
+	start_time TIME NOT NULL,
+	end_time TIME NOT NULL,
+	is_delete BOOL NOT NULL DEFAULT false,
+	created_by UUID NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now():::TIMESTAMPTZ,
+	updated_by UUID NULL,
+	updated_at TIMESTAMPTZ NULL,
 	CONSTRAINT "primary" PRIMARY KEY (id ASC),
-	FAMILY "primary" (id, name, description, duration, is_delete, created_by, created_at, updated_by, updated_at)
+	INDEX classroom_booking_classroom_id_idx (classroom_id ASC, date ASC),
+	FAMILY "primary" (id, classroom_id, title, date, start_time, end_time, is_delete, created_by, created_at, updated_by, updated_at)
+);
+
+CREATE TABLE notification (
+	id UUID NOT NULL DEFAULT gen_random_uuid(),
+	user_id UUID NOT NULL,
+	role STRING NOT NULL,
+	type STRING NOT NULL,
+	title STRING NOT NULL,
+	body STRING NOT NULL DEFAULT '':::STRING,
+	reference_id UUID NULL,
+	is_read BOOL NOT NULL DEFAULT false,
+	read_at TIMESTAMPTZ NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now():::TIMESTAMPTZ,
+	CONSTRAINT "primary" PRIMARY KEY (id ASC),
+	INDEX notification_user_id_idx (user_id ASC, role ASC, created_at DESC),
+	FAMILY "primary" (id, user_id, role, type, title, body, reference_id, is_read, read_at, created_at)
 );
 
 CREATE TABLE lecturer (
@@ -105,10 +271,12 @@ CREATE TABLE classroom (
 	created_at TIMESTAMPTZ NOT NULL DEFAULT now():::TIMESTAMPTZ,
 	updated_by UUID NULL,
 	updated_at TIMESTAMPTZ NULL,
+	capacity INT8 NOT NULL DEFAULT 0:::INT8,
+	features STRING[] NOT NULL DEFAULT ARRAY[]:::STRING[],
 	CONSTRAINT "primary" PRIMARY KEY (id ASC),
 	INDEX classroom_faculty_id_idx (faculty_id ASC),
 	INDEX classroom_auto_index_classroom_fk (faculty_id ASC),
-	FAMILY "primary" (id, faculty_id, floor, room_no, code, is_delete, created_by, created_at, updated_by, updated_at)
+	FAMILY "primary" (id, faculty_id, floor, room_no, code, is_delete, created_by, created_at, updated_by, updated_at, capacity, features)
 );
 
 CREATE TABLE intake (
@@ -127,6 +295,37 @@ CREATE TABLE intake (
 	FAMILY "primary" (id, year, month, is_delete, created_by, created_at, updated_by, updated_at, trimester, start_date, end_date)
 );
 
+CREATE TABLE holiday (
+	id UUID NOT NULL DEFAULT gen_random_uuid(),
+	date DATE NOT NULL,
+	name STRING NOT NULL,
+	is_delete BOOL NOT NULL DEFAULT false,
+	created_by UUID NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now():::TIMESTAMPTZ,
+	updated_by UUID NULL,
+	updated_at TIMESTAMPTZ NULL,
+	CONSTRAINT "primary" PRIMARY KEY (id ASC),
+	INDEX holiday_date_idx (date ASC),
+	FAMILY "primary" (id, date, name, is_delete, created_by, created_at, updated_by, updated_at)
+);
+
+CREATE TABLE academic_period (
+	id UUID NOT NULL DEFAULT gen_random_uuid(),
+	intake_id UUID NOT NULL,
+	type STRING NOT NULL,
+	name STRING NOT NULL,
+	start_date TIMESTAMPTZ NOT NULL,
+	end_date TIMESTAMPTZ NOT NULL,
+	is_delete BOOL NOT NULL DEFAULT false,
+	created_by UUID NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now():::TIMESTAMPTZ,
+	updated_by UUID NULL,
+	updated_at TIMESTAMPTZ NULL,
+	CONSTRAINT "primary" PRIMARY KEY (id ASC),
+	INDEX academic_period_intake_id_idx (intake_id ASC, type ASC),
+	FAMILY "primary" (id, intake_id, type, name, start_date, end_date, is_delete, created_by, created_at, updated_by, updated_at)
+);
+
 CREATE TABLE session (
 	id UUID NOT NULL DEFAULT gen_random_uuid(),
 	subject_id UUID NOT NULL,
@@ -142,6 +341,7 @@ CREATE TABLE session (
 	day INT8 NOT NULL,
 	start_time TIME NOT NULL,
 	end_time TIME NOT NULL,
+	capacity INT8 NOT NULL DEFAULT 0:::INT8,
 	CONSTRAINT "primary" PRIMARY KEY (id ASC),
 	INDEX timetable_subject_id_idx (subject_id ASC, lecturer_id ASC, intake_id ASC, classroom_id ASC, program_id ASC),
 	INDEX timetable_auto_index_timetable_fk (subject_id ASC),
@@ -149,7 +349,30 @@ CREATE TABLE session (
 	INDEX timetable_auto_index_timetable_fk_2 (program_id ASC),
 	INDEX timetable_auto_index_timetable_fk_3 (classroom_id ASC),
 	INDEX timetable_auto_index_timetable_fk_4 (intake_id ASC),
-	FAMILY "primary" (id, subject_id, lecturer_id, intake_id, is_delete, created_by, created_at, updated_by, updated_at, classroom_id, program_id, day, start_time, end_time)
+	FAMILY "primary" (id, subject_id, lecturer_id, intake_id, is_delete, created_by, created_at, updated_by, updated_at, classroom_id, program_id, day, start_time, end_time, capacity)
+);
+
+CREATE TABLE class (
+	id UUID NOT NULL DEFAULT gen_random_uuid(),
+	session_id UUID NOT NULL,
+	date DATE NOT NULL,
+	status STRING NOT NULL DEFAULT 'scheduled':::STRING,
+	reason STRING NOT NULL DEFAULT '':::STRING,
+	classroom_id UUID NULL,
+	substitute_lecturer_id UUID NULL,
+	rescheduled_from_id UUID NULL,
+	is_delete BOOL NOT NULL DEFAULT false,
+	created_by UUID NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now():::TIMESTAMPTZ,
+	updated_by UUID NULL,
+	updated_at TIMESTAMPTZ NULL,
+	CONSTRAINT "primary" PRIMARY KEY (id ASC),
+	UNIQUE INDEX class_session_id_date_key (session_id ASC, date ASC) WHERE is_delete = false,
+	INDEX class_date_idx (date ASC),
+	INDEX class_classroom_id_idx (classroom_id ASC),
+	INDEX class_substitute_lecturer_id_idx (substitute_lecturer_id ASC),
+	INDEX class_rescheduled_from_id_idx (rescheduled_from_id ASC),
+	FAMILY "primary" (id, session_id, date, status, reason, classroom_id, substitute_lecturer_id, rescheduled_from_id, is_delete, created_by, created_at, updated_by, updated_at)
 );
 
 CREATE TABLE student_enroll (
@@ -161,11 +384,29 @@ CREATE TABLE student_enroll (
 	created_at TIMESTAMPTZ NOT NULL DEFAULT now():::TIMESTAMPTZ,
 	updated_by UUID NULL,
 	updated_at TIMESTAMPTZ NULL,
+	late_reason STRING NOT NULL DEFAULT '':::STRING,
+	status STRING NOT NULL DEFAULT 'enrolled':::STRING,
 	CONSTRAINT "primary" PRIMARY KEY (id ASC),
 	INDEX student_enroll_timetable_id_idx ("session_ID" ASC, student_id ASC),
 	INDEX student_enroll_auto_index_student_enroll_fk (student_id ASC),
 	INDEX student_enroll_auto_index_student_enroll_fk_1 ("session_ID" ASC),
-	FAMILY "primary" (id, "session_ID", student_id, is_delete, created_by, created_at, updated_by, updated_at)
+	FAMILY "primary" (id, "session_ID", student_id, is_delete, created_by, created_at, updated_by, updated_at, late_reason, status)
+);
+
+CREATE TABLE student_waitlist (
+	id UUID NOT NULL DEFAULT gen_random_uuid(),
+	session_id UUID NOT NULL,
+	student_id UUID NOT NULL,
+	is_delete BOOL NOT NULL DEFAULT false,
+	created_by UUID NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now():::TIMESTAMPTZ,
+	updated_by UUID NULL,
+	updated_at TIMESTAMPTZ NULL,
+	CONSTRAINT "primary" PRIMARY KEY (id ASC),
+	UNIQUE INDEX student_waitlist_session_id_student_id_key (session_id ASC, student_id ASC) WHERE is_delete = false,
+	INDEX student_waitlist_session_id_idx (session_id ASC, created_at ASC),
+	INDEX student_waitlist_student_id_idx (student_id ASC),
+	FAMILY "primary" (id, session_id, student_id, is_delete, created_by, created_at, updated_by, updated_at)
 );
 
 CREATE TABLE attendance (
@@ -177,26 +418,101 @@ CREATE TABLE attendance (
 	created_at TIMESTAMPTZ NOT NULL DEFAULT now():::TIMESTAMPTZ,
 	updated_by UUID NULL,
 	updated_at TIMESTAMPTZ NULL,
+	status STRING NOT NULL DEFAULT 'absent':::STRING,
+	reason STRING NOT NULL DEFAULT '':::STRING,
 	CONSTRAINT "primary" PRIMARY KEY (id ASC),
 	INDEX attendance_student_id_idx (student_id ASC, student_enroll_id ASC),
 	INDEX attendance_auto_index_attendance_fk_1 (student_enroll_id ASC),
-	FAMILY "primary" (id, student_id, student_enroll_id, is_attend, created_by, created_at, updated_by, updated_at)
+	FAMILY "primary" (id, student_id, student_enroll_id, is_attend, created_by, created_at, updated_by, updated_at, status, reason)
+);
+
+CREATE TABLE assessment (
+	id UUID NOT NULL DEFAULT gen_random_uuid(),
+	session_id UUID NOT NULL,
+	name STRING NOT NULL,
+	weight INT8 NOT NULL,
+	max_marks INT8 NOT NULL DEFAULT 100:::INT8,
+	is_delete BOOL NOT NULL DEFAULT false,
+	created_by UUID NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now():::TIMESTAMPTZ,
+	updated_by UUID NULL,
+	updated_at TIMESTAMPTZ NULL,
+	CONSTRAINT "primary" PRIMARY KEY (id ASC),
+	INDEX assessment_session_id_idx (session_id ASC),
+	FAMILY "primary" (id, session_id, name, weight, max_marks, is_delete, created_by, created_at, updated_by, updated_at)
+);
+
+CREATE TABLE assessment_score (
+	id UUID NOT NULL DEFAULT gen_random_uuid(),
+	assessment_id UUID NOT NULL,
+	student_enroll_id UUID NOT NULL,
+	score FLOAT8 NOT NULL DEFAULT 0.0:::FLOAT8,
+	is_delete BOOL NOT NULL DEFAULT false,
+	created_by UUID NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now():::TIMESTAMPTZ,
+	updated_by UUID NULL,
+	updated_at TIMESTAMPTZ NULL,
+	CONSTRAINT "primary" PRIMARY KEY (id ASC),
+	INDEX assessment_score_assessment_id_idx (assessment_id ASC, student_enroll_id ASC),
+	INDEX assessment_score_student_enroll_id_idx (student_enroll_id ASC),
+	FAMILY "primary" (id, assessment_id, student_enroll_id, score, is_delete, created_by, created_at, updated_by, updated_at)
 );
 
 CREATE TABLE result (
 	id UUID NOT NULL DEFAULT gen_random_uuid(),
 	student_enroll_id UUID NOT NULL,
-	grade VARCHAR(1) NOT NULL,
+	grade STRING NOT NULL,
 	marks INT8 NOT NULL,
 	is_delete BOOL NOT NULL DEFAULT false,
 	created_by UUID NOT NULL,
 	created_at TIMESTAMPTZ NOT NULL DEFAULT now():::TIMESTAMPTZ,
 	updated_by UUID NULL,
 	updated_at TIMESTAMPTZ NULL,
+	grade_point FLOAT8 NOT NULL DEFAULT 0.0:::FLOAT8,
+	grading_scheme_id UUID NULL,
+	status STRING NOT NULL DEFAULT 'draft':::STRING,
+	is_derived BOOL NOT NULL DEFAULT false,
 	CONSTRAINT "primary" PRIMARY KEY (id ASC),
 	INDEX result_student_enroll_id_idx (student_enroll_id ASC),
 	INDEX result_auto_index_result_fk (student_enroll_id ASC),
-	FAMILY "primary" (id, student_enroll_id, grade, marks, is_delete, created_by, created_at, updated_by, updated_at)
+	FAMILY "primary" (id, student_enroll_id, grade, marks, is_delete, created_by, created_at, updated_by, updated_at, grade_point, grading_scheme_id, status, is_derived)
+);
+
+CREATE TABLE result_appeal (
+	id UUID NOT NULL DEFAULT gen_random_uuid(),
+	result_id UUID NOT NULL,
+	student_id UUID NOT NULL,
+	reason STRING NOT NULL,
+	status STRING NOT NULL DEFAULT 'pending':::STRING,
+	original_marks INT8 NOT NULL,
+	original_grade STRING NOT NULL,
+	revised_marks INT8 NULL,
+	revised_grade STRING NULL,
+	review_comment STRING NOT NULL DEFAULT '':::STRING,
+	reviewed_by UUID NULL,
+	reviewed_at TIMESTAMPTZ NULL,
+	is_delete BOOL NOT NULL DEFAULT false,
+	created_by UUID NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now():::TIMESTAMPTZ,
+	updated_by UUID NULL,
+	updated_at TIMESTAMPTZ NULL,
+	CONSTRAINT "primary" PRIMARY KEY (id ASC),
+	INDEX result_appeal_result_id_idx (result_id ASC),
+	INDEX result_appeal_student_id_idx (student_id ASC),
+	FAMILY "primary" (id, result_id, student_id, reason, status, original_marks, original_grade, revised_marks, revised_grade, review_comment, reviewed_by, reviewed_at, is_delete, created_by, created_at, updated_by, updated_at)
+);
+
+CREATE TABLE result_status_history (
+	id UUID NOT NULL DEFAULT gen_random_uuid(),
+	session_id UUID NOT NULL,
+	from_status STRING NOT NULL,
+	to_status STRING NOT NULL,
+	comment STRING NOT NULL DEFAULT '':::STRING,
+	created_by UUID NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now():::TIMESTAMPTZ,
+	CONSTRAINT "primary" PRIMARY KEY (id ASC),
+	INDEX result_status_history_session_id_idx (session_id ASC),
+	FAMILY "primary" (id, session_id, from_status, to_status, comment, created_by, created_at)
 );
 
 INSERT INTO admin (id, username, password, created_by, created_at, updated_by, updated_at, is_active) VALUES
@@ -204,29 +520,81 @@ INSERT INTO admin (id, username, password, created_by, created_at, updated_by, u
 
 ALTER TABLE program ADD CONSTRAINT program_fk FOREIGN KEY (faculty_id) REFERENCES faculty(id) ON DELETE CASCADE ON UPDATE CASCADE;
 ALTER TABLE student ADD CONSTRAINT student_fk FOREIGN KEY (program_id) REFERENCES program(id) ON DELETE CASCADE ON UPDATE CASCADE;
+ALTER TABLE subject_prerequisite ADD CONSTRAINT subject_prerequisite_fk FOREIGN KEY (subject_id) REFERENCES subject(id) ON DELETE CASCADE ON UPDATE CASCADE;
+ALTER TABLE subject_prerequisite ADD CONSTRAINT subject_prerequisite_fk_1 FOREIGN KEY (prerequisite_subject_id) REFERENCES subject(id) ON DELETE CASCADE ON UPDATE CASCADE;
 ALTER TABLE classroom ADD CONSTRAINT classroom_fk FOREIGN KEY (faculty_id) REFERENCES faculty(id) ON DELETE CASCADE ON UPDATE CASCADE;
 ALTER TABLE session ADD CONSTRAINT timetable_fk FOREIGN KEY (subject_id) REFERENCES subject(id) ON DELETE CASCADE ON UPDATE CASCADE;
 ALTER TABLE session ADD CONSTRAINT timetable_fk_1 FOREIGN KEY (lecturer_id) REFERENCES lecturer(id) ON DELETE CASCADE ON UPDATE CASCADE;
 ALTER TABLE session ADD CONSTRAINT timetable_fk_2 FOREIGN KEY (program_id) REFERENCES program(id) ON DELETE CASCADE ON UPDATE CASCADE;
 ALTER TABLE session ADD CONSTRAINT timetable_fk_3 FOREIGN KEY (classroom_id) REFERENCES classroom(id) ON DELETE CASCADE ON UPDATE CASCADE;
 ALTER TABLE session ADD CONSTRAINT timetable_fk_4 FOREIGN KEY (intake_id) REFERENCES intake(id) ON DELETE CASCADE ON UPDATE CASCADE;
+ALTER TABLE class ADD CONSTRAINT class_fk FOREIGN KEY (session_id) REFERENCES session(id) ON DELETE CASCADE ON UPDATE CASCADE;
+ALTER TABLE class ADD CONSTRAINT class_fk_1 FOREIGN KEY (classroom_id) REFERENCES classroom(id) ON DELETE SET NULL ON UPDATE CASCADE;
+ALTER TABLE class ADD CONSTRAINT class_fk_2 FOREIGN KEY (substitute_lecturer_id) REFERENCES lecturer(id) ON DELETE SET NULL ON UPDATE CASCADE;
+ALTER TABLE class ADD CONSTRAINT class_fk_3 FOREIGN KEY (rescheduled_from_id) REFERENCES class(id) ON DELETE SET NULL ON UPDATE CASCADE;
 ALTER TABLE student_enroll ADD CONSTRAINT student_enroll_fk FOREIGN KEY (student_id) REFERENCES student(id) ON DELETE CASCADE ON UPDATE CASCADE;
 ALTER TABLE student_enroll ADD CONSTRAINT student_enroll_fk_1 FOREIGN KEY ("session_ID") REFERENCES session(id) ON DELETE CASCADE ON UPDATE CASCADE;
+ALTER TABLE student_waitlist ADD CONSTRAINT student_waitlist_fk FOREIGN KEY (student_id) REFERENCES student(id) ON DELETE CASCADE ON UPDATE CASCADE;
+ALTER TABLE student_waitlist ADD CONSTRAINT student_waitlist_fk_1 FOREIGN KEY (session_id) REFERENCES session(id) ON DELETE CASCADE ON UPDATE CASCADE;
+ALTER TABLE grading_band ADD CONSTRAINT grading_band_fk FOREIGN KEY (grading_scheme_id) REFERENCES grading_scheme(id) ON DELETE CASCADE ON UPDATE CASCADE;
+ALTER TABLE program ADD CONSTRAINT program_fk_1 FOREIGN KEY (grading_scheme_id) REFERENCES grading_scheme(id) ON DELETE SET NULL ON UPDATE CASCADE;
+ALTER TABLE subject ADD CONSTRAINT subject_fk FOREIGN KEY (grading_scheme_id) REFERENCES grading_scheme(id) ON DELETE SET NULL ON UPDATE CASCADE;
+ALTER TABLE result ADD CONSTRAINT result_fk_1 FOREIGN KEY (grading_scheme_id) REFERENCES grading_scheme(id) ON DELETE SET NULL ON UPDATE CASCADE;
+ALTER TABLE assessment ADD CONSTRAINT assessment_fk FOREIGN KEY (session_id) REFERENCES session(id) ON DELETE CASCADE ON UPDATE CASCADE;
+ALTER TABLE assessment_score ADD CONSTRAINT assessment_score_fk FOREIGN KEY (assessment_id) REFERENCES assessment(id) ON DELETE CASCADE ON UPDATE CASCADE;
+ALTER TABLE assessment_score ADD CONSTRAINT assessment_score_fk_1 FOREIGN KEY (student_enroll_id) REFERENCES student_enroll(id) ON DELETE CASCADE ON UPDATE CASCADE;
+ALTER TABLE academic_period ADD CONSTRAINT academic_period_fk FOREIGN KEY (intake_id) REFERENCES intake(id) ON DELETE CASCADE ON UPDATE CASCADE;
+ALTER TABLE result_appeal ADD CONSTRAINT result_appeal_fk FOREIGN KEY (result_id) REFERENCES result(id) ON DELETE CASCADE ON UPDATE CASCADE;
+ALTER TABLE result_appeal ADD CONSTRAINT result_appeal_fk_1 FOREIGN KEY (student_id) REFERENCES student(id) ON DELETE CASCADE ON UPDATE CASCADE;
+ALTER TABLE result_status_history ADD CONSTRAINT result_status_history_fk FOREIGN KEY (session_id) REFERENCES session(id) ON DELETE CASCADE ON UPDATE CASCADE;
 ALTER TABLE attendance ADD CONSTRAINT attendance_fk FOREIGN KEY (student_id) REFERENCES student(id) ON DELETE CASCADE ON UPDATE CASCADE;
 ALTER TABLE attendance ADD CONSTRAINT attendance_fk_1 FOREIGN KEY (student_enroll_id) REFERENCES student_enroll(id) ON DELETE CASCADE ON UPDATE CASCADE;
 ALTER TABLE result ADD CONSTRAINT result_fk FOREIGN KEY (student_enroll_id) REFERENCES student_enroll(id) ON DELETE CASCADE ON UPDATE CASCADE;
+ALTER TABLE curriculum ADD CONSTRAINT curriculum_fk FOREIGN KEY (program_id) REFERENCES program(id) ON DELETE CASCADE ON UPDATE CASCADE;
+ALTER TABLE curriculum ADD CONSTRAINT curriculum_fk_1 FOREIGN KEY (subject_id) REFERENCES subject(id) ON DELETE CASCADE ON UPDATE CASCADE;
+ALTER TABLE graduation ADD CONSTRAINT graduation_fk FOREIGN KEY (student_id) REFERENCES student(id) ON DELETE CASCADE ON UPDATE CASCADE;
+ALTER TABLE graduation ADD CONSTRAINT graduation_fk_1 FOREIGN KEY (intake_id) REFERENCES intake(id) ON DELETE CASCADE ON UPDATE CASCADE;
+ALTER TABLE student_status_history ADD CONSTRAINT student_status_history_fk FOREIGN KEY (student_id) REFERENCES student(id) ON DELETE CASCADE ON UPDATE CASCADE;
+ALTER TABLE student_code_sequence ADD CONSTRAINT student_code_sequence_fk FOREIGN KEY (program_id) REFERENCES program(id) ON DELETE CASCADE ON UPDATE CASCADE;
+ALTER TABLE classroom_booking ADD CONSTRAINT classroom_booking_fk FOREIGN KEY (classroom_id) REFERENCES classroom(id) ON DELETE CASCADE ON UPDATE CASCADE;
 
 -- Validate foreign key constraints. These can fail if there was unvalidated data during the dump.
 ALTER TABLE program VALIDATE CONSTRAINT program_fk;
 ALTER TABLE student VALIDATE CONSTRAINT student_fk;
+ALTER TABLE subject_prerequisite VALIDATE CONSTRAINT subject_prerequisite_fk;
+ALTER TABLE subject_prerequisite VALIDATE CONSTRAINT subject_prerequisite_fk_1;
 ALTER TABLE classroom VALIDATE CONSTRAINT classroom_fk;
 ALTER TABLE session VALIDATE CONSTRAINT timetable_fk;
 ALTER TABLE session VALIDATE CONSTRAINT timetable_fk_1;
 ALTER TABLE session VALIDATE CONSTRAINT timetable_fk_2;
 ALTER TABLE session VALIDATE CONSTRAINT timetable_fk_3;
 ALTER TABLE session VALIDATE CONSTRAINT timetable_fk_4;
+ALTER TABLE class VALIDATE CONSTRAINT class_fk;
+ALTER TABLE class VALIDATE CONSTRAINT class_fk_1;
+ALTER TABLE class VALIDATE CONSTRAINT class_fk_2;
+ALTER TABLE class VALIDATE CONSTRAINT class_fk_3;
 ALTER TABLE student_enroll VALIDATE CONSTRAINT student_enroll_fk;
 ALTER TABLE student_enroll VALIDATE CONSTRAINT student_enroll_fk_1;
+ALTER TABLE student_waitlist VALIDATE CONSTRAINT student_waitlist_fk;
+ALTER TABLE student_waitlist VALIDATE CONSTRAINT student_waitlist_fk_1;
+ALTER TABLE grading_band VALIDATE CONSTRAINT grading_band_fk;
+ALTER TABLE program VALIDATE CONSTRAINT program_fk_1;
+ALTER TABLE subject VALIDATE CONSTRAINT subject_fk;
+ALTER TABLE result VALIDATE CONSTRAINT result_fk_1;
+ALTER TABLE assessment VALIDATE CONSTRAINT assessment_fk;
+ALTER TABLE assessment_score VALIDATE CONSTRAINT assessment_score_fk;
+ALTER TABLE assessment_score VALIDATE CONSTRAINT assessment_score_fk_1;
+ALTER TABLE academic_period VALIDATE CONSTRAINT academic_period_fk;
+ALTER TABLE result_appeal VALIDATE CONSTRAINT result_appeal_fk;
+ALTER TABLE result_appeal VALIDATE CONSTRAINT result_appeal_fk_1;
+ALTER TABLE result_status_history VALIDATE CONSTRAINT result_status_history_fk;
 ALTER TABLE attendance VALIDATE CONSTRAINT attendance_fk;
 ALTER TABLE attendance VALIDATE CONSTRAINT attendance_fk_1;
 ALTER TABLE result VALIDATE CONSTRAINT result_fk;
+ALTER TABLE curriculum VALIDATE CONSTRAINT curriculum_fk;
+ALTER TABLE curriculum VALIDATE CONSTRAINT curriculum_fk_1;
+ALTER TABLE graduation VALIDATE CONSTRAINT graduation_fk;
+ALTER TABLE graduation VALIDATE CONSTRAINT graduation_fk_1;
+ALTER TABLE student_status_history VALIDATE CONSTRAINT student_status_history_fk;
+ALTER TABLE student_code_sequence VALIDATE CONSTRAINT student_code_sequence_fk;
+ALTER TABLE classroom_booking VALIDATE CONSTRAINT classroom_booking_fk;
@@ -163,3 +163,28 @@ func DeleteCache(ctx context.Context, id string) error {
 
 	return nil
 }
+
+// PopDataFromCache reads the key and deletes it in one transaction, so the value is only ever returned once.
+func PopDataFromCache(ctx context.Context, id string) (string, error) {
+	conn, err := cachePool.Dial()
+
+	if err != nil {
+		return "", err
+	}
+
+	conn.Send("MULTI")
+	conn.Send("GET", id)
+	conn.Send("DEL", id)
+
+	values, err := redis.Values(conn.Do("EXEC"))
+	if err != nil {
+		return "", err
+	}
+
+	data, err := redis.String(values[0], nil)
+	if err != nil {
+		return "", err
+	}
+
+	return data, nil
+}
@@ -1,11 +1,15 @@
 package helpers
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 )
 
+// PQ_UNIQUE_VIOLATION is the SQLSTATE returned when an insert or update breaks a unique index.
+const PQ_UNIQUE_VIOLATION = "23505"
+
 type DBOptions struct {
 	Host        string
 	Port        int
@@ -18,6 +22,12 @@ type DBOptions struct {
 	SSLMode     string
 }
 
+type Queryer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
 func InitDB(options DBOptions) (*sql.DB, error) {
 	dbConfig := fmt.Sprintf("postgresql://%s:%s@%s:%d/%s?sslmode=%s",
 		options.Username,
@@ -36,3 +46,15 @@ func InitDB(options DBOptions) (*sql.DB, error) {
 	}
 	return db, nil
 }
+
+// IsUniqueViolation reports whether err was raised by a unique index, such as a second student with the
+// same email.
+func IsUniqueViolation(err error) bool {
+
+	pqErr, ok := err.(*pq.Error)
+	if !ok {
+		return false
+	}
+
+	return pqErr.Code == PQ_UNIQUE_VIOLATION
+}
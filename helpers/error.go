@@ -44,13 +44,20 @@ func ErrorResponse(w http.ResponseWriter, message string, status int) {
 }
 
 const (
-	InternalServerError         = "Internal Server Error"
-	BadRequestMessage           = "Bad Request"
-	UnauthorizedMessage         = "Unauthorized"
-	IncorrectEmailMessage       = "Incorrect Email"
-	IncorrectStudentCodeMessage = "Incorrect Student Code"
-	IncorrectPasswordMessage    = "Incorrect Password"
-	IncorrectMonthMessage       = "Incorrect Month"
-	ForbiddenMessage            = "Forbidden Message"
-	PasswordChangedMessage      = "Password Changed"
+	InternalServerError          = "Internal Server Error"
+	BadRequestMessage            = "Bad Request"
+	UnauthorizedMessage          = "Unauthorized"
+	IncorrectEmailMessage        = "Incorrect Email"
+	IncorrectStudentCodeMessage  = "Incorrect Student Code"
+	IncorrectPasswordMessage     = "Incorrect Password"
+	IncorrectMonthMessage        = "Incorrect Month"
+	IncorrectTrimesterMessage    = "Incorrect Trimester"
+	ForbiddenMessage             = "Forbidden Message"
+	PasswordChangedMessage       = "Password Changed"
+	SessionConflictMessage       = "Session Conflict"
+	PrerequisiteNotMetMessage    = "Prerequisite Not Met"
+	ImportValidationMessage      = "Import Validation Failed"
+	GraduationNotEligibleMessage = "Graduation Requirements Not Met"
+	StudentStatusMessage         = "Student Status Does Not Allow This Action"
+	ClassroomBookedMessage       = "Classroom Already Booked"
 )
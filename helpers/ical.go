@@ -0,0 +1,97 @@
+package helpers
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	icalDateTime = "20060102T150405"
+	icalLineSize = 75
+)
+
+type (
+	// CalendarEvent is a weekly repeating event, or a single one when Until is zero. Start, End, Until and
+	// ExDates are written as floating local times, so calendar clients show them in the reader's own time zone.
+	CalendarEvent struct {
+		UID         string
+		Summary     string
+		Location    string
+		Description string
+		Start       time.Time
+		End         time.Time
+		Until       time.Time
+		ExDates     []time.Time
+	}
+)
+
+var icalDays = []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+var icalEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+// BuildCalendar writes the events as an RFC 5545 VCALENDAR.
+func BuildCalendar(name string, events []CalendarEvent) []byte {
+
+	var buffer bytes.Buffer
+	stamp := time.Now().UTC().Format(icalDateTime) + "Z"
+
+	writeICalLine(&buffer, "BEGIN:VCALENDAR")
+	writeICalLine(&buffer, "VERSION:2.0")
+	writeICalLine(&buffer, "PRODID:-//school//timetable//EN")
+	writeICalLine(&buffer, "CALSCALE:GREGORIAN")
+	writeICalLine(&buffer, "METHOD:PUBLISH")
+	writeICalLine(&buffer, "X-WR-CALNAME:"+icalEscaper.Replace(name))
+
+	for _, event := range events {
+		writeICalLine(&buffer, "BEGIN:VEVENT")
+		writeICalLine(&buffer, "UID:"+event.UID)
+		writeICalLine(&buffer, "DTSTAMP:"+stamp)
+		writeICalLine(&buffer, "DTSTART:"+event.Start.Format(icalDateTime))
+		writeICalLine(&buffer, "DTEND:"+event.End.Format(icalDateTime))
+
+		if !event.Until.IsZero() {
+			writeICalLine(&buffer, fmt.Sprintf("RRULE:FREQ=WEEKLY;BYDAY=%s;UNTIL=%s", icalDays[event.Start.Weekday()],
+				event.Until.Format(icalDateTime)))
+		}
+
+		for _, exDate := range event.ExDates {
+			writeICalLine(&buffer, "EXDATE:"+exDate.Format(icalDateTime))
+		}
+
+		writeICalLine(&buffer, "SUMMARY:"+icalEscaper.Replace(event.Summary))
+
+		if event.Location != "" {
+			writeICalLine(&buffer, "LOCATION:"+icalEscaper.Replace(event.Location))
+		}
+
+		if event.Description != "" {
+			writeICalLine(&buffer, "DESCRIPTION:"+icalEscaper.Replace(event.Description))
+		}
+
+		writeICalLine(&buffer, "END:VEVENT")
+	}
+
+	writeICalLine(&buffer, "END:VCALENDAR")
+
+	return buffer.Bytes()
+}
+
+// writeICalLine folds the line at 75 octets, without splitting a UTF-8 character, and ends it with CRLF.
+func writeICalLine(buffer *bytes.Buffer, line string) {
+
+	size := 0
+	for _, r := range line {
+		length := len(string(r))
+		if size+length > icalLineSize {
+			buffer.WriteString("\r\n ")
+			size = 1
+		}
+
+		buffer.WriteRune(r)
+		size += length
+	}
+
+	buffer.WriteString("\r\n")
+}
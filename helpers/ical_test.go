@@ -0,0 +1,122 @@
+package helpers
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestWriteICalLine(t *testing.T) {
+
+	cases := []string{
+		"BEGIN:VEVENT",
+		strings.Repeat("a", icalLineSize),
+		"SUMMARY:" + strings.Repeat("x", 200),
+		"LOCATION:" + strings.Repeat("Dewan é 講堂 ", 20),
+	}
+
+	for _, line := range cases {
+		var buffer bytes.Buffer
+		writeICalLine(&buffer, line)
+
+		output := buffer.String()
+		if !strings.HasSuffix(output, "\r\n") {
+			t.Fatalf("writeICalLine(%s) does not end with CRLF", line)
+		}
+
+		for _, folded := range strings.Split(strings.TrimSuffix(output, "\r\n"), "\r\n") {
+			if len(folded) > icalLineSize {
+				t.Fatalf("writeICalLine(%s) wrote a line of %d octets", line, len(folded))
+			}
+
+			if !utf8.ValidString(folded) {
+				t.Fatalf("writeICalLine(%s) split a UTF-8 character", line)
+			}
+		}
+
+		if got := strings.ReplaceAll(strings.TrimSuffix(output, "\r\n"), "\r\n ", ""); got != line {
+			t.Fatalf("writeICalLine(%s) unfolds to %s", line, got)
+		}
+	}
+
+	var buffer bytes.Buffer
+	writeICalLine(&buffer, strings.Repeat("a", icalLineSize))
+	if strings.Contains(buffer.String(), "\r\n ") {
+		t.Fatal("writeICalLine folded a line of exactly 75 octets")
+	}
+
+}
+
+func TestBuildCalendar(t *testing.T) {
+
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	events := []CalendarEvent{
+		{
+			UID:         "weekly@school",
+			Summary:     "Databases; Lab, Part 1",
+			Location:    `Block A\Room 2`,
+			Description: "Bring laptops\nand notes",
+			Start:       start,
+			End:         start.Add(2 * time.Hour),
+			Until:       time.Date(2026, 5, 25, 11, 0, 0, 0, time.UTC),
+			ExDates: []time.Time{
+				time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC),
+				time.Date(2026, 4, 6, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			UID:     "single@school",
+			Summary: "Replacement Class",
+			Start:   time.Date(2026, 3, 21, 14, 0, 0, 0, time.UTC),
+			End:     time.Date(2026, 3, 21, 16, 0, 0, 0, time.UTC),
+		},
+	}
+
+	calendar := string(BuildCalendar("Timetable, Semester 1", events))
+	unfolded := strings.ReplaceAll(calendar, "\r\n ", "")
+	lines := strings.Split(strings.TrimSuffix(unfolded, "\r\n"), "\r\n")
+
+	expected := []string{
+		"BEGIN:VCALENDAR",
+		`X-WR-CALNAME:Timetable\, Semester 1`,
+		"UID:weekly@school",
+		"DTSTART:20260302T090000",
+		"DTEND:20260302T110000",
+		"RRULE:FREQ=WEEKLY;BYDAY=MO;UNTIL=20260525T110000",
+		"EXDATE:20260316T090000",
+		"EXDATE:20260406T090000",
+		`SUMMARY:Databases\; Lab\, Part 1`,
+		`LOCATION:Block A\\Room 2`,
+		`DESCRIPTION:Bring laptops\nand notes`,
+		"UID:single@school",
+		"DTSTART:20260321T140000",
+		"SUMMARY:Replacement Class",
+		"END:VCALENDAR",
+	}
+
+	index := 0
+	for _, line := range lines {
+		if index < len(expected) && line == expected[index] {
+			index++
+		}
+	}
+
+	if index != len(expected) {
+		t.Fatalf("BuildCalendar() is missing %s in order:\n%s", expected[index], unfolded)
+	}
+
+	if strings.Count(unfolded, "BEGIN:VEVENT") != 2 || strings.Count(unfolded, "END:VEVENT") != 2 {
+		t.Fatalf("BuildCalendar() expected two events:\n%s", unfolded)
+	}
+
+	if strings.Count(unfolded, "RRULE:") != 1 || strings.Count(unfolded, "EXDATE:") != 2 {
+		t.Fatalf("BuildCalendar() wrote a rule or exception for the single event:\n%s", unfolded)
+	}
+
+	if strings.Count(unfolded, "LOCATION:") != 1 || strings.Count(unfolded, "DESCRIPTION:") != 1 {
+		t.Fatalf("BuildCalendar() wrote an empty location or description:\n%s", unfolded)
+	}
+
+}
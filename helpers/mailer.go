@@ -0,0 +1,78 @@
+package helpers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+type (
+	Mail struct {
+		To      string
+		Subject string
+		Body    string
+	}
+
+	// Mailer delivers a single mail. Implementations are set once with InitMailer.
+	Mailer interface {
+		Send(ctx context.Context, mail Mail) error
+	}
+
+	// FileMailer writes every mail to its own file in Dir instead of sending it, for local use and testing.
+	FileMailer struct {
+		Dir string
+	}
+)
+
+var (
+	mailer    Mailer
+	mailQueue chan Mail
+)
+
+// InitMailer sets the mailer and starts the worker sending queued mails in the background.
+func InitMailer(m Mailer, queueSize int) {
+	mailer = m
+	mailQueue = make(chan Mail, queueSize)
+
+	go func() {
+		for mail := range mailQueue {
+			if err := mailer.Send(context.Background(), mail); err != nil {
+				logger.Err.Errorf("error : mailer : %s : %v", mail.To, err)
+			}
+		}
+	}()
+}
+
+// QueueMail hands the mail to the background worker without waiting for it to be sent.
+func QueueMail(ctx context.Context, mail Mail) error {
+
+	if mailQueue == nil {
+		return errors.New("Mailer Is Not Configured")
+	}
+
+	select {
+	case mailQueue <- mail:
+		return nil
+	default:
+		return errors.New("Mail Queue Is Full")
+	}
+}
+
+func (m FileMailer) Send(ctx context.Context, mail Mail) error {
+
+	err := os.MkdirAll(m.Dir, 0700)
+	if err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%d-%s.eml", time.Now().UnixNano(), strings.NewReplacer("@", "_at_", "/", "_").Replace(mail.To))
+	content := fmt.Sprintf("To: %s\r\nSubject: %s\r\nDate: %s\r\n\r\n%s\r\n", mail.To, mail.Subject,
+		time.Now().Format(time.RFC1123Z), mail.Body)
+
+	return ioutil.WriteFile(filepath.Join(m.Dir, name), []byte(content), 0600)
+}
@@ -18,23 +18,25 @@ var decoder = schema.NewDecoder()
 
 type (
 	FilterOption struct {
-		Limit  int    `json:"limit" schema="limit"`
-		Offset int    `json:"offset" schema="offset"`
-		Search string `json:"search" schema="search"`
-		Dir    string `json:"dir" schema="dir"`
+		Limit  int    `json:"limit" schema:"limit"`
+		Offset int    `json:"offset" schema:"offset"`
+		Search string `json:"search" schema:"search"`
+		Dir    string `json:"dir" schema:"dir"`
 	}
 
 	Filter struct {
 		FilterOption    `json:"filter,omitempty"`
 		SessionID       uuid.UUID `json:"session_id" schema:"session_id"`
 		StudentEnrollID uuid.UUID `json:"student_enroll_id" schema:"student_enroll_id"`
-		ClassID         uuid.UUID `json:"class_id"schema:"class_id"`
+		ClassID         uuid.UUID `json:"class_id" schema:"class_id"`
 		StudentID       uuid.UUID `json:"student_id" schema:"student_id"`
 		SubjectID       uuid.UUID `json:"subject_id" schema:"subject_id"`
-		LecturerID      uuid.UUID `json:"lecturer_id"schema:"lecturer_id"`
+		LecturerID      uuid.UUID `json:"lecturer_id" schema:"lecturer_id"`
 		IntakeID        uuid.UUID `json:"intake_id" schema:"intake_id"`
 		ProgramID       uuid.UUID `json:"program_id" schema:"program_id"`
 		ResultID        uuid.UUID `json:"result_id" schema:"result_id"`
+		ClassroomID     uuid.UUID `json:"classroom_id" schema:"classroom_id"`
+		Status          string    `json:"status" schema:"status"`
 	}
 )
 
@@ -8,4 +8,9 @@ type (
 	BaseResponse struct {
 		Errors []string `json:"errors,omitempty"`
 	}
+	File struct {
+		Name        string
+		ContentType string
+		Content     []byte
+	}
 )
@@ -0,0 +1,190 @@
+package helpers
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io/ioutil"
+	"path"
+	"strconv"
+	"strings"
+)
+
+type (
+	xlsxWorkbook struct {
+		Sheets []struct {
+			ID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+
+	xlsxRelationships struct {
+		Relationships []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+
+	xlsxText struct {
+		Text string `xml:"t"`
+		Runs []struct {
+			Text string `xml:"t"`
+		} `xml:"r"`
+	}
+
+	xlsxSharedStrings struct {
+		Items []xlsxText `xml:"si"`
+	}
+
+	xlsxSheet struct {
+		Rows []struct {
+			Cells []struct {
+				Ref    string   `xml:"r,attr"`
+				Type   string   `xml:"t,attr"`
+				Value  string   `xml:"v"`
+				Inline xlsxText `xml:"is"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+)
+
+func (t xlsxText) String() string {
+
+	if len(t.Runs) == 0 {
+		return t.Text
+	}
+
+	var text strings.Builder
+	for _, run := range t.Runs {
+		text.WriteString(run.Text)
+	}
+
+	return text.String()
+}
+
+// ReadXLSX returns the cells of the first worksheet as text, one slice per row, the same shape as
+// csv.Reader.ReadAll. Empty cells in the middle of a row are kept as empty strings.
+func ReadXLSX(content []byte) ([][]string, error) {
+
+	reader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string]*zip.File)
+	for _, file := range reader.File {
+		files[file.Name] = file
+	}
+
+	var workbook xlsxWorkbook
+	err = readXLSXPart(files, "xl/workbook.xml", &workbook)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(workbook.Sheets) == 0 {
+		return nil, errors.New("Workbook Has No Sheets")
+	}
+
+	var relationships xlsxRelationships
+	err = readXLSXPart(files, "xl/_rels/workbook.xml.rels", &relationships)
+	if err != nil {
+		return nil, err
+	}
+
+	sheetPath := ""
+	for _, relationship := range relationships.Relationships {
+		if relationship.ID == workbook.Sheets[0].ID {
+			sheetPath = relationship.Target
+		}
+	}
+
+	if strings.HasPrefix(sheetPath, "/") {
+		sheetPath = strings.TrimPrefix(sheetPath, "/")
+	} else {
+		sheetPath = path.Join("xl", sheetPath)
+	}
+
+	var sharedStrings xlsxSharedStrings
+	if _, ok := files["xl/sharedStrings.xml"]; ok {
+		err = readXLSXPart(files, "xl/sharedStrings.xml", &sharedStrings)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var sheet xlsxSheet
+	err = readXLSXPart(files, sheetPath, &sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	var records [][]string
+	for _, row := range sheet.Rows {
+		var record []string
+		for _, cell := range row.Cells {
+			column := len(record)
+			if cell.Ref != "" {
+				column = xlsxColumn(cell.Ref)
+			}
+
+			for len(record) < column {
+				record = append(record, "")
+			}
+
+			value := cell.Value
+			switch cell.Type {
+			case "s":
+				index, err := strconv.Atoi(cell.Value)
+				if err != nil || index < 0 || index >= len(sharedStrings.Items) {
+					return nil, errors.New("Invalid Shared String In Cell " + cell.Ref)
+				}
+				value = sharedStrings.Items[index].String()
+			case "inlineStr":
+				value = cell.Inline.String()
+			}
+
+			record = append(record, value)
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+func readXLSXPart(files map[string]*zip.File, name string, v interface{}) error {
+
+	file, ok := files[name]
+	if !ok {
+		return errors.New("Missing " + name)
+	}
+
+	part, err := file.Open()
+	if err != nil {
+		return err
+	}
+
+	defer part.Close()
+
+	content, err := ioutil.ReadAll(part)
+	if err != nil {
+		return err
+	}
+
+	return xml.Unmarshal(content, v)
+}
+
+// xlsxColumn turns the letters of a cell reference such as "C12" into a zero based column index.
+func xlsxColumn(ref string) int {
+
+	column := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		column = column*26 + int(r-'A') + 1
+	}
+
+	return column - 1
+}
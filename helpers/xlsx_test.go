@@ -0,0 +1,146 @@
+package helpers
+
+import (
+	"archive/zip"
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+const (
+	xlsxTestWorkbook = `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"
+		xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+		<sheets>
+			<sheet name="Students" sheetId="1" r:id="rId1"/>
+			<sheet name="Notes" sheetId="2" r:id="rId2"/>
+		</sheets>
+	</workbook>`
+
+	xlsxTestRelationships = `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+		<Relationship Id="rId2" Target="worksheets/sheet2.xml"/>
+		<Relationship Id="rId1" Target="worksheets/sheet1.xml"/>
+	</Relationships>`
+
+	xlsxTestSharedStrings = `<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+		<si><t>name</t></si>
+		<si><t>email</t></si>
+		<si><r><t>Ali </t></r><r><t>Baba</t></r></si>
+	</sst>`
+
+	xlsxTestNotes = `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+		<sheetData><row r="1"><c r="A1" t="inlineStr"><is><t>ignored</t></is></c></row></sheetData>
+	</worksheet>`
+)
+
+func xlsxTestFile(t *testing.T, parts map[string]string) []byte {
+
+	var buffer bytes.Buffer
+	writer := zip.NewWriter(&buffer)
+	for name, content := range parts {
+		part, err := writer.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = part.Write([]byte(content))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err := writer.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return buffer.Bytes()
+}
+
+func TestReadXLSX(t *testing.T) {
+
+	cases := []struct {
+		name     string
+		sheet    string
+		expected [][]string
+	}{
+		{
+			name: "shared strings",
+			sheet: `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>
+				<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c></row>
+				<row r="2"><c r="A2" t="s"><v>2</v></c><c r="B2"><v>42</v></c></row>
+			</sheetData></worksheet>`,
+			expected: [][]string{{"name", "email"}, {"Ali Baba", "42"}},
+		},
+		{
+			name: "inline strings",
+			sheet: `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>
+				<row r="1"><c r="A1" t="inlineStr"><is><t>ali@school.test</t></is></c></row>
+				<row r="2"><c r="A2" t="inlineStr"><is><r><t>rich </t></r><r><t>text</t></r></is></c></row>
+			</sheetData></worksheet>`,
+			expected: [][]string{{"ali@school.test"}, {"rich text"}},
+		},
+		{
+			name: "sparse cells",
+			sheet: `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>
+				<row r="1"><c r="B1"><v>1</v></c><c r="D1"><v>2</v></c></row>
+				<row r="2"><c r="AA2"><v>3</v></c></row>
+			</sheetData></worksheet>`,
+			expected: [][]string{
+				{"", "1", "", "2"},
+				{"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "",
+					"", "", "3"},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			content := xlsxTestFile(t, map[string]string{
+				"xl/workbook.xml":            xlsxTestWorkbook,
+				"xl/_rels/workbook.xml.rels": xlsxTestRelationships,
+				"xl/sharedStrings.xml":       xlsxTestSharedStrings,
+				"xl/worksheets/sheet1.xml":   c.sheet,
+				"xl/worksheets/sheet2.xml":   xlsxTestNotes,
+			})
+
+			records, err := ReadXLSX(content)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(records, c.expected) {
+				t.Fatalf("ReadXLSX() = %q, expected %q", records, c.expected)
+			}
+		})
+	}
+
+}
+
+func TestReadXLSXInvalid(t *testing.T) {
+
+	cases := map[string]map[string]string{
+		"shared string out of range": {
+			"xl/workbook.xml":            xlsxTestWorkbook,
+			"xl/_rels/workbook.xml.rels": xlsxTestRelationships,
+			"xl/sharedStrings.xml":       xlsxTestSharedStrings,
+			"xl/worksheets/sheet1.xml": `<worksheet><sheetData>
+				<row r="1"><c r="A1" t="s"><v>9</v></c></row>
+			</sheetData></worksheet>`,
+		},
+		"missing worksheet": {
+			"xl/workbook.xml":            xlsxTestWorkbook,
+			"xl/_rels/workbook.xml.rels": xlsxTestRelationships,
+		},
+	}
+
+	for name, parts := range cases {
+		if _, err := ReadXLSX(xlsxTestFile(t, parts)); err == nil {
+			t.Fatalf("ReadXLSX(%s) expected an error", name)
+		}
+	}
+
+	if _, err := ReadXLSX([]byte("name,email")); err == nil {
+		t.Fatal("ReadXLSX(csv) expected an error")
+	}
+
+}
@@ -119,6 +119,9 @@ func LoginMiddleware(next http.Handler, roles ...string) http.Handler {
 
 		}
 
+		ctx = context.WithValue(ctx, "user_id", sessionData.UserID.String())
+		r = r.WithContext(ctx)
+		next.ServeHTTP(w, r)
 	})
 
 }
@@ -0,0 +1,254 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/lib/pq"
+	uuid "github.com/satori/go.uuid"
+	"time"
+)
+
+const (
+	ACADEMIC_PERIOD_ENROLLMENT = "enrollment"
+	ACADEMIC_PERIOD_ADD_DROP   = "add_drop"
+	ACADEMIC_PERIOD_WITHDRAW   = "withdraw"
+	ACADEMIC_PERIOD_TEACHING   = "teaching"
+	ACADEMIC_PERIOD_EXAM       = "exam"
+	ACADEMIC_PERIOD_HOLIDAY    = "holiday"
+)
+
+type (
+	AcademicPeriodModel struct {
+		ID        uuid.UUID
+		IntakeID  uuid.UUID
+		Type      string
+		Name      string
+		StartDate time.Time
+		EndDate   time.Time
+		IsDelete  bool
+		CreatedBy uuid.UUID
+		CreatedAt time.Time
+		UpdatedBy uuid.NullUUID
+		UpdatedAt pq.NullTime
+	}
+
+	AcademicPeriodResponse struct {
+		ID        uuid.UUID `json:"id"`
+		IntakeID  uuid.UUID `json:"intake_id"`
+		Type      string    `json:"type"`
+		Name      string    `json:"name"`
+		StartDate time.Time `json:"start_date"`
+		EndDate   time.Time `json:"end_date"`
+		IsDelete  bool      `json:"is_delete"`
+		CreatedBy uuid.UUID `json:"created_by"`
+		CreatedAt time.Time `json:"created_at"`
+		UpdatedBy uuid.UUID `json:"updated_by"`
+		UpdatedAt time.Time `json:"updated_at"`
+	}
+)
+
+func (s AcademicPeriodModel) Response() AcademicPeriodResponse {
+	return AcademicPeriodResponse{
+		ID:        s.ID,
+		IntakeID:  s.IntakeID,
+		Type:      s.Type,
+		Name:      s.Name,
+		StartDate: s.StartDate,
+		EndDate:   s.EndDate,
+		IsDelete:  s.IsDelete,
+		CreatedBy: s.CreatedBy,
+		CreatedAt: s.CreatedAt,
+		UpdatedBy: s.UpdatedBy.UUID,
+		UpdatedAt: s.UpdatedAt.Time,
+	}
+}
+
+// Contains reports whether the moment falls inside the period. The end date is inclusive up to the end of
+// that day.
+func (s AcademicPeriodModel) Contains(moment time.Time) bool {
+	return !moment.Before(s.StartDate) && moment.Before(s.EndDate.AddDate(0, 0, 1))
+}
+
+func GetOneAcademicPeriod(ctx context.Context, db *sql.DB, academicPeriodID uuid.UUID) (AcademicPeriodModel, error) {
+
+	query := fmt.Sprintf(`
+		SELECT
+			id,
+			intake_id,
+			type,
+			name,
+			start_date,
+			end_date,
+			is_delete,
+			created_by,
+			created_at,
+			updated_by,
+			updated_at
+		FROM academic_period
+		WHERE is_delete = false
+		AND id = $1`)
+
+	var academicPeriod AcademicPeriodModel
+	err := db.QueryRowContext(ctx, query, academicPeriodID).Scan(
+		&academicPeriod.ID,
+		&academicPeriod.IntakeID,
+		&academicPeriod.Type,
+		&academicPeriod.Name,
+		&academicPeriod.StartDate,
+		&academicPeriod.EndDate,
+		&academicPeriod.IsDelete,
+		&academicPeriod.CreatedBy,
+		&academicPeriod.CreatedAt,
+		&academicPeriod.UpdatedBy,
+		&academicPeriod.UpdatedAt,
+	)
+
+	if err != nil {
+		return AcademicPeriodModel{}, err
+	}
+
+	return academicPeriod, nil
+
+}
+
+// GetAllAcademicPeriodByIntake returns the intake's periods ordered by start date. An empty periodType
+// returns every type.
+func GetAllAcademicPeriodByIntake(ctx context.Context, db *sql.DB, intakeID uuid.UUID, periodType string) (
+	[]AcademicPeriodModel, error) {
+
+	var typeQuery string
+
+	args := []interface{}{intakeID}
+
+	if periodType != "" {
+		args = append(args, periodType)
+		typeQuery = fmt.Sprintf(`AND type = $%d`, len(args))
+	}
+
+	query := fmt.Sprintf(`
+		SELECT
+			id,
+			intake_id,
+			type,
+			name,
+			start_date,
+			end_date,
+			is_delete,
+			created_by,
+			created_at,
+			updated_by,
+			updated_at
+		FROM academic_period
+		WHERE is_delete = false
+		AND intake_id = $1
+		%s
+		ORDER BY start_date ASC`, typeQuery)
+
+	rows, err := db.QueryContext(ctx, query, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var academicPeriods []AcademicPeriodModel
+	for rows.Next() {
+		var academicPeriod AcademicPeriodModel
+
+		rows.Scan(
+			&academicPeriod.ID,
+			&academicPeriod.IntakeID,
+			&academicPeriod.Type,
+			&academicPeriod.Name,
+			&academicPeriod.StartDate,
+			&academicPeriod.EndDate,
+			&academicPeriod.IsDelete,
+			&academicPeriod.CreatedBy,
+			&academicPeriod.CreatedAt,
+			&academicPeriod.UpdatedBy,
+			&academicPeriod.UpdatedAt,
+		)
+
+		academicPeriods = append(academicPeriods, academicPeriod)
+	}
+
+	return academicPeriods, nil
+
+}
+
+func (s *AcademicPeriodModel) Insert(ctx context.Context, db *sql.DB) error {
+
+	query := fmt.Sprintf(`
+		INSERT INTO academic_period(
+			intake_id,
+			type,
+			name,
+			start_date,
+			end_date,
+			created_by,
+			created_at)
+		VALUES(
+		$1,$2,$3,$4,$5,$6,now())
+		RETURNING id, created_at,is_delete`)
+
+	err := db.QueryRowContext(ctx, query,
+		s.IntakeID, s.Type, s.Name, s.StartDate, s.EndDate, s.CreatedBy).Scan(
+		&s.ID, &s.CreatedAt, &s.IsDelete,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+
+}
+
+func (s *AcademicPeriodModel) Update(ctx context.Context, db *sql.DB) error {
+
+	query := fmt.Sprintf(`
+		UPDATE academic_period
+		SET
+			type=$1,
+			name=$2,
+			start_date=$3,
+			end_date=$4,
+			updated_at=NOW(),
+			updated_by=$5
+		WHERE id=$6
+		RETURNING id,intake_id,created_at,updated_at,created_by,is_delete`)
+
+	err := db.QueryRowContext(ctx, query,
+		s.Type, s.Name, s.StartDate, s.EndDate, s.UpdatedBy, s.ID).Scan(
+		&s.ID, &s.IntakeID, &s.CreatedAt, &s.UpdatedAt, &s.CreatedBy, &s.IsDelete,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+
+}
+
+func (s *AcademicPeriodModel) Delete(ctx context.Context, db *sql.DB) error {
+
+	query := fmt.Sprintf(`
+		UPDATE academic_period
+		SET
+			is_delete=true,
+			updated_by=$1,
+			updated_at=NOW()
+		WHERE id=$2`)
+
+	_, err := db.ExecContext(ctx, query,
+		s.UpdatedBy, s.ID)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
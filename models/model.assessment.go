@@ -0,0 +1,239 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/lib/pq"
+	uuid "github.com/satori/go.uuid"
+	"school/helpers"
+	"time"
+)
+
+type (
+	AssessmentModel struct {
+		ID        uuid.UUID
+		SessionID uuid.UUID
+		Name      string
+		Weight    int
+		MaxMarks  int
+		IsDelete  bool
+		CreatedBy uuid.UUID
+		CreatedAt time.Time
+		UpdatedBy uuid.NullUUID
+		UpdatedAt pq.NullTime
+	}
+
+	AssessmentResponse struct {
+		ID        uuid.UUID `json:"id"`
+		SessionID uuid.UUID `json:"session_id"`
+		Name      string    `json:"name"`
+		Weight    int       `json:"weight"`
+		MaxMarks  int       `json:"max_marks"`
+		IsDelete  bool      `json:"is_delete"`
+		CreatedBy uuid.UUID `json:"created_by"`
+		CreatedAt time.Time `json:"created_at"`
+		UpdatedBy uuid.UUID `json:"updated_by"`
+		UpdatedAt time.Time `json:"updated_at"`
+	}
+)
+
+func (s AssessmentModel) Response() AssessmentResponse {
+	return AssessmentResponse{
+		ID:        s.ID,
+		SessionID: s.SessionID,
+		Name:      s.Name,
+		Weight:    s.Weight,
+		MaxMarks:  s.MaxMarks,
+		IsDelete:  s.IsDelete,
+		CreatedBy: s.CreatedBy,
+		CreatedAt: s.CreatedAt,
+		UpdatedBy: s.UpdatedBy.UUID,
+		UpdatedAt: s.UpdatedAt.Time,
+	}
+}
+
+func GetOneAssessment(ctx context.Context, db *sql.DB, assessmentID uuid.UUID) (AssessmentModel, error) {
+
+	query := fmt.Sprintf(`
+		SELECT
+			id,
+			session_id,
+			name,
+			weight,
+			max_marks,
+			is_delete,
+			created_by,
+			created_at,
+			updated_by,
+			updated_at
+		FROM assessment
+		WHERE is_delete = false
+		AND id = $1`)
+
+	var assessment AssessmentModel
+	err := db.QueryRowContext(ctx, query, assessmentID).Scan(
+		&assessment.ID,
+		&assessment.SessionID,
+		&assessment.Name,
+		&assessment.Weight,
+		&assessment.MaxMarks,
+		&assessment.IsDelete,
+		&assessment.CreatedBy,
+		&assessment.CreatedAt,
+		&assessment.UpdatedBy,
+		&assessment.UpdatedAt,
+	)
+
+	if err != nil {
+		return AssessmentModel{}, err
+	}
+
+	return assessment, nil
+
+}
+
+func GetAllAssessmentBySession(ctx context.Context, db helpers.Queryer, sessionID uuid.UUID) (
+	[]AssessmentModel, error) {
+
+	query := fmt.Sprintf(`
+		SELECT
+			id,
+			session_id,
+			name,
+			weight,
+			max_marks,
+			is_delete,
+			created_by,
+			created_at,
+			updated_by,
+			updated_at
+		FROM assessment
+		WHERE is_delete = false
+		AND session_id = $1
+		ORDER BY created_at ASC`)
+
+	rows, err := db.QueryContext(ctx, query, sessionID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var assessments []AssessmentModel
+	for rows.Next() {
+		var assessment AssessmentModel
+
+		rows.Scan(
+			&assessment.ID,
+			&assessment.SessionID,
+			&assessment.Name,
+			&assessment.Weight,
+			&assessment.MaxMarks,
+			&assessment.IsDelete,
+			&assessment.CreatedBy,
+			&assessment.CreatedAt,
+			&assessment.UpdatedBy,
+			&assessment.UpdatedAt,
+		)
+
+		assessments = append(assessments, assessment)
+	}
+
+	return assessments, nil
+
+}
+
+func GetTotalAssessmentWeightBySession(ctx context.Context, db helpers.Queryer, sessionID uuid.UUID) (int, error) {
+
+	query := fmt.Sprintf(`
+		SELECT
+			COALESCE(SUM(weight), 0)
+		FROM assessment
+		WHERE is_delete = false
+		AND session_id = $1`)
+
+	var weight int
+	err := db.QueryRowContext(ctx, query, sessionID).Scan(&weight)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return weight, nil
+
+}
+
+func (s *AssessmentModel) Insert(ctx context.Context, db helpers.Queryer) error {
+
+	query := fmt.Sprintf(`
+		INSERT INTO assessment(
+			session_id,
+			name,
+			weight,
+			max_marks,
+			created_by,
+			created_at)
+		VALUES(
+		$1,$2,$3,$4,$5,now())
+		RETURNING id, created_at,is_delete`)
+
+	err := db.QueryRowContext(ctx, query,
+		s.SessionID, s.Name, s.Weight, s.MaxMarks, s.CreatedBy).Scan(
+		&s.ID, &s.CreatedAt, &s.IsDelete,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+
+}
+
+func (s *AssessmentModel) Update(ctx context.Context, db helpers.Queryer) error {
+
+	query := fmt.Sprintf(`
+		UPDATE assessment
+		SET
+			name=$1,
+			weight=$2,
+			max_marks=$3,
+			updated_at=NOW(),
+			updated_by=$4
+		WHERE id=$5
+		RETURNING id,session_id,created_at,updated_at,created_by,is_delete`)
+
+	err := db.QueryRowContext(ctx, query,
+		s.Name, s.Weight, s.MaxMarks, s.UpdatedBy, s.ID).Scan(
+		&s.ID, &s.SessionID, &s.CreatedAt, &s.UpdatedAt, &s.CreatedBy, &s.IsDelete,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+
+}
+
+func (s *AssessmentModel) Delete(ctx context.Context, db helpers.Queryer) error {
+
+	query := fmt.Sprintf(`
+		UPDATE assessment
+		SET
+			is_delete=true,
+			updated_by=$1,
+			updated_at=NOW()
+		WHERE id=$2`)
+
+	_, err := db.ExecContext(ctx, query,
+		s.UpdatedBy, s.ID)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,257 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/lib/pq"
+	uuid "github.com/satori/go.uuid"
+	"school/helpers"
+	"time"
+)
+
+type (
+	AssessmentScoreModel struct {
+		ID              uuid.UUID
+		AssessmentID    uuid.UUID
+		StudentEnrollID uuid.UUID
+		Score           float64
+		IsDelete        bool
+		CreatedBy       uuid.UUID
+		CreatedAt       time.Time
+		UpdatedBy       uuid.NullUUID
+		UpdatedAt       pq.NullTime
+	}
+
+	AssessmentScoreResponse struct {
+		ID            uuid.UUID             `json:"id"`
+		AssessmentID  uuid.UUID             `json:"assessment_id"`
+		StudentEnroll StudentEnrollResponse `json:"student_enroll"`
+		Score         float64               `json:"score"`
+		IsDelete      bool                  `json:"is_delete"`
+		CreatedBy     uuid.UUID             `json:"created_by"`
+		CreatedAt     time.Time             `json:"created_at"`
+		UpdatedBy     uuid.UUID             `json:"updated_by"`
+		UpdatedAt     time.Time             `json:"updated_at"`
+	}
+)
+
+func (s AssessmentScoreModel) Response(ctx context.Context, db *sql.DB, logger *helpers.Logger) (
+	AssessmentScoreResponse, error) {
+
+	studentEnroll, err := GetOneStudentEnroll(ctx, db, s.StudentEnrollID)
+	if err != nil {
+		logger.Err.Printf(`model.assessment.score.go/GetOneStudentEnroll/%v`, err)
+		return AssessmentScoreResponse{}, err
+	}
+
+	studentEnrollResponse, err := studentEnroll.Response(ctx, db, logger)
+	if err != nil {
+		logger.Err.Printf(`model.assessment.score.go/studentEnrollResponse/%v`, err)
+		return AssessmentScoreResponse{}, err
+	}
+
+	return AssessmentScoreResponse{
+		ID:            s.ID,
+		AssessmentID:  s.AssessmentID,
+		StudentEnroll: studentEnrollResponse,
+		Score:         s.Score,
+		IsDelete:      s.IsDelete,
+		CreatedBy:     s.CreatedBy,
+		CreatedAt:     s.CreatedAt,
+		UpdatedBy:     s.UpdatedBy.UUID,
+		UpdatedAt:     s.UpdatedAt.Time,
+	}, nil
+}
+
+func GetOneAssessmentScoreByStudentEnroll(ctx context.Context, db helpers.Queryer, assessmentID uuid.UUID,
+	studentEnrollID uuid.UUID) (AssessmentScoreModel, error) {
+
+	query := fmt.Sprintf(`
+		SELECT
+			id,
+			assessment_id,
+			student_enroll_id,
+			score,
+			is_delete,
+			created_by,
+			created_at,
+			updated_by,
+			updated_at
+		FROM assessment_score
+		WHERE is_delete = false
+		AND assessment_id = $1
+		AND student_enroll_id = $2`)
+
+	var score AssessmentScoreModel
+	err := db.QueryRowContext(ctx, query, assessmentID, studentEnrollID).Scan(
+		&score.ID,
+		&score.AssessmentID,
+		&score.StudentEnrollID,
+		&score.Score,
+		&score.IsDelete,
+		&score.CreatedBy,
+		&score.CreatedAt,
+		&score.UpdatedBy,
+		&score.UpdatedAt,
+	)
+
+	if err != nil {
+		return AssessmentScoreModel{}, err
+	}
+
+	return score, nil
+
+}
+
+func GetAllAssessmentScoreByAssessment(ctx context.Context, db *sql.DB, assessmentID uuid.UUID) (
+	[]AssessmentScoreModel, error) {
+
+	query := fmt.Sprintf(`
+		SELECT
+			sc.id,
+			sc.assessment_id,
+			sc.student_enroll_id,
+			sc.score,
+			sc.is_delete,
+			sc.created_by,
+			sc.created_at,
+			sc.updated_by,
+			sc.updated_at
+		FROM assessment_score sc
+		INNER JOIN student_enroll se ON sc.student_enroll_id = se.id
+		INNER JOIN student st ON se.student_id = st.id
+		WHERE sc.is_delete = false
+		AND se.is_delete = false
+		AND sc.assessment_id = $1
+		ORDER BY st.name ASC`)
+
+	rows, err := db.QueryContext(ctx, query, assessmentID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var scores []AssessmentScoreModel
+	for rows.Next() {
+		var score AssessmentScoreModel
+
+		rows.Scan(
+			&score.ID,
+			&score.AssessmentID,
+			&score.StudentEnrollID,
+			&score.Score,
+			&score.IsDelete,
+			&score.CreatedBy,
+			&score.CreatedAt,
+			&score.UpdatedBy,
+			&score.UpdatedAt,
+		)
+
+		scores = append(scores, score)
+	}
+
+	return scores, nil
+
+}
+
+// GetWeightedMarksByStudentEnroll sums every assessment score of the enrollment scaled to the
+// assessment weight, along with the number of scores. Components without a score count as zero.
+func GetWeightedMarksByStudentEnroll(ctx context.Context, db helpers.Queryer, studentEnrollID uuid.UUID) (
+	float64, int, error) {
+
+	query := fmt.Sprintf(`
+		SELECT
+			COALESCE(SUM(sc.score * a.weight::FLOAT8 / a.max_marks::FLOAT8), 0),
+			COUNT(sc.id)
+		FROM assessment a
+		INNER JOIN student_enroll se ON a.session_id = se.session_id
+		INNER JOIN assessment_score sc ON sc.assessment_id = a.id AND sc.student_enroll_id = se.id
+		WHERE a.is_delete = false
+		AND sc.is_delete = false
+		AND se.id = $1`)
+
+	var marks float64
+	var count int
+	err := db.QueryRowContext(ctx, query, studentEnrollID).Scan(&marks, &count)
+
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return marks, count, nil
+
+}
+
+func (s *AssessmentScoreModel) Insert(ctx context.Context, db helpers.Queryer) error {
+
+	query := fmt.Sprintf(`
+		INSERT INTO assessment_score(
+			assessment_id,
+			student_enroll_id,
+			score,
+			created_by,
+			created_at)
+		VALUES(
+		$1,$2,$3,$4,now())
+		RETURNING id, created_at,is_delete`)
+
+	err := db.QueryRowContext(ctx, query,
+		s.AssessmentID, s.StudentEnrollID, s.Score, s.CreatedBy).Scan(
+		&s.ID, &s.CreatedAt, &s.IsDelete,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+
+}
+
+func (s *AssessmentScoreModel) Update(ctx context.Context, db helpers.Queryer) error {
+
+	query := fmt.Sprintf(`
+		UPDATE assessment_score
+		SET
+			score=$1,
+			updated_at=NOW(),
+			updated_by=$2
+		WHERE id=$3
+		RETURNING id,assessment_id,student_enroll_id,created_at,updated_at,created_by,is_delete`)
+
+	err := db.QueryRowContext(ctx, query,
+		s.Score, s.UpdatedBy, s.ID).Scan(
+		&s.ID, &s.AssessmentID, &s.StudentEnrollID, &s.CreatedAt, &s.UpdatedAt, &s.CreatedBy, &s.IsDelete,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+
+}
+
+func DeleteAssessmentScoreByAssessment(ctx context.Context, db helpers.Queryer, assessmentID uuid.UUID,
+	updatedBy uuid.NullUUID) error {
+
+	query := fmt.Sprintf(`
+		UPDATE assessment_score
+		SET
+			is_delete=true,
+			updated_by=$1,
+			updated_at=NOW()
+		WHERE is_delete = false
+		AND assessment_id=$2`)
+
+	_, err := db.ExecContext(ctx, query, updatedBy, assessmentID)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -11,12 +11,21 @@ import (
 	"time"
 )
 
+const (
+	ATTENDANCE_PRESENT = "present"
+	ATTENDANCE_ABSENT  = "absent"
+	ATTENDANCE_LATE    = "late"
+	ATTENDANCE_EXCUSED = "excused"
+)
+
 type (
 	AttendanceModel struct {
 		ID        uuid.UUID
 		StudentID uuid.UUID
 		ClassID   uuid.UUID
 		IsAttend  bool
+		Status    string
+		Reason    string
 		CreatedBy uuid.UUID
 		CreatedAt time.Time
 		UpdatedBy uuid.NullUUID
@@ -28,6 +37,8 @@ type (
 		Student   StudentResponse `json:"student"`
 		Class     ClassResponse   `json:"class"`
 		IsAttend  bool            `json:"is_attend"`
+		Status    string          `json:"status"`
+		Reason    string          `json:"reason"`
 		CreatedBy uuid.UUID       `json:"created_by"`
 		CreatedAt time.Time       `json:"created_at"`
 		UpdatedBy uuid.UUID       `json:"updated_by"`
@@ -66,6 +77,8 @@ func (s AttendanceModel) Response(ctx context.Context, db *sql.DB, logger *helpe
 		Student:   studentResponse,
 		Class:     classResponse,
 		IsAttend:  s.IsAttend,
+		Status:    s.Status,
+		Reason:    s.Reason,
 		CreatedBy: s.CreatedBy,
 		CreatedAt: s.CreatedAt,
 		UpdatedBy: s.UpdatedBy.UUID,
@@ -81,6 +94,8 @@ func GetOneAttendance(ctx context.Context, db *sql.DB, attendanceID uuid.UUID) (
 			student_id,
 			class_id,
 			is_attend,
+			status,
+			reason,
 			created_by,
 			created_at,
 			updated_by,
@@ -96,6 +111,50 @@ func GetOneAttendance(ctx context.Context, db *sql.DB, attendanceID uuid.UUID) (
 		&attendance.StudentID,
 		&attendance.ClassID,
 		&attendance.IsAttend,
+		&attendance.Status,
+		&attendance.Reason,
+		&attendance.CreatedBy,
+		&attendance.CreatedAt,
+		&attendance.UpdatedBy,
+		&attendance.UpdatedAt,
+	)
+
+	if err != nil {
+		return AttendanceModel{}, err
+	}
+
+	return attendance, nil
+
+}
+
+func GetOneAttendanceByClassAndStudent(ctx context.Context, db *sql.DB, classID uuid.UUID, studentID uuid.UUID) (
+	AttendanceModel, error) {
+
+	query := fmt.Sprintf(`
+		SELECT
+			id,
+			student_id,
+			class_id,
+			is_attend,
+			status,
+			reason,
+			created_by,
+			created_at,
+			updated_by,
+			updated_at
+		FROM attendance
+		WHERE class_id = $1
+		AND student_id = $2
+	`)
+
+	var attendance AttendanceModel
+	err := db.QueryRowContext(ctx, query, classID, studentID).Scan(
+		&attendance.ID,
+		&attendance.StudentID,
+		&attendance.ClassID,
+		&attendance.IsAttend,
+		&attendance.Status,
+		&attendance.Reason,
 		&attendance.CreatedBy,
 		&attendance.CreatedAt,
 		&attendance.UpdatedBy,
@@ -136,6 +195,8 @@ func GetAllAttendance(ctx context.Context, db *sql.DB, filter helpers.Filter) ([
 			student_id,
 			class_id,
 			is_attend,
+			status,
+			reason,
 			created_by,
 			created_at,
 			updated_by,
@@ -160,6 +221,8 @@ func GetAllAttendance(ctx context.Context, db *sql.DB, filter helpers.Filter) ([
 			&attendance.StudentID,
 			&attendance.ClassID,
 			&attendance.IsAttend,
+			&attendance.Status,
+			&attendance.Reason,
 			&attendance.CreatedBy,
 			&attendance.CreatedAt,
 			&attendance.UpdatedBy,
@@ -194,6 +257,8 @@ func GetAllAttendanceByClass(ctx context.Context, db *sql.DB, filter helpers.Fil
 			student_id,
 			class_id,
 			is_attend,
+			status,
+			reason,
 			created_by,
 			created_at,
 			updated_by,
@@ -217,6 +282,8 @@ func GetAllAttendanceByClass(ctx context.Context, db *sql.DB, filter helpers.Fil
 			&attendance.StudentID,
 			&attendance.ClassID,
 			&attendance.IsAttend,
+			&attendance.Status,
+			&attendance.Reason,
 			&attendance.CreatedBy,
 			&attendance.CreatedAt,
 			&attendance.UpdatedBy,
@@ -230,7 +297,7 @@ func GetAllAttendanceByClass(ctx context.Context, db *sql.DB, filter helpers.Fil
 
 }
 
-func (s *AttendanceModel) Insert(ctx context.Context, db *sql.DB) error {
+func (s *AttendanceModel) Insert(ctx context.Context, db helpers.Queryer) error {
 
 	query := fmt.Sprintf(`
 		INSERT INTO attendance(
@@ -240,11 +307,11 @@ func (s *AttendanceModel) Insert(ctx context.Context, db *sql.DB) error {
 			created_at)
 		VALUES(
 		$1,$2,$3,now())
-		RETURNING id, created_at,is_attend`)
+		RETURNING id, created_at,is_attend,status`)
 
 	err := db.QueryRowContext(ctx, query,
 		s.StudentID, s.ClassID, s.CreatedBy).Scan(
-		&s.ID, &s.CreatedAt, &s.IsAttend,
+		&s.ID, &s.CreatedAt, &s.IsAttend, &s.Status,
 	)
 
 	if err != nil {
@@ -255,19 +322,21 @@ func (s *AttendanceModel) Insert(ctx context.Context, db *sql.DB) error {
 
 }
 
-func (s *AttendanceModel) Update(ctx context.Context, db *sql.DB) error {
+func (s *AttendanceModel) Update(ctx context.Context, db helpers.Queryer) error {
 
 	query := fmt.Sprintf(`
 		UPDATE attendance
 		SET
 			is_attend=$1,
+			status=$2,
+			reason=$3,
 			updated_at=NOW(),
-			updated_by=$2
-		WHERE id=$3
+			updated_by=$4
+		WHERE id=$5
 		RETURNING id,student_id,class_id,created_at,updated_at,created_by`)
 
 	err := db.QueryRowContext(ctx, query,
-		s.IsAttend, s.UpdatedBy, s.ID).Scan(
+		s.IsAttend, s.Status, s.Reason, s.UpdatedBy, s.ID).Scan(
 		&s.ID, &s.StudentID, &s.ClassID, &s.CreatedAt, &s.UpdatedAt, &s.CreatedBy,
 	)
 
@@ -278,3 +347,49 @@ func (s *AttendanceModel) Update(ctx context.Context, db *sql.DB) error {
 	return nil
 
 }
+
+// MoveAttendanceClass carries the attendance rows of a rescheduled class over to the class that replaces it.
+func MoveAttendanceClass(ctx context.Context, db helpers.Queryer, fromClassID uuid.UUID, toClassID uuid.UUID,
+	updatedBy uuid.NullUUID) error {
+
+	query := fmt.Sprintf(`
+		UPDATE attendance
+		SET
+			class_id=$1,
+			updated_by=$2,
+			updated_at=NOW()
+		WHERE class_id=$3`)
+
+	_, err := db.ExecContext(ctx, query,
+		toClassID, updatedBy, fromClassID)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteAttendanceByStudentFrom removes the student's attendance rows of the session's classes from the date
+// on, once the student no longer attends the session.
+func DeleteAttendanceByStudentFrom(ctx context.Context, db helpers.Queryer, sessionID uuid.UUID,
+	studentID uuid.UUID, from time.Time) error {
+
+	query := fmt.Sprintf(`
+		DELETE FROM attendance
+		WHERE student_id = $1
+		AND class_id IN (
+			SELECT id
+			FROM class
+			WHERE session_id = $2
+			AND date >= $3
+		)`)
+
+	_, err := db.ExecContext(ctx, query, studentID, sessionID, from)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
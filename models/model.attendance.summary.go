@@ -0,0 +1,156 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	uuid "github.com/satori/go.uuid"
+	"school/helpers"
+)
+
+type (
+	AttendanceSummaryModel struct {
+		SessionID uuid.UUID
+		StudentID uuid.UUID
+		Total     int
+		Present   int
+		Late      int
+		Excused   int
+		Absent    int
+	}
+
+	AttendanceSummaryResponse struct {
+		Session    SessionResponse `json:"session"`
+		Student    StudentResponse `json:"student"`
+		Total      int             `json:"total"`
+		Present    int             `json:"present"`
+		Late       int             `json:"late"`
+		Excused    int             `json:"excused"`
+		Absent     int             `json:"absent"`
+		Percentage float64         `json:"percentage"`
+	}
+)
+
+// Percentage counts late as attended and leaves excused classes out of the total. A student with no
+// countable class yet is at 100%.
+func (s AttendanceSummaryModel) Percentage() float64 {
+
+	total := s.Total - s.Excused
+	if total <= 0 {
+		return 100
+	}
+
+	return float64(s.Present+s.Late) / float64(total) * 100
+}
+
+func (s AttendanceSummaryModel) Response(ctx context.Context, db *sql.DB, logger *helpers.Logger) (
+	AttendanceSummaryResponse, error) {
+
+	session, err := GetOneSession(ctx, db, s.SessionID)
+	if err != nil {
+		logger.Err.Printf(`model.attendance.summary.go/GetOneSession/%v`, err)
+		return AttendanceSummaryResponse{}, err
+	}
+
+	sessionResponse, err := session.Response(ctx, db, logger)
+	if err != nil {
+		logger.Err.Printf(`model.attendance.summary.go/SessionResponse/%v`, err)
+		return AttendanceSummaryResponse{}, err
+	}
+
+	student, err := GetOneStudent(ctx, db, s.StudentID)
+	if err != nil {
+		logger.Err.Printf(`model.attendance.summary.go/GetOneStudent/%v`, err)
+		return AttendanceSummaryResponse{}, err
+	}
+
+	studentResponse, err := student.Response(ctx, db, logger)
+	if err != nil {
+		logger.Err.Printf(`model.attendance.summary.go/StudentResponse/%v`, err)
+		return AttendanceSummaryResponse{}, err
+	}
+
+	return AttendanceSummaryResponse{
+		Session:    sessionResponse,
+		Student:    studentResponse,
+		Total:      s.Total,
+		Present:    s.Present,
+		Late:       s.Late,
+		Excused:    s.Excused,
+		Absent:     s.Absent,
+		Percentage: s.Percentage(),
+	}, nil
+}
+
+// getAllAttendanceSummary counts the attendance of every student per session over classes that already
+// took place, restricted by the given condition on session s or attendance a.
+func getAllAttendanceSummary(ctx context.Context, db *sql.DB, condition string, arg interface{}) (
+	[]AttendanceSummaryModel, error) {
+
+	query := fmt.Sprintf(`
+		SELECT
+			c.session_id,
+			a.student_id,
+			COUNT(a.id),
+			SUM(CASE WHEN a.status = $2 THEN 1 ELSE 0 END),
+			SUM(CASE WHEN a.status = $3 THEN 1 ELSE 0 END),
+			SUM(CASE WHEN a.status = $4 THEN 1 ELSE 0 END),
+			SUM(CASE WHEN a.status = $5 THEN 1 ELSE 0 END)
+		FROM attendance a
+		INNER JOIN class c ON a.class_id = c.id
+		INNER JOIN session s ON c.session_id = s.id
+		WHERE c.is_delete = false
+		AND c.status = $6
+		AND s.is_delete = false
+		AND c.date <= now()
+		AND %s = $1
+		GROUP BY c.session_id, a.student_id
+		ORDER BY c.session_id, a.student_id`, condition)
+
+	rows, err := db.QueryContext(ctx, query, arg,
+		ATTENDANCE_PRESENT, ATTENDANCE_LATE, ATTENDANCE_EXCUSED, ATTENDANCE_ABSENT, CLASS_SCHEDULED)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var summaries []AttendanceSummaryModel
+	for rows.Next() {
+		var summary AttendanceSummaryModel
+
+		rows.Scan(
+			&summary.SessionID,
+			&summary.StudentID,
+			&summary.Total,
+			&summary.Present,
+			&summary.Late,
+			&summary.Excused,
+			&summary.Absent,
+		)
+
+		summaries = append(summaries, summary)
+	}
+
+	return summaries, nil
+
+}
+
+func GetAllAttendanceSummaryBySession(ctx context.Context, db *sql.DB, sessionID uuid.UUID) (
+	[]AttendanceSummaryModel, error) {
+
+	return getAllAttendanceSummary(ctx, db, "s.id", sessionID)
+}
+
+func GetAllAttendanceSummaryByStudent(ctx context.Context, db *sql.DB, studentID uuid.UUID) (
+	[]AttendanceSummaryModel, error) {
+
+	return getAllAttendanceSummary(ctx, db, "a.student_id", studentID)
+}
+
+func GetAllAttendanceSummaryByIntake(ctx context.Context, db *sql.DB, intakeID uuid.UUID) (
+	[]AttendanceSummaryModel, error) {
+
+	return getAllAttendanceSummary(ctx, db, "s.intake_id", intakeID)
+}
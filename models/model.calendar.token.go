@@ -0,0 +1,113 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/lib/pq"
+	uuid "github.com/satori/go.uuid"
+	"school/helpers"
+	"time"
+)
+
+type (
+	CalendarTokenModel struct {
+		ID        uuid.UUID
+		UserID    uuid.UUID
+		Role      string
+		Token     string
+		IsDelete  bool
+		CreatedBy uuid.UUID
+		CreatedAt time.Time
+		UpdatedBy uuid.NullUUID
+		UpdatedAt pq.NullTime
+	}
+)
+
+func GetOneCalendarTokenByToken(ctx context.Context, db *sql.DB, token string) (CalendarTokenModel, error) {
+
+	query := fmt.Sprintf(`
+		SELECT
+			id,
+			user_id,
+			role,
+			token,
+			is_delete,
+			created_by,
+			created_at,
+			updated_by,
+			updated_at
+		FROM calendar_token
+		WHERE is_delete = false
+		AND token = $1`)
+
+	var calendarToken CalendarTokenModel
+	err := db.QueryRowContext(ctx, query, token).Scan(
+		&calendarToken.ID,
+		&calendarToken.UserID,
+		&calendarToken.Role,
+		&calendarToken.Token,
+		&calendarToken.IsDelete,
+		&calendarToken.CreatedBy,
+		&calendarToken.CreatedAt,
+		&calendarToken.UpdatedBy,
+		&calendarToken.UpdatedAt,
+	)
+
+	if err != nil {
+		return CalendarTokenModel{}, err
+	}
+
+	return calendarToken, nil
+
+}
+
+func (s *CalendarTokenModel) Insert(ctx context.Context, db helpers.Queryer) error {
+
+	query := fmt.Sprintf(`
+		INSERT INTO calendar_token(
+			user_id,
+			role,
+			token,
+			created_by,
+			created_at)
+		VALUES(
+		$1,$2,$3,$4,now())
+		RETURNING id, created_at,is_delete`)
+
+	err := db.QueryRowContext(ctx, query,
+		s.UserID, s.Role, s.Token, s.CreatedBy).Scan(
+		&s.ID, &s.CreatedAt, &s.IsDelete,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+
+}
+
+// DeleteCalendarTokenByUser revokes every feed token of the user in the role.
+func DeleteCalendarTokenByUser(ctx context.Context, db helpers.Queryer, userID uuid.UUID, role string,
+	updatedBy uuid.NullUUID) (int64, error) {
+
+	query := fmt.Sprintf(`
+		UPDATE calendar_token
+		SET
+			is_delete=true,
+			updated_by=$1,
+			updated_at=NOW()
+		WHERE is_delete = false
+		AND user_id=$2
+		AND role=$3`)
+
+	result, err := db.ExecContext(ctx, query,
+		updatedBy, userID, role)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
@@ -11,27 +11,45 @@ import (
 	"time"
 )
 
+const (
+	CLASS_SCHEDULED   = "scheduled"
+	CLASS_CANCELLED   = "cancelled"
+	CLASS_RESCHEDULED = "rescheduled"
+)
+
 type (
+	// ClassModel is one occurrence of a session. ClassroomID and SubstituteLecturerID are only set when they
+	// differ from the session's, RescheduledFromID links a moved class to the cancelled original.
 	ClassModel struct {
-		ID        uuid.UUID
-		SessionID uuid.UUID
-		Date      time.Time
-		IsDelete  bool
-		CreatedBy uuid.UUID
-		CreatedAt time.Time
-		UpdatedBy uuid.NullUUID
-		UpdatedAt pq.NullTime
+		ID                   uuid.UUID
+		SessionID            uuid.UUID
+		Date                 time.Time
+		Status               string
+		Reason               string
+		ClassroomID          uuid.NullUUID
+		SubstituteLecturerID uuid.NullUUID
+		RescheduledFromID    uuid.NullUUID
+		IsDelete             bool
+		CreatedBy            uuid.UUID
+		CreatedAt            time.Time
+		UpdatedBy            uuid.NullUUID
+		UpdatedAt            pq.NullTime
 	}
 
 	ClassResponse struct {
-		ID        uuid.UUID       `json:"id"`
-		Session   SessionResponse `json:"session"`
-		Date      time.Time       `json:"date"`
-		IsDelete  bool            `json:"is_delete"`
-		CreatedBy uuid.UUID       `json:"created_by"`
-		CreatedAt time.Time       `json:"created_at"`
-		UpdatedBy uuid.UUID       `json:"updated_by"`
-		UpdatedAt time.Time       `json:"updated_at"`
+		ID                 uuid.UUID         `json:"id"`
+		Session            SessionResponse   `json:"session"`
+		Date               time.Time         `json:"date"`
+		Status             string            `json:"status"`
+		Reason             string            `json:"reason"`
+		Classroom          ClassRoomResponse `json:"classroom"`
+		SubstituteLecturer *LecturerResponse `json:"substitute_lecturer"`
+		RescheduledFromID  uuid.UUID         `json:"rescheduled_from_id"`
+		IsDelete           bool              `json:"is_delete"`
+		CreatedBy          uuid.UUID         `json:"created_by"`
+		CreatedAt          time.Time         `json:"created_at"`
+		UpdatedBy          uuid.UUID         `json:"updated_by"`
+		UpdatedAt          time.Time         `json:"updated_at"`
 	}
 )
 
@@ -49,15 +67,52 @@ func (s ClassModel) Response(ctx context.Context, db *sql.DB, logger *helpers.Lo
 		return ClassResponse{}, nil
 	}
 
+	classroomResponse := sessionResponse.Classroom
+	if s.ClassroomID.Valid {
+		classroom, err := GetOneClassroom(ctx, db, s.ClassroomID.UUID)
+		if err != nil {
+			logger.Err.Printf(`model.class.go/GetOneClassroom/%v`, err)
+			return ClassResponse{}, nil
+		}
+
+		classroomResponse, err = classroom.Response(ctx, db, logger)
+		if err != nil {
+			logger.Err.Printf(`model.class.go/classroomResponse/%v`, err)
+			return ClassResponse{}, nil
+		}
+	}
+
+	var substituteResponse *LecturerResponse
+	if s.SubstituteLecturerID.Valid {
+		substitute, err := GetOneLecturer(ctx, db, s.SubstituteLecturerID.UUID)
+		if err != nil {
+			logger.Err.Printf(`model.class.go/GetOneLecturer/%v`, err)
+			return ClassResponse{}, nil
+		}
+
+		response, err := substitute.Response(ctx, db, logger)
+		if err != nil {
+			logger.Err.Printf(`model.class.go/substituteResponse/%v`, err)
+			return ClassResponse{}, nil
+		}
+
+		substituteResponse = &response
+	}
+
 	return ClassResponse{
-		ID:        s.ID,
-		Session:   sessionResponse,
-		Date:      s.Date,
-		IsDelete:  s.IsDelete,
-		CreatedBy: s.CreatedBy,
-		CreatedAt: s.CreatedAt,
-		UpdatedBy: s.UpdatedBy.UUID,
-		UpdatedAt: s.UpdatedAt.Time,
+		ID:                 s.ID,
+		Session:            sessionResponse,
+		Date:               s.Date,
+		Status:             s.Status,
+		Reason:             s.Reason,
+		Classroom:          classroomResponse,
+		SubstituteLecturer: substituteResponse,
+		RescheduledFromID:  s.RescheduledFromID.UUID,
+		IsDelete:           s.IsDelete,
+		CreatedBy:          s.CreatedBy,
+		CreatedAt:          s.CreatedAt,
+		UpdatedBy:          s.UpdatedBy.UUID,
+		UpdatedAt:          s.UpdatedAt.Time,
 	}, nil
 }
 
@@ -68,6 +123,11 @@ func GetOneClass(ctx context.Context, db *sql.DB, classID uuid.UUID) (ClassModel
 			id,
 			session_id,
 			date,
+			status,
+			reason,
+			classroom_id,
+			substitute_lecturer_id,
+			rescheduled_from_id,
 			is_delete,
 			created_by,
 			created_at,
@@ -83,6 +143,11 @@ func GetOneClass(ctx context.Context, db *sql.DB, classID uuid.UUID) (ClassModel
 		&class.ID,
 		&class.SessionID,
 		&class.Date,
+		&class.Status,
+		&class.Reason,
+		&class.ClassroomID,
+		&class.SubstituteLecturerID,
+		&class.RescheduledFromID,
 		&class.IsDelete,
 		&class.CreatedBy,
 		&class.CreatedAt,
@@ -98,7 +163,7 @@ func GetOneClass(ctx context.Context, db *sql.DB, classID uuid.UUID) (ClassModel
 
 }
 
-func GetAllClassBySession(ctx context.Context, db *sql.DB, filter helpers.Filter) ([]ClassModel, error) {
+func GetAllClassBySession(ctx context.Context, db helpers.Queryer, filter helpers.Filter) ([]ClassModel, error) {
 
 	var filters []string
 
@@ -116,6 +181,11 @@ func GetAllClassBySession(ctx context.Context, db *sql.DB, filter helpers.Filter
 			id,
 			session_id,
 			date,
+			status,
+			reason,
+			classroom_id,
+			substitute_lecturer_id,
+			rescheduled_from_id,
 			is_delete,
 			created_by,
 			created_at,
@@ -140,6 +210,11 @@ func GetAllClassBySession(ctx context.Context, db *sql.DB, filter helpers.Filter
 			&class.ID,
 			&class.SessionID,
 			&class.Date,
+			&class.Status,
+			&class.Reason,
+			&class.ClassroomID,
+			&class.SubstituteLecturerID,
+			&class.RescheduledFromID,
 			&class.IsDelete,
 			&class.CreatedBy,
 			&class.CreatedAt,
@@ -154,21 +229,51 @@ func GetAllClassBySession(ctx context.Context, db *sql.DB, filter helpers.Filter
 
 }
 
-func (s *ClassModel) Insert(ctx context.Context, db *sql.DB) error {
+func (s *ClassModel) Insert(ctx context.Context, db helpers.Queryer) error {
 
 	query := fmt.Sprintf(`
 		INSERT INTO class(
 			session_id,
 			date,
+			classroom_id,
+			substitute_lecturer_id,
+			rescheduled_from_id,
 			created_by,
 			created_at)
 		VALUES(
-		$1,$2,$3,now())
-		RETURNING id, created_at,is_delete`)
+		$1,$2,$3,$4,$5,$6,now())
+		RETURNING id, status, created_at,is_delete`)
+
+	err := db.QueryRowContext(ctx, query,
+		s.SessionID, s.Date, s.ClassroomID, s.SubstituteLecturerID, s.RescheduledFromID, s.CreatedBy).Scan(
+		&s.ID, &s.Status, &s.CreatedAt, &s.IsDelete,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+
+}
+
+// StatusUpdate cancels the class, either outright or because it was moved to another date. A cancelled class
+// keeps its row, and its date, so only Status tells it apart from a scheduled one.
+func (s *ClassModel) StatusUpdate(ctx context.Context, db helpers.Queryer) error {
+
+	query := fmt.Sprintf(`
+		UPDATE class
+		SET
+			status=$1,
+			reason=$2,
+			updated_by=$3,
+			updated_at=NOW()
+		WHERE id=$4
+		RETURNING updated_at`)
 
 	err := db.QueryRowContext(ctx, query,
-		s.SessionID, s.Date, s.CreatedBy).Scan(
-		&s.ID, &s.CreatedAt, &s.IsDelete,
+		s.Status, s.Reason, s.UpdatedBy, s.ID).Scan(
+		&s.UpdatedAt,
 	)
 
 	if err != nil {
@@ -176,5 +281,230 @@ func (s *ClassModel) Insert(ctx context.Context, db *sql.DB) error {
 	}
 
 	return nil
+}
+
+func (s *ClassModel) SubstituteUpdate(ctx context.Context, db helpers.Queryer) error {
+
+	query := fmt.Sprintf(`
+		UPDATE class
+		SET
+			substitute_lecturer_id=$1,
+			reason=$2,
+			updated_by=$3,
+			updated_at=NOW()
+		WHERE id=$4`)
+
+	_, err := db.ExecContext(ctx, query,
+		s.SubstituteLecturerID, s.Reason, s.UpdatedBy, s.ID)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetAllClassInSlot returns the classes on the date that overlap start and end and were moved to another
+// classroom or handed to a substitute, in the classroom or taught by the lecturer of the filter.
+func GetAllClassInSlot(ctx context.Context, db *sql.DB, filter helpers.Filter, date time.Time,
+	startTime time.Time, endTime time.Time) ([]ClassModel, error) {
+
+	var filters []string
+
+	if filter.ClassID != uuid.Nil {
+		filters = append(filters, fmt.Sprintf(`
+			AND c.id != '%s'`,
+			filter.ClassID))
+	}
+
+	if filter.ClassroomID != uuid.Nil {
+		filters = append(filters, fmt.Sprintf(`
+			AND COALESCE(c.classroom_id, s.classroom_id) = '%s'`,
+			filter.ClassroomID))
+	}
+
+	if filter.LecturerID != uuid.Nil {
+		filters = append(filters, fmt.Sprintf(`
+			AND COALESCE(c.substitute_lecturer_id, s.lecturer_id) = '%s'`,
+			filter.LecturerID))
+	}
+
+	query := fmt.Sprintf(`
+		SELECT
+			c.id,
+			c.session_id,
+			c.date,
+			c.status,
+			c.reason,
+			c.classroom_id,
+			c.substitute_lecturer_id,
+			c.rescheduled_from_id,
+			c.is_delete,
+			c.created_by,
+			c.created_at,
+			c.updated_by,
+			c.updated_at
+		FROM class c
+		INNER JOIN session s ON c.session_id = s.id
+		WHERE c.is_delete = false
+		AND c.status = $4
+		AND s.is_delete = false
+		AND (c.classroom_id IS NOT NULL OR c.substitute_lecturer_id IS NOT NULL OR c.rescheduled_from_id IS NOT NULL)
+		AND c.date = $1::DATE
+		AND s.start_time < $3::TIME
+		AND s.end_time > $2::TIME
+		%s`, strings.Join(filters, ""))
+
+	rows, err := db.QueryContext(ctx, query, date, startTime, endTime, CLASS_SCHEDULED)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var classes []ClassModel
+	for rows.Next() {
+		var class ClassModel
+		rows.Scan(
+			&class.ID,
+			&class.SessionID,
+			&class.Date,
+			&class.Status,
+			&class.Reason,
+			&class.ClassroomID,
+			&class.SubstituteLecturerID,
+			&class.RescheduledFromID,
+			&class.IsDelete,
+			&class.CreatedBy,
+			&class.CreatedAt,
+			&class.UpdatedBy,
+			&class.UpdatedAt,
+		)
+
+		classes = append(classes, class)
+	}
+
+	return classes, nil
+
+}
+
+// GetAllClassBySubstitute returns the classes the lecturer teaches in place of the session's lecturer.
+func GetAllClassBySubstitute(ctx context.Context, db *sql.DB, lecturerID uuid.UUID) ([]ClassModel, error) {
+
+	query := fmt.Sprintf(`
+		SELECT
+			id,
+			session_id,
+			date,
+			status,
+			reason,
+			classroom_id,
+			substitute_lecturer_id,
+			rescheduled_from_id,
+			is_delete,
+			created_by,
+			created_at,
+			updated_by,
+			updated_at
+		FROM class
+		WHERE is_delete = false
+		AND status = $2
+		AND substitute_lecturer_id = $1
+		ORDER BY date ASC`)
+
+	rows, err := db.QueryContext(ctx, query, lecturerID, CLASS_SCHEDULED)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var classes []ClassModel
+	for rows.Next() {
+		var class ClassModel
+		rows.Scan(
+			&class.ID,
+			&class.SessionID,
+			&class.Date,
+			&class.Status,
+			&class.Reason,
+			&class.ClassroomID,
+			&class.SubstituteLecturerID,
+			&class.RescheduledFromID,
+			&class.IsDelete,
+			&class.CreatedBy,
+			&class.CreatedAt,
+			&class.UpdatedBy,
+			&class.UpdatedAt,
+		)
+
+		classes = append(classes, class)
+	}
+
+	return classes, nil
+
+}
+
+// GetAllClassMovedByIntake returns the classes of the intake's sessions that were moved to another date or
+// classroom or handed to a substitute.
+func GetAllClassMovedByIntake(ctx context.Context, db *sql.DB, intakeID uuid.UUID) ([]ClassModel, error) {
+
+	query := fmt.Sprintf(`
+		SELECT
+			c.id,
+			c.session_id,
+			c.date,
+			c.status,
+			c.reason,
+			c.classroom_id,
+			c.substitute_lecturer_id,
+			c.rescheduled_from_id,
+			c.is_delete,
+			c.created_by,
+			c.created_at,
+			c.updated_by,
+			c.updated_at
+		FROM class c
+		INNER JOIN session s ON c.session_id = s.id
+		WHERE c.is_delete = false
+		AND c.status = $2
+		AND s.is_delete = false
+		AND (c.classroom_id IS NOT NULL OR c.substitute_lecturer_id IS NOT NULL OR c.rescheduled_from_id IS NOT NULL)
+		AND s.intake_id = $1
+		ORDER BY c.date ASC`)
+
+	rows, err := db.QueryContext(ctx, query, intakeID, CLASS_SCHEDULED)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var classes []ClassModel
+	for rows.Next() {
+		var class ClassModel
+		rows.Scan(
+			&class.ID,
+			&class.SessionID,
+			&class.Date,
+			&class.Status,
+			&class.Reason,
+			&class.ClassroomID,
+			&class.SubstituteLecturerID,
+			&class.RescheduledFromID,
+			&class.IsDelete,
+			&class.CreatedBy,
+			&class.CreatedAt,
+			&class.UpdatedBy,
+			&class.UpdatedAt,
+		)
+
+		classes = append(classes, class)
+	}
+
+	return classes, nil
 
 }
@@ -0,0 +1,273 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/lib/pq"
+	uuid "github.com/satori/go.uuid"
+	"school/helpers"
+	"strings"
+	"time"
+)
+
+type (
+	ClassroomBookingModel struct {
+		ID          uuid.UUID
+		ClassroomID uuid.UUID
+		Title       string
+		Date        time.Time
+		StartTime   time.Time
+		EndTime     time.Time
+		IsDelete    bool
+		CreatedBy   uuid.UUID
+		CreatedAt   time.Time
+		UpdatedBy   uuid.NullUUID
+		UpdatedAt   pq.NullTime
+	}
+
+	ClassroomBookingResponse struct {
+		ID          uuid.UUID `json:"id"`
+		ClassroomID uuid.UUID `json:"classroom_id"`
+		Title       string    `json:"title"`
+		Date        time.Time `json:"date"`
+		StartTime   time.Time `json:"start_time"`
+		EndTime     time.Time `json:"end_time"`
+		IsDelete    bool      `json:"is_delete"`
+		CreatedBy   uuid.UUID `json:"created_by"`
+		CreatedAt   time.Time `json:"created_at"`
+		UpdatedBy   uuid.UUID `json:"updated_by"`
+		UpdatedAt   time.Time `json:"updated_at"`
+	}
+)
+
+func (s ClassroomBookingModel) Response() ClassroomBookingResponse {
+	return ClassroomBookingResponse{
+		ID:          s.ID,
+		ClassroomID: s.ClassroomID,
+		Title:       s.Title,
+		Date:        s.Date,
+		StartTime:   s.StartTime,
+		EndTime:     s.EndTime,
+		IsDelete:    s.IsDelete,
+		CreatedBy:   s.CreatedBy,
+		CreatedAt:   s.CreatedAt,
+		UpdatedBy:   s.UpdatedBy.UUID,
+		UpdatedAt:   s.UpdatedAt.Time,
+	}
+}
+
+func GetOneClassroomBooking(ctx context.Context, db *sql.DB, bookingID uuid.UUID) (ClassroomBookingModel, error) {
+
+	query := fmt.Sprintf(`
+		SELECT
+			id,
+			classroom_id,
+			title,
+			date,
+			start_time,
+			end_time,
+			is_delete,
+			created_by,
+			created_at,
+			updated_by,
+			updated_at
+		FROM classroom_booking
+		WHERE 
+			id = $1
+	`)
+
+	var booking ClassroomBookingModel
+	err := db.QueryRowContext(ctx, query, bookingID).Scan(
+		&booking.ID,
+		&booking.ClassroomID,
+		&booking.Title,
+		&booking.Date,
+		&booking.StartTime,
+		&booking.EndTime,
+		&booking.IsDelete,
+		&booking.CreatedBy,
+		&booking.CreatedAt,
+		&booking.UpdatedBy,
+		&booking.UpdatedAt,
+	)
+
+	if err != nil {
+		return ClassroomBookingModel{}, err
+	}
+
+	return booking, nil
+
+}
+
+// GetAllClassroomBookingBetween returns the bookings dated from and to inclusive, of one classroom when
+// classroomID is set.
+func GetAllClassroomBookingBetween(ctx context.Context, db *sql.DB, classroomID uuid.UUID, from time.Time,
+	to time.Time) ([]ClassroomBookingModel, error) {
+
+	filters := []string{
+		`is_delete = false`,
+		`date BETWEEN $1::DATE AND $2::DATE`,
+	}
+
+	if classroomID != uuid.Nil {
+		filters = append(filters, fmt.Sprintf(`classroom_id = '%s'`, classroomID))
+	}
+
+	query := fmt.Sprintf(`
+		SELECT
+			id,
+			classroom_id,
+			title,
+			date,
+			start_time,
+			end_time,
+			is_delete,
+			created_by,
+			created_at,
+			updated_by,
+			updated_at
+		FROM classroom_booking
+		WHERE %s
+		ORDER BY date ASC, start_time ASC`, strings.Join(filters, " AND "))
+
+	rows, err := db.QueryContext(ctx, query, from, to)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var bookings []ClassroomBookingModel
+	for rows.Next() {
+		var booking ClassroomBookingModel
+
+		rows.Scan(
+			&booking.ID,
+			&booking.ClassroomID,
+			&booking.Title,
+			&booking.Date,
+			&booking.StartTime,
+			&booking.EndTime,
+			&booking.IsDelete,
+			&booking.CreatedBy,
+			&booking.CreatedAt,
+			&booking.UpdatedBy,
+			&booking.UpdatedAt,
+		)
+
+		bookings = append(bookings, booking)
+	}
+
+	return bookings, nil
+
+}
+
+// GetAllClassroomBookingConflict returns the other bookings of the same classroom and date whose time
+// overlaps the booking.
+func GetAllClassroomBookingConflict(ctx context.Context, db *sql.DB, booking ClassroomBookingModel) (
+	[]ClassroomBookingModel, error) {
+
+	query := fmt.Sprintf(`
+		SELECT
+			id,
+			classroom_id,
+			title,
+			date,
+			start_time,
+			end_time,
+			is_delete,
+			created_by,
+			created_at,
+			updated_by,
+			updated_at
+		FROM classroom_booking
+		WHERE is_delete = false
+		AND id != $1
+		AND classroom_id = $2
+		AND date = $3::DATE
+		AND start_time < $5::TIME
+		AND end_time > $4::TIME`)
+
+	rows, err := db.QueryContext(ctx, query, booking.ID, booking.ClassroomID, booking.Date, booking.StartTime,
+		booking.EndTime)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var bookings []ClassroomBookingModel
+	for rows.Next() {
+		var conflict ClassroomBookingModel
+
+		rows.Scan(
+			&conflict.ID,
+			&conflict.ClassroomID,
+			&conflict.Title,
+			&conflict.Date,
+			&conflict.StartTime,
+			&conflict.EndTime,
+			&conflict.IsDelete,
+			&conflict.CreatedBy,
+			&conflict.CreatedAt,
+			&conflict.UpdatedBy,
+			&conflict.UpdatedAt,
+		)
+
+		bookings = append(bookings, conflict)
+	}
+
+	return bookings, nil
+
+}
+
+func (s *ClassroomBookingModel) Insert(ctx context.Context, db helpers.Queryer) error {
+
+	query := fmt.Sprintf(`
+		INSERT INTO classroom_booking(
+			classroom_id,
+			title,
+			date,
+			start_time,
+			end_time,
+			created_by,
+			created_at)
+		VALUES(
+		$1,$2,$3,$4,$5,$6,now())
+		RETURNING id, created_at,is_delete`)
+
+	err := db.QueryRowContext(ctx, query,
+		s.ClassroomID, s.Title, s.Date, s.StartTime, s.EndTime, s.CreatedBy).Scan(
+		&s.ID, &s.CreatedAt, &s.IsDelete,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+
+}
+
+func (s *ClassroomBookingModel) Delete(ctx context.Context, db *sql.DB) error {
+
+	query := fmt.Sprintf(`
+		UPDATE classroom_booking
+		SET
+			is_delete=true,
+			updated_by=$1,
+			updated_at=NOW()
+		WHERE id=$2`)
+
+	_, err := db.ExecContext(ctx, query,
+		s.UpdatedBy, s.ID)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
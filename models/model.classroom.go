@@ -17,6 +17,8 @@ type (
 		Floor     int
 		RoomNo    int
 		Code      string
+		Capacity  int
+		Features  []string
 		IsDelete  bool
 		CreatedBy uuid.UUID
 		CreatedAt time.Time
@@ -30,6 +32,8 @@ type (
 		Floor     int             `json:"floor"`
 		RoomNo    int             `json:"room_no"`
 		Code      string          `json:"code"`
+		Capacity  int             `json:"capacity"`
+		Features  []string        `json:"features"`
 		IsDelete  bool            `json:"is_delete"`
 		CreatedBy uuid.UUID       `json:"created_by"`
 		CreatedAt time.Time       `json:"created_at"`
@@ -52,6 +56,8 @@ func (s ClassRoomModel) Response(ctx context.Context, db *sql.DB, logger *helper
 		Floor:     s.Floor,
 		RoomNo:    s.RoomNo,
 		Code:      s.Code,
+		Capacity:  s.Capacity,
+		Features:  s.Features,
 		IsDelete:  s.IsDelete,
 		CreatedBy: s.CreatedBy,
 		CreatedAt: s.CreatedAt,
@@ -69,6 +75,8 @@ func GetOneClassroom(ctx context.Context, db *sql.DB, classroomID uuid.UUID) (Cl
 			floor,
 			room_no,
 			code,
+			capacity,
+			features,
 			is_delete,
 			created_by,
 			created_at,
@@ -86,6 +94,8 @@ func GetOneClassroom(ctx context.Context, db *sql.DB, classroomID uuid.UUID) (Cl
 		&classroom.Floor,
 		&classroom.RoomNo,
 		&classroom.Code,
+		&classroom.Capacity,
+		pq.Array(&classroom.Features),
 		&classroom.IsDelete,
 		&classroom.CreatedBy,
 		&classroom.CreatedAt,
@@ -116,6 +126,8 @@ func GetAllClassroom(ctx context.Context, db *sql.DB, filter helpers.Filter) ([]
 			floor,
 			room_no,
 			code,
+			capacity,
+			features,
 			is_delete,
 			created_by,
 			created_at,
@@ -145,6 +157,8 @@ func GetAllClassroom(ctx context.Context, db *sql.DB, filter helpers.Filter) ([]
 			&classroom.Floor,
 			&classroom.RoomNo,
 			&classroom.Code,
+			&classroom.Capacity,
+			pq.Array(&classroom.Features),
 			&classroom.IsDelete,
 			&classroom.CreatedBy,
 			&classroom.CreatedAt,
@@ -167,14 +181,16 @@ func (s *ClassRoomModel) Insert(ctx context.Context, db *sql.DB) error {
 			floor,
 			room_no,
 			code,
+			capacity,
+			features,
 			created_by,
 			created_at)
 		VALUES(
-		$1,$2,$3,$4,$5,now())
+		$1,$2,$3,$4,$5,$6,$7,now())
 		RETURNING id, created_at,is_delete`)
 
 	err := db.QueryRowContext(ctx, query,
-		s.FacultyID, s.Floor, s.RoomNo, s.Code, s.CreatedBy).Scan(
+		s.FacultyID, s.Floor, s.RoomNo, s.Code, s.Capacity, pq.Array(s.Features), s.CreatedBy).Scan(
 		&s.ID, &s.CreatedAt, &s.IsDelete,
 	)
 
@@ -195,13 +211,15 @@ func (s *ClassRoomModel) Update(ctx context.Context, db *sql.DB) error {
 			floor=$2,
 			room_no=$3,
 			code=$4,
+			capacity=$5,
+			features=$6,
 			updated_at=NOW(),
-			updated_by=$5
-		WHERE id=$6
+			updated_by=$7
+		WHERE id=$8
 		RETURNING id,created_at,updated_at,created_by,is_delete`)
 
 	err := db.QueryRowContext(ctx, query,
-		s.FacultyID, s.Floor, s.RoomNo, s.Code, s.UpdatedBy, s.ID).Scan(
+		s.FacultyID, s.Floor, s.RoomNo, s.Code, s.Capacity, pq.Array(s.Features), s.UpdatedBy, s.ID).Scan(
 		&s.ID, &s.CreatedAt, &s.UpdatedAt, &s.CreatedBy, &s.IsDelete,
 	)
 
@@ -0,0 +1,244 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/lib/pq"
+	uuid "github.com/satori/go.uuid"
+	"school/helpers"
+	"time"
+)
+
+type (
+	CurriculumModel struct {
+		ID          uuid.UUID
+		ProgramID   uuid.UUID
+		SubjectID   uuid.UUID
+		Year        int
+		Trimester   int
+		IsElective  bool
+		CreditHours int
+		IsDelete    bool
+		CreatedBy   uuid.UUID
+		CreatedAt   time.Time
+		UpdatedBy   uuid.NullUUID
+		UpdatedAt   pq.NullTime
+	}
+
+	CurriculumResponse struct {
+		ID          uuid.UUID       `json:"id"`
+		ProgramID   uuid.UUID       `json:"program_id"`
+		Subject     SubjectResponse `json:"subject"`
+		Year        int             `json:"year"`
+		Trimester   int             `json:"trimester"`
+		IsElective  bool            `json:"is_elective"`
+		CreditHours int             `json:"credit_hours"`
+		IsDelete    bool            `json:"is_delete"`
+		CreatedBy   uuid.UUID       `json:"created_by"`
+		CreatedAt   time.Time       `json:"created_at"`
+		UpdatedBy   uuid.UUID       `json:"updated_by"`
+		UpdatedAt   time.Time       `json:"updated_at"`
+	}
+)
+
+func (s CurriculumModel) Response(ctx context.Context, db *sql.DB, logger *helpers.Logger) (
+	CurriculumResponse, error) {
+
+	subject, err := GetOneSubject(ctx, db, s.SubjectID)
+	if err != nil {
+		logger.Err.Printf(`model.curriculum.go/GetOneSubject/%v`, err)
+		return CurriculumResponse{}, err
+	}
+
+	return CurriculumResponse{
+		ID:          s.ID,
+		ProgramID:   s.ProgramID,
+		Subject:     subject.Response(),
+		Year:        s.Year,
+		Trimester:   s.Trimester,
+		IsElective:  s.IsElective,
+		CreditHours: s.CreditHours,
+		IsDelete:    s.IsDelete,
+		CreatedBy:   s.CreatedBy,
+		CreatedAt:   s.CreatedAt,
+		UpdatedBy:   s.UpdatedBy.UUID,
+		UpdatedAt:   s.UpdatedAt.Time,
+	}, nil
+}
+
+func GetOneCurriculum(ctx context.Context, db *sql.DB, curriculumID uuid.UUID) (CurriculumModel, error) {
+
+	query := fmt.Sprintf(`
+		SELECT
+			id,
+			program_id,
+			subject_id,
+			year,
+			trimester,
+			is_elective,
+			credit_hours,
+			is_delete,
+			created_by,
+			created_at,
+			updated_by,
+			updated_at
+		FROM curriculum
+		WHERE is_delete = false
+		AND id = $1`)
+
+	var curriculum CurriculumModel
+	err := db.QueryRowContext(ctx, query, curriculumID).Scan(
+		&curriculum.ID,
+		&curriculum.ProgramID,
+		&curriculum.SubjectID,
+		&curriculum.Year,
+		&curriculum.Trimester,
+		&curriculum.IsElective,
+		&curriculum.CreditHours,
+		&curriculum.IsDelete,
+		&curriculum.CreatedBy,
+		&curriculum.CreatedAt,
+		&curriculum.UpdatedBy,
+		&curriculum.UpdatedAt,
+	)
+
+	if err != nil {
+		return CurriculumModel{}, err
+	}
+
+	return curriculum, nil
+
+}
+
+// GetAllCurriculumByProgram returns the program's curriculum in degree plan order.
+func GetAllCurriculumByProgram(ctx context.Context, db *sql.DB, programID uuid.UUID) ([]CurriculumModel, error) {
+
+	query := fmt.Sprintf(`
+		SELECT
+			id,
+			program_id,
+			subject_id,
+			year,
+			trimester,
+			is_elective,
+			credit_hours,
+			is_delete,
+			created_by,
+			created_at,
+			updated_by,
+			updated_at
+		FROM curriculum
+		WHERE is_delete = false
+		AND program_id = $1
+		ORDER BY year ASC, trimester ASC, is_elective ASC`)
+
+	rows, err := db.QueryContext(ctx, query, programID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var curriculums []CurriculumModel
+	for rows.Next() {
+		var curriculum CurriculumModel
+
+		rows.Scan(
+			&curriculum.ID,
+			&curriculum.ProgramID,
+			&curriculum.SubjectID,
+			&curriculum.Year,
+			&curriculum.Trimester,
+			&curriculum.IsElective,
+			&curriculum.CreditHours,
+			&curriculum.IsDelete,
+			&curriculum.CreatedBy,
+			&curriculum.CreatedAt,
+			&curriculum.UpdatedBy,
+			&curriculum.UpdatedAt,
+		)
+
+		curriculums = append(curriculums, curriculum)
+	}
+
+	return curriculums, nil
+
+}
+
+func (s *CurriculumModel) Insert(ctx context.Context, db *sql.DB) error {
+
+	query := fmt.Sprintf(`
+		INSERT INTO curriculum(
+			program_id,
+			subject_id,
+			year,
+			trimester,
+			is_elective,
+			credit_hours,
+			created_by,
+			created_at)
+		VALUES(
+		$1,$2,$3,$4,$5,$6,$7,now())
+		RETURNING id, created_at,is_delete`)
+
+	err := db.QueryRowContext(ctx, query,
+		s.ProgramID, s.SubjectID, s.Year, s.Trimester, s.IsElective, s.CreditHours, s.CreatedBy).Scan(
+		&s.ID, &s.CreatedAt, &s.IsDelete,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+
+}
+
+func (s *CurriculumModel) Update(ctx context.Context, db *sql.DB) error {
+
+	query := fmt.Sprintf(`
+		UPDATE curriculum
+		SET
+			year=$1,
+			trimester=$2,
+			is_elective=$3,
+			credit_hours=$4,
+			updated_at=NOW(),
+			updated_by=$5
+		WHERE id=$6
+		RETURNING id,program_id,subject_id,created_at,updated_at,created_by,is_delete`)
+
+	err := db.QueryRowContext(ctx, query,
+		s.Year, s.Trimester, s.IsElective, s.CreditHours, s.UpdatedBy, s.ID).Scan(
+		&s.ID, &s.ProgramID, &s.SubjectID, &s.CreatedAt, &s.UpdatedAt, &s.CreatedBy, &s.IsDelete,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+
+}
+
+func (s *CurriculumModel) Delete(ctx context.Context, db *sql.DB) error {
+
+	query := fmt.Sprintf(`
+		UPDATE curriculum
+		SET
+			is_delete=true,
+			updated_by=$1,
+			updated_at=NOW()
+		WHERE id=$2`)
+
+	_, err := db.ExecContext(ctx, query,
+		s.UpdatedBy, s.ID)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,175 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"github.com/lib/pq"
+	uuid "github.com/satori/go.uuid"
+	"school/helpers"
+	"time"
+)
+
+type (
+	GradingBandModel struct {
+		ID              uuid.UUID
+		GradingSchemeID uuid.UUID
+		Grade           string
+		MinMarks        int
+		GradePoint      float64
+		IsDelete        bool
+		CreatedBy       uuid.UUID
+		CreatedAt       time.Time
+		UpdatedBy       uuid.NullUUID
+		UpdatedAt       pq.NullTime
+	}
+
+	GradingBandResponse struct {
+		ID         uuid.UUID `json:"id"`
+		Grade      string    `json:"grade"`
+		MinMarks   int       `json:"min_marks"`
+		GradePoint float64   `json:"grade_point"`
+	}
+)
+
+func (s GradingBandModel) Response() GradingBandResponse {
+	return GradingBandResponse{
+		ID:         s.ID,
+		Grade:      s.Grade,
+		MinMarks:   s.MinMarks,
+		GradePoint: s.GradePoint,
+	}
+}
+
+// GetGradingBand returns the band the marks fall into. Bands must be ordered by min_marks descending.
+func GetGradingBand(bands []GradingBandModel, marks int) (GradingBandModel, bool) {
+
+	for _, band := range bands {
+		if marks >= band.MinMarks {
+			return band, true
+		}
+	}
+
+	return GradingBandModel{}, false
+}
+
+func GetAllGradingBandByScheme(ctx context.Context, db helpers.Queryer, gradingSchemeID uuid.UUID) (
+	[]GradingBandModel, error) {
+
+	query := fmt.Sprintf(`
+		SELECT
+			id,
+			grading_scheme_id,
+			grade,
+			min_marks,
+			grade_point,
+			is_delete,
+			created_by,
+			created_at,
+			updated_by,
+			updated_at
+		FROM grading_band
+		WHERE is_delete = false
+		AND grading_scheme_id = $1
+		ORDER BY min_marks DESC`)
+
+	rows, err := db.QueryContext(ctx, query, gradingSchemeID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var bands []GradingBandModel
+	for rows.Next() {
+		var band GradingBandModel
+
+		rows.Scan(
+			&band.ID,
+			&band.GradingSchemeID,
+			&band.Grade,
+			&band.MinMarks,
+			&band.GradePoint,
+			&band.IsDelete,
+			&band.CreatedBy,
+			&band.CreatedAt,
+			&band.UpdatedBy,
+			&band.UpdatedAt,
+		)
+
+		bands = append(bands, band)
+	}
+
+	return bands, nil
+
+}
+
+func GetCountGradingBandByGrade(ctx context.Context, db helpers.Queryer, grade string) (int, error) {
+
+	query := fmt.Sprintf(`
+		SELECT
+			COUNT(gb.id)
+		FROM grading_band gb
+		INNER JOIN grading_scheme gs ON gb.grading_scheme_id = gs.id
+		WHERE gb.is_delete = false
+		AND gs.is_delete = false
+		AND gb.grade = $1`)
+
+	var count int
+	err := db.QueryRowContext(ctx, query, grade).Scan(&count)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+
+}
+
+func (s *GradingBandModel) Insert(ctx context.Context, db helpers.Queryer) error {
+
+	query := fmt.Sprintf(`
+		INSERT INTO grading_band(
+			grading_scheme_id,
+			grade,
+			min_marks,
+			grade_point,
+			created_by,
+			created_at)
+		VALUES(
+		$1,$2,$3,$4,$5,now())
+		RETURNING id, created_at,is_delete`)
+
+	err := db.QueryRowContext(ctx, query,
+		s.GradingSchemeID, s.Grade, s.MinMarks, s.GradePoint, s.CreatedBy).Scan(
+		&s.ID, &s.CreatedAt, &s.IsDelete,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+
+}
+
+func DeleteGradingBandByScheme(ctx context.Context, db helpers.Queryer, gradingSchemeID uuid.UUID,
+	updatedBy uuid.NullUUID) error {
+
+	query := fmt.Sprintf(`
+		UPDATE grading_band
+		SET
+			is_delete=true,
+			updated_by=$1,
+			updated_at=NOW()
+		WHERE is_delete = false
+		AND grading_scheme_id=$2`)
+
+	_, err := db.ExecContext(ctx, query, updatedBy, gradingSchemeID)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
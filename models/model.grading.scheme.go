@@ -0,0 +1,321 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/lib/pq"
+	uuid "github.com/satori/go.uuid"
+	"school/helpers"
+	"time"
+)
+
+type (
+	GradingSchemeModel struct {
+		ID          uuid.UUID
+		Name        string
+		Description string
+		PassMark    int
+		IsPassFail  bool
+		IsDefault   bool
+		IsDelete    bool
+		CreatedBy   uuid.UUID
+		CreatedAt   time.Time
+		UpdatedBy   uuid.NullUUID
+		UpdatedAt   pq.NullTime
+	}
+
+	GradingSchemeResponse struct {
+		ID          uuid.UUID             `json:"id"`
+		Name        string                `json:"name"`
+		Description string                `json:"description"`
+		PassMark    int                   `json:"pass_mark"`
+		IsPassFail  bool                  `json:"is_pass_fail"`
+		IsDefault   bool                  `json:"is_default"`
+		Bands       []GradingBandResponse `json:"bands"`
+		IsDelete    bool                  `json:"is_delete"`
+		CreatedBy   uuid.UUID             `json:"created_by"`
+		CreatedAt   time.Time             `json:"created_at"`
+		UpdatedBy   uuid.UUID             `json:"updated_by"`
+		UpdatedAt   time.Time             `json:"updated_at"`
+	}
+)
+
+func (s GradingSchemeModel) Response(ctx context.Context, db *sql.DB, logger *helpers.Logger) (
+	GradingSchemeResponse, error) {
+
+	bands, err := GetAllGradingBandByScheme(ctx, db, s.ID)
+	if err != nil {
+		logger.Err.Printf(`model.grading.scheme.go/GetAllGradingBandByScheme/%v`, err)
+		return GradingSchemeResponse{}, err
+	}
+
+	var bandsResponse []GradingBandResponse
+	for _, band := range bands {
+		bandsResponse = append(bandsResponse, band.Response())
+	}
+
+	return GradingSchemeResponse{
+		ID:          s.ID,
+		Name:        s.Name,
+		Description: s.Description,
+		PassMark:    s.PassMark,
+		IsPassFail:  s.IsPassFail,
+		IsDefault:   s.IsDefault,
+		Bands:       bandsResponse,
+		IsDelete:    s.IsDelete,
+		CreatedBy:   s.CreatedBy,
+		CreatedAt:   s.CreatedAt,
+		UpdatedBy:   s.UpdatedBy.UUID,
+		UpdatedAt:   s.UpdatedAt.Time,
+	}, nil
+}
+
+func GetOneGradingScheme(ctx context.Context, db *sql.DB, gradingSchemeID uuid.UUID) (GradingSchemeModel, error) {
+
+	query := fmt.Sprintf(`
+		SELECT
+			id,
+			name,
+			description,
+			pass_mark,
+			is_pass_fail,
+			is_default,
+			is_delete,
+			created_by,
+			created_at,
+			updated_by,
+			updated_at
+		FROM grading_scheme
+		WHERE is_delete = false
+		AND id = $1`)
+
+	var gradingScheme GradingSchemeModel
+	err := db.QueryRowContext(ctx, query, gradingSchemeID).Scan(
+		&gradingScheme.ID,
+		&gradingScheme.Name,
+		&gradingScheme.Description,
+		&gradingScheme.PassMark,
+		&gradingScheme.IsPassFail,
+		&gradingScheme.IsDefault,
+		&gradingScheme.IsDelete,
+		&gradingScheme.CreatedBy,
+		&gradingScheme.CreatedAt,
+		&gradingScheme.UpdatedBy,
+		&gradingScheme.UpdatedAt,
+	)
+
+	if err != nil {
+		return GradingSchemeModel{}, err
+	}
+
+	return gradingScheme, nil
+
+}
+
+// GetGradingSchemeBySession resolves the scheme that grades a session: the subject's scheme first,
+// then the program's, then the default scheme. sql.ErrNoRows means no scheme applies.
+func GetGradingSchemeBySession(ctx context.Context, db *sql.DB, sessionID uuid.UUID) (GradingSchemeModel, error) {
+
+	query := fmt.Sprintf(`
+		SELECT
+			gs.id,
+			gs.name,
+			gs.description,
+			gs.pass_mark,
+			gs.is_pass_fail,
+			gs.is_default,
+			gs.is_delete,
+			gs.created_by,
+			gs.created_at,
+			gs.updated_by,
+			gs.updated_at
+		FROM session s
+		INNER JOIN subject su ON s.subject_id = su.id
+		INNER JOIN program p ON s.program_id = p.id
+		LEFT JOIN grading_scheme sgs ON su.grading_scheme_id = sgs.id AND sgs.is_delete = false
+		LEFT JOIN grading_scheme pgs ON p.grading_scheme_id = pgs.id AND pgs.is_delete = false
+		INNER JOIN grading_scheme gs ON gs.id = COALESCE(sgs.id, pgs.id, (
+			SELECT id FROM grading_scheme WHERE is_default = true AND is_delete = false LIMIT 1))
+		WHERE s.id = $1`)
+
+	var gradingScheme GradingSchemeModel
+	err := db.QueryRowContext(ctx, query, sessionID).Scan(
+		&gradingScheme.ID,
+		&gradingScheme.Name,
+		&gradingScheme.Description,
+		&gradingScheme.PassMark,
+		&gradingScheme.IsPassFail,
+		&gradingScheme.IsDefault,
+		&gradingScheme.IsDelete,
+		&gradingScheme.CreatedBy,
+		&gradingScheme.CreatedAt,
+		&gradingScheme.UpdatedBy,
+		&gradingScheme.UpdatedAt,
+	)
+
+	if err != nil {
+		return GradingSchemeModel{}, err
+	}
+
+	return gradingScheme, nil
+
+}
+
+func GetAllGradingScheme(ctx context.Context, db *sql.DB, filter helpers.Filter) ([]GradingSchemeModel, error) {
+
+	var searchQuery string
+	args := []interface{}{filter.Limit, filter.Offset}
+
+	if filter.Search != "" {
+		args = append(args, "%"+filter.Search+"%")
+		searchQuery = fmt.Sprintf(`AND LOWER(name) LIKE LOWER($%d)`, len(args))
+	}
+
+	query := fmt.Sprintf(`
+		SELECT
+			id,
+			name,
+			description,
+			pass_mark,
+			is_pass_fail,
+			is_default,
+			is_delete,
+			created_by,
+			created_at,
+			updated_by,
+			updated_at
+		FROM grading_scheme
+		WHERE is_delete = false
+		%s
+		ORDER BY name %s
+		LIMIT $1 OFFSET $2`, searchQuery, filter.Dir)
+
+	rows, err := db.QueryContext(ctx, query, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var gradingSchemes []GradingSchemeModel
+	for rows.Next() {
+		var gradingScheme GradingSchemeModel
+
+		rows.Scan(
+			&gradingScheme.ID,
+			&gradingScheme.Name,
+			&gradingScheme.Description,
+			&gradingScheme.PassMark,
+			&gradingScheme.IsPassFail,
+			&gradingScheme.IsDefault,
+			&gradingScheme.IsDelete,
+			&gradingScheme.CreatedBy,
+			&gradingScheme.CreatedAt,
+			&gradingScheme.UpdatedBy,
+			&gradingScheme.UpdatedAt,
+		)
+
+		gradingSchemes = append(gradingSchemes, gradingScheme)
+	}
+
+	return gradingSchemes, nil
+
+}
+
+func (s *GradingSchemeModel) Insert(ctx context.Context, db helpers.Queryer) error {
+
+	query := fmt.Sprintf(`
+		INSERT INTO grading_scheme(
+			name,
+			description,
+			pass_mark,
+			is_pass_fail,
+			is_default,
+			created_by,
+			created_at)
+		VALUES(
+		$1,$2,$3,$4,$5,$6,now())
+		RETURNING id, created_at,is_delete`)
+
+	err := db.QueryRowContext(ctx, query,
+		s.Name, s.Description, s.PassMark, s.IsPassFail, s.IsDefault, s.CreatedBy).Scan(
+		&s.ID, &s.CreatedAt, &s.IsDelete,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+
+}
+
+func (s *GradingSchemeModel) Update(ctx context.Context, db helpers.Queryer) error {
+
+	query := fmt.Sprintf(`
+		UPDATE grading_scheme
+		SET
+			name=$1,
+			description=$2,
+			pass_mark=$3,
+			is_pass_fail=$4,
+			is_default=$5,
+			updated_at=NOW(),
+			updated_by=$6
+		WHERE id=$7
+		RETURNING id,created_at,updated_at,created_by,is_delete`)
+
+	err := db.QueryRowContext(ctx, query,
+		s.Name, s.Description, s.PassMark, s.IsPassFail, s.IsDefault, s.UpdatedBy, s.ID).Scan(
+		&s.ID, &s.CreatedAt, &s.UpdatedAt, &s.CreatedBy, &s.IsDelete,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+
+}
+
+func (s *GradingSchemeModel) Delete(ctx context.Context, db *sql.DB) error {
+
+	query := fmt.Sprintf(`
+		UPDATE grading_scheme
+		SET
+			is_delete=true,
+			is_default=false,
+			updated_by=$1,
+			updated_at=NOW()
+		WHERE id=$2`)
+
+	_, err := db.ExecContext(ctx, query,
+		s.UpdatedBy, s.ID)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ClearDefault unsets the default flag on every other scheme so only one default exists.
+func (s *GradingSchemeModel) ClearDefault(ctx context.Context, db helpers.Queryer) error {
+
+	query := fmt.Sprintf(`
+		UPDATE grading_scheme
+		SET
+			is_default=false
+		WHERE is_default = true
+		AND id != $1`)
+
+	_, err := db.ExecContext(ctx, query, s.ID)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
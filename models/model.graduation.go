@@ -0,0 +1,273 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/lib/pq"
+	uuid "github.com/satori/go.uuid"
+	"school/helpers"
+	"strings"
+	"time"
+)
+
+type (
+	GraduationModel struct {
+		ID             uuid.UUID
+		StudentID      uuid.UUID
+		IntakeID       uuid.UUID
+		CGPA           float64
+		CreditHours    int
+		Classification string
+		GraduatedAt    time.Time
+		IsDelete       bool
+		CreatedBy      uuid.UUID
+		CreatedAt      time.Time
+		UpdatedBy      uuid.NullUUID
+		UpdatedAt      pq.NullTime
+	}
+
+	GraduationResponse struct {
+		ID             uuid.UUID       `json:"id"`
+		Student        StudentResponse `json:"student"`
+		IntakeID       uuid.UUID       `json:"intake_id"`
+		CGPA           float64         `json:"cgpa"`
+		CreditHours    int             `json:"credit_hours"`
+		Classification string          `json:"classification"`
+		GraduatedAt    time.Time       `json:"graduated_at"`
+		IsDelete       bool            `json:"is_delete"`
+		CreatedBy      uuid.UUID       `json:"created_by"`
+		CreatedAt      time.Time       `json:"created_at"`
+		UpdatedBy      uuid.UUID       `json:"updated_by"`
+		UpdatedAt      time.Time       `json:"updated_at"`
+	}
+)
+
+func (s GraduationModel) Response(ctx context.Context, db *sql.DB, logger *helpers.Logger) (
+	GraduationResponse, error) {
+
+	student, err := GetOneStudent(ctx, db, s.StudentID)
+	if err != nil {
+		logger.Err.Printf(`model.graduation.go/GetOneStudent/%v`, err)
+		return GraduationResponse{}, err
+	}
+
+	studentResponse, err := student.Response(ctx, db, logger)
+	if err != nil {
+		logger.Err.Printf(`model.graduation.go/StudentResponse/%v`, err)
+		return GraduationResponse{}, err
+	}
+
+	return GraduationResponse{
+		ID:             s.ID,
+		Student:        studentResponse,
+		IntakeID:       s.IntakeID,
+		CGPA:           s.CGPA,
+		CreditHours:    s.CreditHours,
+		Classification: s.Classification,
+		GraduatedAt:    s.GraduatedAt,
+		IsDelete:       s.IsDelete,
+		CreatedBy:      s.CreatedBy,
+		CreatedAt:      s.CreatedAt,
+		UpdatedBy:      s.UpdatedBy.UUID,
+		UpdatedAt:      s.UpdatedAt.Time,
+	}, nil
+}
+
+func GetOneGraduationByStudent(ctx context.Context, db helpers.Queryer, studentID uuid.UUID) (
+	GraduationModel, error) {
+
+	query := fmt.Sprintf(`
+		SELECT
+			id,
+			student_id,
+			intake_id,
+			cgpa,
+			credit_hours,
+			classification,
+			graduated_at,
+			is_delete,
+			created_by,
+			created_at,
+			updated_by,
+			updated_at
+		FROM graduation
+		WHERE is_delete = false
+		AND student_id = $1`)
+
+	var graduation GraduationModel
+	err := db.QueryRowContext(ctx, query, studentID).Scan(
+		&graduation.ID,
+		&graduation.StudentID,
+		&graduation.IntakeID,
+		&graduation.CGPA,
+		&graduation.CreditHours,
+		&graduation.Classification,
+		&graduation.GraduatedAt,
+		&graduation.IsDelete,
+		&graduation.CreatedBy,
+		&graduation.CreatedAt,
+		&graduation.UpdatedBy,
+		&graduation.UpdatedAt,
+	)
+
+	if err != nil {
+		return GraduationModel{}, err
+	}
+
+	return graduation, nil
+
+}
+
+func GetAllGraduation(ctx context.Context, db *sql.DB, filter helpers.Filter) ([]GraduationModel, error) {
+
+	var filters []string
+
+	if filter.IntakeID != uuid.Nil {
+		filters = append(filters, fmt.Sprintf(`
+			g.intake_id = '%s'`,
+			filter.IntakeID))
+	}
+
+	if filter.ProgramID != uuid.Nil {
+		filters = append(filters, fmt.Sprintf(`
+			st.program_id = '%s'`,
+			filter.ProgramID))
+	}
+
+	filterJoin := strings.Join(filters, " AND ")
+	if filterJoin != "" {
+		filterJoin = fmt.Sprintf("AND %s", filterJoin)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT
+			g.id,
+			g.student_id,
+			g.intake_id,
+			g.cgpa,
+			g.credit_hours,
+			g.classification,
+			g.graduated_at,
+			g.is_delete,
+			g.created_by,
+			g.created_at,
+			g.updated_by,
+			g.updated_at
+		FROM graduation g
+		INNER JOIN student st ON g.student_id = st.id
+		WHERE g.is_delete = false
+		%s
+		ORDER BY g.graduated_at DESC, st.student_code ASC
+		LIMIT $1 OFFSET $2`, filterJoin)
+
+	rows, err := db.QueryContext(ctx, query, filter.Limit, filter.Offset)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var graduations []GraduationModel
+	for rows.Next() {
+		var graduation GraduationModel
+
+		rows.Scan(
+			&graduation.ID,
+			&graduation.StudentID,
+			&graduation.IntakeID,
+			&graduation.CGPA,
+			&graduation.CreditHours,
+			&graduation.Classification,
+			&graduation.GraduatedAt,
+			&graduation.IsDelete,
+			&graduation.CreatedBy,
+			&graduation.CreatedAt,
+			&graduation.UpdatedBy,
+			&graduation.UpdatedAt,
+		)
+
+		graduations = append(graduations, graduation)
+	}
+
+	return graduations, nil
+
+}
+
+// GetAllGraduationCandidateByIntake returns the students enrolled in any session of the intake, optionally
+// limited to one program.
+func GetAllGraduationCandidateByIntake(ctx context.Context, db *sql.DB, intakeID uuid.UUID, programID uuid.UUID) (
+	[]uuid.UUID, error) {
+
+	var programQuery string
+
+	if programID != uuid.Nil {
+		programQuery = fmt.Sprintf(`AND st.program_id = '%s'`, programID)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT DISTINCT
+			st.id,
+			st.student_code
+		FROM student_enroll se
+		INNER JOIN session s ON se.session_id = s.id
+		INNER JOIN student st ON se.student_id = st.id
+		WHERE se.is_delete = false
+		AND s.intake_id = $1
+		%s
+		ORDER BY st.student_code ASC`, programQuery)
+
+	rows, err := db.QueryContext(ctx, query, intakeID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var studentIDs []uuid.UUID
+	for rows.Next() {
+		var studentID uuid.UUID
+		var studentCode string
+
+		rows.Scan(
+			&studentID,
+			&studentCode,
+		)
+
+		studentIDs = append(studentIDs, studentID)
+	}
+
+	return studentIDs, nil
+
+}
+
+func (s *GraduationModel) Insert(ctx context.Context, db helpers.Queryer) error {
+
+	query := fmt.Sprintf(`
+		INSERT INTO graduation(
+			student_id,
+			intake_id,
+			cgpa,
+			credit_hours,
+			classification,
+			graduated_at,
+			created_by,
+			created_at)
+		VALUES(
+		$1,$2,$3,$4,$5,$6,$7,now())
+		RETURNING id, created_at,is_delete`)
+
+	err := db.QueryRowContext(ctx, query,
+		s.StudentID, s.IntakeID, s.CGPA, s.CreditHours, s.Classification, s.GraduatedAt, s.CreatedBy).Scan(
+		&s.ID, &s.CreatedAt, &s.IsDelete,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+
+}
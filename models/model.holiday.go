@@ -0,0 +1,197 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/lib/pq"
+	uuid "github.com/satori/go.uuid"
+	"school/helpers"
+	"time"
+)
+
+type (
+	HolidayModel struct {
+		ID        uuid.UUID
+		Date      time.Time
+		Name      string
+		IsDelete  bool
+		CreatedBy uuid.UUID
+		CreatedAt time.Time
+		UpdatedBy uuid.NullUUID
+		UpdatedAt pq.NullTime
+	}
+
+	HolidayResponse struct {
+		ID        uuid.UUID `json:"id"`
+		Date      time.Time `json:"date"`
+		Name      string    `json:"name"`
+		IsDelete  bool      `json:"is_delete"`
+		CreatedBy uuid.UUID `json:"created_by"`
+		CreatedAt time.Time `json:"created_at"`
+		UpdatedBy uuid.UUID `json:"updated_by"`
+		UpdatedAt time.Time `json:"updated_at"`
+	}
+)
+
+func (s HolidayModel) Response() HolidayResponse {
+	return HolidayResponse{
+		ID:        s.ID,
+		Date:      s.Date,
+		Name:      s.Name,
+		IsDelete:  s.IsDelete,
+		CreatedBy: s.CreatedBy,
+		CreatedAt: s.CreatedAt,
+		UpdatedBy: s.UpdatedBy.UUID,
+		UpdatedAt: s.UpdatedAt.Time,
+	}
+}
+
+func GetAllHoliday(ctx context.Context, db *sql.DB, filter helpers.Filter) ([]HolidayModel, error) {
+
+	var searchQuery string
+	args := []interface{}{filter.Limit, filter.Offset}
+
+	if filter.Search != "" {
+		args = append(args, "%"+filter.Search+"%")
+		searchQuery = fmt.Sprintf(`AND LOWER(name) LIKE LOWER($%d)`, len(args))
+	}
+
+	query := fmt.Sprintf(`
+		SELECT
+			id,
+			date,
+			name,
+			is_delete,
+			created_by,
+			created_at,
+			updated_by,
+			updated_at
+		FROM holiday
+		WHERE is_delete = false
+		%s
+		ORDER BY date %s
+		LIMIT $1 OFFSET $2`, searchQuery, filter.Dir)
+
+	rows, err := db.QueryContext(ctx, query, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var holidays []HolidayModel
+	for rows.Next() {
+		var holiday HolidayModel
+
+		rows.Scan(
+			&holiday.ID,
+			&holiday.Date,
+			&holiday.Name,
+			&holiday.IsDelete,
+			&holiday.CreatedBy,
+			&holiday.CreatedAt,
+			&holiday.UpdatedBy,
+			&holiday.UpdatedAt,
+		)
+
+		holidays = append(holidays, holiday)
+	}
+
+	return holidays, nil
+
+}
+
+func GetAllHolidayBetween(ctx context.Context, db *sql.DB, from time.Time, to time.Time) ([]HolidayModel, error) {
+
+	query := fmt.Sprintf(`
+		SELECT
+			id,
+			date,
+			name,
+			is_delete,
+			created_by,
+			created_at,
+			updated_by,
+			updated_at
+		FROM holiday
+		WHERE is_delete = false
+		AND date >= $1
+		AND date <= $2
+		ORDER BY date ASC`)
+
+	rows, err := db.QueryContext(ctx, query, from, to)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var holidays []HolidayModel
+	for rows.Next() {
+		var holiday HolidayModel
+
+		rows.Scan(
+			&holiday.ID,
+			&holiday.Date,
+			&holiday.Name,
+			&holiday.IsDelete,
+			&holiday.CreatedBy,
+			&holiday.CreatedAt,
+			&holiday.UpdatedBy,
+			&holiday.UpdatedAt,
+		)
+
+		holidays = append(holidays, holiday)
+	}
+
+	return holidays, nil
+
+}
+
+func (s *HolidayModel) Insert(ctx context.Context, db *sql.DB) error {
+
+	query := fmt.Sprintf(`
+		INSERT INTO holiday(
+			date,
+			name,
+			created_by,
+			created_at)
+		VALUES(
+		$1,$2,$3,now())
+		RETURNING id, created_at,is_delete`)
+
+	err := db.QueryRowContext(ctx, query,
+		s.Date, s.Name, s.CreatedBy).Scan(
+		&s.ID, &s.CreatedAt, &s.IsDelete,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+
+}
+
+func (s *HolidayModel) Delete(ctx context.Context, db *sql.DB) error {
+
+	query := fmt.Sprintf(`
+		UPDATE holiday
+		SET
+			is_delete=true,
+			updated_by=$1,
+			updated_at=NOW()
+		WHERE id=$2`)
+
+	_, err := db.ExecContext(ctx, query,
+		s.UpdatedBy, s.ID)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,236 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/lib/pq"
+	uuid "github.com/satori/go.uuid"
+	"school/helpers"
+	"strings"
+	"time"
+)
+
+const (
+	NOTIFICATION_CLASS_ADDED            = "class_added"
+	NOTIFICATION_CLASS_CANCELLED        = "class_cancelled"
+	NOTIFICATION_CLASS_RESCHEDULED      = "class_rescheduled"
+	NOTIFICATION_CLASS_SUBSTITUTED      = "class_substituted"
+	NOTIFICATION_ENROLLMENT_ACCEPTED    = "enrollment_accepted"
+	NOTIFICATION_ENROLLMENT_WAITLISTED  = "enrollment_waitlisted"
+	NOTIFICATION_ENROLLMENT_CANCELLED   = "enrollment_cancelled"
+	NOTIFICATION_RESULT_PUBLISHED       = "result_published"
+	NOTIFICATION_RESULT_RETURNED        = "result_returned"
+	NOTIFICATION_RESULT_UPDATED         = "result_updated"
+	NOTIFICATION_RESULT_REVISED         = "result_revised"
+	NOTIFICATION_RESULT_APPEAL_REJECTED = "result_appeal_rejected"
+
+	NOTIFICATION_READ   = "read"
+	NOTIFICATION_UNREAD = "unread"
+)
+
+type (
+	NotificationModel struct {
+		ID          uuid.UUID
+		UserID      uuid.UUID
+		Role        string
+		Type        string
+		Title       string
+		Body        string
+		ReferenceID uuid.NullUUID
+		IsRead      bool
+		ReadAt      pq.NullTime
+		CreatedAt   time.Time
+	}
+
+	NotificationResponse struct {
+		ID          uuid.UUID `json:"id"`
+		Type        string    `json:"type"`
+		Title       string    `json:"title"`
+		Body        string    `json:"body"`
+		ReferenceID uuid.UUID `json:"reference_id"`
+		IsRead      bool      `json:"is_read"`
+		ReadAt      time.Time `json:"read_at"`
+		CreatedAt   time.Time `json:"created_at"`
+	}
+)
+
+func (s NotificationModel) Response() NotificationResponse {
+	return NotificationResponse{
+		ID:          s.ID,
+		Type:        s.Type,
+		Title:       s.Title,
+		Body:        s.Body,
+		ReferenceID: s.ReferenceID.UUID,
+		IsRead:      s.IsRead,
+		ReadAt:      s.ReadAt.Time,
+		CreatedAt:   s.CreatedAt,
+	}
+}
+
+func (s *NotificationModel) Insert(ctx context.Context, db helpers.Queryer) error {
+
+	query := fmt.Sprintf(`
+		INSERT INTO notification(
+			user_id,
+			role,
+			type,
+			title,
+			body,
+			reference_id,
+			created_at)
+		VALUES(
+		$1,$2,$3,$4,$5,$6,now())
+		RETURNING id, created_at,is_read`)
+
+	err := db.QueryRowContext(ctx, query,
+		s.UserID, s.Role, s.Type, s.Title, s.Body, s.ReferenceID).Scan(
+		&s.ID, &s.CreatedAt, &s.IsRead,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+
+}
+
+func GetAllNotificationByUser(ctx context.Context, db *sql.DB, userID uuid.UUID, role string,
+	filter helpers.Filter) ([]NotificationModel, error) {
+
+	filters := []string{`
+			user_id = $3`, `
+			role = $4`}
+
+	if filter.Status == NOTIFICATION_READ {
+		filters = append(filters, `
+			is_read = true`)
+	}
+
+	if filter.Status == NOTIFICATION_UNREAD {
+		filters = append(filters, `
+			is_read = false`)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT
+			id,
+			user_id,
+			role,
+			type,
+			title,
+			body,
+			reference_id,
+			is_read,
+			read_at,
+			created_at
+		FROM notification
+		WHERE %s
+		ORDER BY created_at DESC
+		LIMIT $1 OFFSET $2`, strings.Join(filters, " AND "))
+
+	rows, err := db.QueryContext(ctx, query, filter.Limit, filter.Offset, userID, role)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var notifications []NotificationModel
+	for rows.Next() {
+		var notification NotificationModel
+
+		rows.Scan(
+			&notification.ID,
+			&notification.UserID,
+			&notification.Role,
+			&notification.Type,
+			&notification.Title,
+			&notification.Body,
+			&notification.ReferenceID,
+			&notification.IsRead,
+			&notification.ReadAt,
+			&notification.CreatedAt,
+		)
+
+		notifications = append(notifications, notification)
+	}
+
+	return notifications, nil
+
+}
+
+func GetCountUnreadNotificationByUser(ctx context.Context, db *sql.DB, userID uuid.UUID, role string) (int, error) {
+
+	query := fmt.Sprintf(`
+		SELECT
+			COUNT(id)
+		FROM notification
+		WHERE is_read = false
+		AND user_id = $1
+		AND role = $2`)
+
+	var count int
+	err := db.QueryRowContext(ctx, query, userID, role).Scan(&count)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+
+}
+
+// Read marks the notification as read, only when it belongs to the user and role of the model.
+func (s *NotificationModel) Read(ctx context.Context, db *sql.DB) error {
+
+	query := fmt.Sprintf(`
+		UPDATE notification
+		SET
+			is_read=true,
+			read_at=COALESCE(read_at, NOW())
+		WHERE id=$1
+		AND user_id=$2
+		AND role=$3
+		RETURNING user_id,role,type,title,body,reference_id,is_read,read_at,created_at`)
+
+	err := db.QueryRowContext(ctx, query,
+		s.ID, s.UserID, s.Role).Scan(
+		&s.UserID,
+		&s.Role,
+		&s.Type,
+		&s.Title,
+		&s.Body,
+		&s.ReferenceID,
+		&s.IsRead,
+		&s.ReadAt,
+		&s.CreatedAt,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func ReadAllNotificationByUser(ctx context.Context, db *sql.DB, userID uuid.UUID, role string) (int64, error) {
+
+	query := fmt.Sprintf(`
+		UPDATE notification
+		SET
+			is_read=true,
+			read_at=NOW()
+		WHERE is_read = false
+		AND user_id=$1
+		AND role=$2`)
+
+	result, err := db.ExecContext(ctx, query, userID, role)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
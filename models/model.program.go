@@ -12,29 +12,33 @@ import (
 
 type (
 	ProgramModel struct {
-		ID          uuid.UUID
-		FacultyID   uuid.UUID
-		Name        string
-		Code        int
-		Description string
-		IsDelete    bool
-		CreatedBy   uuid.UUID
-		CreatedAt   time.Time
-		UpdatedBy   uuid.NullUUID
-		UpdatedAt   pq.NullTime
+		ID              uuid.UUID
+		FacultyID       uuid.UUID
+		Name            string
+		Code            int
+		Description     string
+		GradingSchemeID uuid.NullUUID
+		ElectiveCredit  int
+		IsDelete        bool
+		CreatedBy       uuid.UUID
+		CreatedAt       time.Time
+		UpdatedBy       uuid.NullUUID
+		UpdatedAt       pq.NullTime
 	}
 
 	ProgramResponse struct {
-		ID          uuid.UUID       `json:"id"`
-		Faculty     FacultyResponse `json:"faculty"`
-		Name        string          `json:"name"`
-		Code        int             `json:"code"`
-		Description string          `json:"description""`
-		IsDelete    bool            `json:"is_delete"`
-		CreatedBy   uuid.UUID       `json:"created_by"`
-		CreatedAt   time.Time       `json:"created_at"`
-		UpdatedBy   uuid.UUID       `json:"updated_by"`
-		UpdatedAt   time.Time       `json:"updated_at"`
+		ID              uuid.UUID       `json:"id"`
+		Faculty         FacultyResponse `json:"faculty"`
+		Name            string          `json:"name"`
+		Code            int             `json:"code"`
+		Description     string          `json:"description"`
+		GradingSchemeID uuid.UUID       `json:"grading_scheme_id"`
+		ElectiveCredit  int             `json:"elective_credit"`
+		IsDelete        bool            `json:"is_delete"`
+		CreatedBy       uuid.UUID       `json:"created_by"`
+		CreatedAt       time.Time       `json:"created_at"`
+		UpdatedBy       uuid.UUID       `json:"updated_by"`
+		UpdatedAt       time.Time       `json:"updated_at"`
 	}
 )
 
@@ -47,16 +51,18 @@ func (s ProgramModel) Response(ctx context.Context, db *sql.DB, logger *helpers.
 	}
 
 	return ProgramResponse{
-		ID:          s.ID,
-		Faculty:     faculty.Response(),
-		Name:        s.Name,
-		Code:        s.Code,
-		Description: s.Description,
-		IsDelete:    s.IsDelete,
-		CreatedBy:   s.CreatedBy,
-		CreatedAt:   s.CreatedAt,
-		UpdatedBy:   s.UpdatedBy.UUID,
-		UpdatedAt:   s.UpdatedAt.Time,
+		ID:              s.ID,
+		Faculty:         faculty.Response(),
+		Name:            s.Name,
+		Code:            s.Code,
+		Description:     s.Description,
+		GradingSchemeID: s.GradingSchemeID.UUID,
+		ElectiveCredit:  s.ElectiveCredit,
+		IsDelete:        s.IsDelete,
+		CreatedBy:       s.CreatedBy,
+		CreatedAt:       s.CreatedAt,
+		UpdatedBy:       s.UpdatedBy.UUID,
+		UpdatedAt:       s.UpdatedAt.Time,
 	}, nil
 
 }
@@ -70,6 +76,8 @@ func GetOneProgram(ctx context.Context, db *sql.DB, programID uuid.UUID) (Progra
 			name,
 			code,
 			description,
+			grading_scheme_id,
+			elective_credit,
 			is_delete,
 			created_by,
 			created_at,
@@ -87,6 +95,8 @@ func GetOneProgram(ctx context.Context, db *sql.DB, programID uuid.UUID) (Progra
 		&program.Name,
 		&program.Code,
 		&program.Description,
+		&program.GradingSchemeID,
+		&program.ElectiveCredit,
 		&program.IsDelete,
 		&program.CreatedBy,
 		&program.CreatedAt,
@@ -117,6 +127,8 @@ func GetAllProgram(ctx context.Context, db *sql.DB, filter helpers.Filter) ([]Pr
 			name,
 			code,
 			description,
+			grading_scheme_id,
+			elective_credit,
 			is_delete,
 			created_by,
 			created_at,
@@ -146,6 +158,8 @@ func GetAllProgram(ctx context.Context, db *sql.DB, filter helpers.Filter) ([]Pr
 			&program.Name,
 			&program.Code,
 			&program.Description,
+			&program.GradingSchemeID,
+			&program.ElectiveCredit,
 			&program.IsDelete,
 			&program.CreatedBy,
 			&program.CreatedAt,
@@ -168,14 +182,16 @@ func (s *ProgramModel) Insert(ctx context.Context, db *sql.DB) error {
 			name,
 			code,
 			description,
+			grading_scheme_id,
+			elective_credit,
 			created_by,
 			created_at)
 		VALUES(
-		$1,$2,$3,$4,$5,now())
+		$1,$2,$3,$4,$5,$6,$7,now())
 		RETURNING id, created_at,is_delete`)
 
 	err := db.QueryRowContext(ctx, query,
-		s.FacultyID, s.Name, s.Code, s.Description, s.CreatedBy).Scan(
+		s.FacultyID, s.Name, s.Code, s.Description, s.GradingSchemeID, s.ElectiveCredit, s.CreatedBy).Scan(
 		&s.ID, &s.CreatedAt, &s.IsDelete,
 	)
 
@@ -196,13 +212,15 @@ func (s *ProgramModel) Update(ctx context.Context, db *sql.DB) error {
 			name=$2,
 			code=$3,
 			description=$4,
+			grading_scheme_id=$5,
+			elective_credit=$6,
 			updated_at=NOW(),
-			updated_by=$5
-		WHERE id=$6
+			updated_by=$7
+		WHERE id=$8
 		RETURNING id,created_at,updated_at,created_by,is_delete`)
 
 	err := db.QueryRowContext(ctx, query,
-		s.FacultyID, s.Name, s.Code, s.Description, s.UpdatedBy, s.ID).Scan(
+		s.FacultyID, s.Name, s.Code, s.Description, s.GradingSchemeID, s.ElectiveCredit, s.UpdatedBy, s.ID).Scan(
 		&s.ID, &s.CreatedAt, &s.UpdatedAt, &s.CreatedBy, &s.IsDelete,
 	)
 
@@ -0,0 +1,333 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/lib/pq"
+	uuid "github.com/satori/go.uuid"
+	"school/helpers"
+	"strings"
+	"time"
+)
+
+const (
+	RESULT_APPEAL_PENDING  = "pending"
+	RESULT_APPEAL_APPROVED = "approved"
+	RESULT_APPEAL_REJECTED = "rejected"
+)
+
+type (
+	ResultAppealModel struct {
+		ID            uuid.UUID
+		ResultID      uuid.UUID
+		StudentID     uuid.UUID
+		Reason        string
+		Status        string
+		OriginalMarks int
+		OriginalGrade string
+		RevisedMarks  sql.NullInt64
+		RevisedGrade  sql.NullString
+		ReviewComment string
+		ReviewedBy    uuid.NullUUID
+		ReviewedAt    pq.NullTime
+		IsDelete      bool
+		CreatedBy     uuid.UUID
+		CreatedAt     time.Time
+		UpdatedBy     uuid.NullUUID
+		UpdatedAt     pq.NullTime
+	}
+
+	ResultAppealResponse struct {
+		ID            uuid.UUID      `json:"id"`
+		Result        ResultResponse `json:"result"`
+		Reason        string         `json:"reason"`
+		Status        string         `json:"status"`
+		OriginalMarks int            `json:"original_marks"`
+		OriginalGrade string         `json:"original_grade"`
+		RevisedMarks  int64          `json:"revised_marks"`
+		RevisedGrade  string         `json:"revised_grade"`
+		ReviewComment string         `json:"review_comment"`
+		ReviewedBy    uuid.UUID      `json:"reviewed_by"`
+		ReviewedAt    time.Time      `json:"reviewed_at"`
+		IsDelete      bool           `json:"is_delete"`
+		CreatedBy     uuid.UUID      `json:"created_by"`
+		CreatedAt     time.Time      `json:"created_at"`
+		UpdatedBy     uuid.UUID      `json:"updated_by"`
+		UpdatedAt     time.Time      `json:"updated_at"`
+	}
+)
+
+func (s ResultAppealModel) Response(ctx context.Context, db *sql.DB, logger *helpers.Logger) (
+	ResultAppealResponse, error) {
+
+	result, err := GetOneResult(ctx, db, s.ResultID)
+	if err != nil {
+		logger.Err.Printf(`model.result.appeal.go/GetOneResult/%v`, err)
+		return ResultAppealResponse{}, err
+	}
+
+	resultResponse, err := result.Response(ctx, db, logger)
+	if err != nil {
+		logger.Err.Printf(`model.result.appeal.go/ResultResponse/%v`, err)
+		return ResultAppealResponse{}, err
+	}
+
+	return ResultAppealResponse{
+		ID:            s.ID,
+		Result:        resultResponse,
+		Reason:        s.Reason,
+		Status:        s.Status,
+		OriginalMarks: s.OriginalMarks,
+		OriginalGrade: s.OriginalGrade,
+		RevisedMarks:  s.RevisedMarks.Int64,
+		RevisedGrade:  s.RevisedGrade.String,
+		ReviewComment: s.ReviewComment,
+		ReviewedBy:    s.ReviewedBy.UUID,
+		ReviewedAt:    s.ReviewedAt.Time,
+		IsDelete:      s.IsDelete,
+		CreatedBy:     s.CreatedBy,
+		CreatedAt:     s.CreatedAt,
+		UpdatedBy:     s.UpdatedBy.UUID,
+		UpdatedAt:     s.UpdatedAt.Time,
+	}, nil
+}
+
+func GetOneResultAppeal(ctx context.Context, db *sql.DB, resultAppealID uuid.UUID) (ResultAppealModel, error) {
+
+	query := fmt.Sprintf(`
+		SELECT
+			id,
+			result_id,
+			student_id,
+			reason,
+			status,
+			original_marks,
+			original_grade,
+			revised_marks,
+			revised_grade,
+			review_comment,
+			reviewed_by,
+			reviewed_at,
+			is_delete,
+			created_by,
+			created_at,
+			updated_by,
+			updated_at
+		FROM result_appeal
+		WHERE is_delete = false
+		AND id = $1`)
+
+	var resultAppeal ResultAppealModel
+	err := db.QueryRowContext(ctx, query, resultAppealID).Scan(
+		&resultAppeal.ID,
+		&resultAppeal.ResultID,
+		&resultAppeal.StudentID,
+		&resultAppeal.Reason,
+		&resultAppeal.Status,
+		&resultAppeal.OriginalMarks,
+		&resultAppeal.OriginalGrade,
+		&resultAppeal.RevisedMarks,
+		&resultAppeal.RevisedGrade,
+		&resultAppeal.ReviewComment,
+		&resultAppeal.ReviewedBy,
+		&resultAppeal.ReviewedAt,
+		&resultAppeal.IsDelete,
+		&resultAppeal.CreatedBy,
+		&resultAppeal.CreatedAt,
+		&resultAppeal.UpdatedBy,
+		&resultAppeal.UpdatedAt,
+	)
+
+	if err != nil {
+		return ResultAppealModel{}, err
+	}
+
+	return resultAppeal, nil
+
+}
+
+func GetAllResultAppeal(ctx context.Context, db *sql.DB, filter helpers.Filter) ([]ResultAppealModel, error) {
+
+	filters := []string{"ra.is_delete = false"}
+
+	args := []interface{}{filter.Limit, filter.Offset}
+
+	if filter.StudentID != uuid.Nil {
+		filters = append(filters, fmt.Sprintf(`
+			ra.student_id = '%s'`,
+			filter.StudentID))
+	}
+
+	if filter.SessionID != uuid.Nil {
+		filters = append(filters, fmt.Sprintf(`
+			se.session_id = '%s'`,
+			filter.SessionID))
+	}
+
+	if filter.LecturerID != uuid.Nil {
+		filters = append(filters, fmt.Sprintf(`
+			s.lecturer_id = '%s'`,
+			filter.LecturerID))
+	}
+
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		filters = append(filters, fmt.Sprintf(`
+			ra.status = $%d`,
+			len(args)))
+	}
+
+	query := fmt.Sprintf(`
+		SELECT
+			ra.id,
+			ra.result_id,
+			ra.student_id,
+			ra.reason,
+			ra.status,
+			ra.original_marks,
+			ra.original_grade,
+			ra.revised_marks,
+			ra.revised_grade,
+			ra.review_comment,
+			ra.reviewed_by,
+			ra.reviewed_at,
+			ra.is_delete,
+			ra.created_by,
+			ra.created_at,
+			ra.updated_by,
+			ra.updated_at
+		FROM result_appeal ra
+		INNER JOIN result r ON ra.result_id = r.id
+		INNER JOIN student_enroll se ON r.student_enroll_id = se.id
+		INNER JOIN session s ON se.session_id = s.id
+		WHERE %s
+		ORDER BY ra.created_at %s
+		LIMIT $1 OFFSET $2`, strings.Join(filters, " AND "), filter.Dir)
+
+	rows, err := db.QueryContext(ctx, query, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var resultAppeals []ResultAppealModel
+	for rows.Next() {
+		var resultAppeal ResultAppealModel
+
+		rows.Scan(
+			&resultAppeal.ID,
+			&resultAppeal.ResultID,
+			&resultAppeal.StudentID,
+			&resultAppeal.Reason,
+			&resultAppeal.Status,
+			&resultAppeal.OriginalMarks,
+			&resultAppeal.OriginalGrade,
+			&resultAppeal.RevisedMarks,
+			&resultAppeal.RevisedGrade,
+			&resultAppeal.ReviewComment,
+			&resultAppeal.ReviewedBy,
+			&resultAppeal.ReviewedAt,
+			&resultAppeal.IsDelete,
+			&resultAppeal.CreatedBy,
+			&resultAppeal.CreatedAt,
+			&resultAppeal.UpdatedBy,
+			&resultAppeal.UpdatedAt,
+		)
+
+		resultAppeals = append(resultAppeals, resultAppeal)
+	}
+
+	return resultAppeals, nil
+
+}
+
+func GetCountPendingResultAppealByResult(ctx context.Context, db *sql.DB, resultID uuid.UUID) (int, error) {
+	return GetCountResultAppealByResult(ctx, db, resultID, RESULT_APPEAL_PENDING)
+}
+
+// GetCountResultAppealByResult counts the appeals of the result in the status.
+func GetCountResultAppealByResult(ctx context.Context, db helpers.Queryer, resultID uuid.UUID, status string) (
+	int, error) {
+
+	query := fmt.Sprintf(`
+		SELECT
+			COUNT(id)
+		FROM result_appeal
+		WHERE is_delete = false
+		AND result_id = $1
+		AND status = $2`)
+
+	var count int
+	err := db.QueryRowContext(ctx, query, resultID, status).Scan(&count)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+
+}
+
+func (s *ResultAppealModel) Insert(ctx context.Context, db helpers.Queryer) error {
+
+	query := fmt.Sprintf(`
+		INSERT INTO result_appeal(
+			result_id,
+			student_id,
+			reason,
+			status,
+			original_marks,
+			original_grade,
+			created_by,
+			created_at)
+		VALUES(
+		$1,$2,$3,$4,$5,$6,$7,now())
+		RETURNING id, created_at,is_delete`)
+
+	err := db.QueryRowContext(ctx, query,
+		s.ResultID, s.StudentID, s.Reason, s.Status, s.OriginalMarks, s.OriginalGrade, s.CreatedBy).Scan(
+		&s.ID, &s.CreatedAt, &s.IsDelete,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+
+}
+
+// Review stores the decision on the appeal. Only pending appeals are updated so two reviewers cannot
+// both decide the same appeal; sql.ErrNoRows means it was already reviewed.
+func (s *ResultAppealModel) Review(ctx context.Context, db helpers.Queryer) error {
+
+	query := fmt.Sprintf(`
+		UPDATE result_appeal
+		SET
+			status=$1,
+			revised_marks=$2,
+			revised_grade=$3,
+			review_comment=$4,
+			reviewed_by=$5,
+			reviewed_at=NOW(),
+			updated_by=$5,
+			updated_at=NOW()
+		WHERE id=$6
+		AND status=$7
+		RETURNING reviewed_at,updated_at`)
+
+	err := db.QueryRowContext(ctx, query,
+		s.Status, s.RevisedMarks, s.RevisedGrade, s.ReviewComment, s.ReviewedBy, s.ID, RESULT_APPEAL_PENDING).Scan(
+		&s.ReviewedAt, &s.UpdatedAt,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+
+}
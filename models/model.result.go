@@ -11,12 +11,27 @@ import (
 	"time"
 )
 
+const (
+	RESULT_DRAFT     = "draft"
+	RESULT_SUBMITTED = "submitted"
+	RESULT_APPROVED  = "approved"
+	RESULT_RETURNED  = "returned"
+	RESULT_PUBLISHED = "published"
+)
+
+// RESULT_GRADE_WITHDRAWN is the grade of a result whose enrollment was withdrawn after add/drop. It earns
+// no credit and is left out of the GPA.
+const RESULT_GRADE_WITHDRAWN = "W"
+
 type (
 	ResultModel struct {
 		ID              uuid.UUID
 		StudentEnrollID uuid.UUID
 		Grade           string
+		GradePoint      float64
+		GradingSchemeID uuid.NullUUID
 		Marks           int
+		Status          string
 		IsDelete        bool
 		CreatedBy       uuid.UUID
 		CreatedAt       time.Time
@@ -28,7 +43,9 @@ type (
 		ID            uuid.UUID             `json:"id"`
 		StudentEnroll StudentEnrollResponse `json:"student_enroll"`
 		Grade         string                `json:"grade"`
+		GradePoint    float64               `json:"grade_point"`
 		Marks         int                   `json:"marks"`
+		Status        string                `json:"status"`
 		IsDelete      bool                  `json:"is_delete"`
 		CreatedBy     uuid.UUID             `json:"created_by"`
 		CreatedAt     time.Time             `json:"created_at"`
@@ -55,7 +72,9 @@ func (s ResultModel) Response(ctx context.Context, db *sql.DB, logger *helpers.L
 		ID:            s.ID,
 		StudentEnroll: studentEnrollResponse,
 		Grade:         s.Grade,
+		GradePoint:    s.GradePoint,
 		Marks:         s.Marks,
+		Status:        s.Status,
 		IsDelete:      s.IsDelete,
 		CreatedBy:     s.CreatedBy,
 		CreatedAt:     s.CreatedAt,
@@ -71,7 +90,10 @@ func GetOneResult(ctx context.Context, db *sql.DB, resultID uuid.UUID) (ResultMo
 			id,
 			student_enroll_id,
 			grade,
+			grade_point,
+			grading_scheme_id,
 			marks,
+			status,
 			is_delete,
 			created_by,
 			created_at,
@@ -87,7 +109,10 @@ func GetOneResult(ctx context.Context, db *sql.DB, resultID uuid.UUID) (ResultMo
 		&result.ID,
 		&result.StudentEnrollID,
 		&result.Grade,
+		&result.GradePoint,
+		&result.GradingSchemeID,
 		&result.Marks,
+		&result.Status,
 		&result.IsDelete,
 		&result.CreatedBy,
 		&result.CreatedAt,
@@ -111,6 +136,10 @@ func GetAllResult(ctx context.Context, db *sql.DB, filter helpers.Filter) ([]Res
 
 	var subjectIDQuery string
 
+	var statusQuery string
+
+	args := []interface{}{filter.Limit, filter.Offset}
+
 	if filter.Search != "" {
 		searchQuery = fmt.Sprintf(`AND LOWER(st.name) LIKE LOWER('%%%s%%')`, filter.Search)
 	}
@@ -123,12 +152,20 @@ func GetAllResult(ctx context.Context, db *sql.DB, filter helpers.Filter) ([]Res
 		subjectIDQuery = fmt.Sprintf(`AND s.subject_id = '%s'`, filter.SubjectID)
 	}
 
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		statusQuery = fmt.Sprintf(`AND r.status = $%d`, len(args))
+	}
+
 	query := fmt.Sprintf(`
 		SELECT
 			r.id,
 			r.student_enroll_id,
 			r.grade,
+			r.grade_point,
+			r.grading_scheme_id,
 			r.marks,
+			r.status,
 			r.is_delete,
 			r.created_by,
 			r.created_at,
@@ -139,11 +176,11 @@ func GetAllResult(ctx context.Context, db *sql.DB, filter helpers.Filter) ([]Res
 		INNER JOIN student st ON se.student_id = st.id
 		INNER JOIN session s ON se.session_id = s.id
 		WHERE r.is_delete = false
-		%s %s %s
+		%s %s %s %s
 		ORDER BY  session_id %s ,st.name  %s
-		LIMIT $1 OFFSET $2`, searchQuery, studentIDQuery, subjectIDQuery, filter.Dir, filter.Dir)
+		LIMIT $1 OFFSET $2`, searchQuery, studentIDQuery, subjectIDQuery, statusQuery, filter.Dir, filter.Dir)
 
-	rows, err := db.QueryContext(ctx, query, filter.Limit, filter.Offset)
+	rows, err := db.QueryContext(ctx, query, args...)
 	fmt.Println(query)
 	if err != nil {
 		return nil, err
@@ -159,7 +196,10 @@ func GetAllResult(ctx context.Context, db *sql.DB, filter helpers.Filter) ([]Res
 			&result.ID,
 			&result.StudentEnrollID,
 			&result.Grade,
+			&result.GradePoint,
+			&result.GradingSchemeID,
 			&result.Marks,
+			&result.Status,
 			&result.IsDelete,
 			&result.CreatedBy,
 			&result.CreatedAt,
@@ -193,7 +233,10 @@ func GetAllResultByStudentEnroll(ctx context.Context, db *sql.DB, filter helpers
 			id,
 			student_enroll_id,
 			grade,
+			grade_point,
+			grading_scheme_id,
 			marks,
+			status,
 			is_delete,
 			created_by,
 			created_at,
@@ -218,7 +261,10 @@ func GetAllResultByStudentEnroll(ctx context.Context, db *sql.DB, filter helpers
 			&result.ID,
 			&result.StudentEnrollID,
 			&result.Grade,
+			&result.GradePoint,
+			&result.GradingSchemeID,
 			&result.Marks,
+			&result.Status,
 			&result.IsDelete,
 			&result.CreatedBy,
 			&result.CreatedAt,
@@ -237,6 +283,8 @@ func GetAllResultForOneStudent(ctx context.Context, db *sql.DB, filter helpers.F
 
 	var filters []string
 
+	args := []interface{}{filter.Limit, filter.Offset}
+
 	if filter.Search != "" {
 		filters = append(filters, fmt.Sprintf(`
 		LOWER(su.name) LIKE LOWER('%%%s%%')`,
@@ -248,6 +296,13 @@ func GetAllResultForOneStudent(ctx context.Context, db *sql.DB, filter helpers.F
 			se.student_id = '%s'`,
 			filter.StudentID))
 	}
+
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		filters = append(filters, fmt.Sprintf(`
+			r.status = $%d`,
+			len(args)))
+	}
 	filterJoin := strings.Join(filters, " AND ")
 	if filterJoin != "" {
 		filterJoin = fmt.Sprintf("WHERE %s", filterJoin)
@@ -257,7 +312,10 @@ func GetAllResultForOneStudent(ctx context.Context, db *sql.DB, filter helpers.F
 			r.id,
 			student_enroll_id,
 			grade,
+			r.grade_point,
+			r.grading_scheme_id,
 			marks,
+			r.status,
 			r.is_delete,
 			r.created_by,
 			r.created_at,
@@ -271,7 +329,7 @@ func GetAllResultForOneStudent(ctx context.Context, db *sql.DB, filter helpers.F
 		ORDER BY  su.name %s
 		LIMIT $1 OFFSET $2`, filterJoin, filter.Dir)
 
-	rows, err := db.QueryContext(ctx, query, filter.Limit, filter.Offset)
+	rows, err := db.QueryContext(ctx, query, args...)
 	fmt.Println(query)
 	if err != nil {
 		return nil, err
@@ -286,7 +344,10 @@ func GetAllResultForOneStudent(ctx context.Context, db *sql.DB, filter helpers.F
 			&result.ID,
 			&result.StudentEnrollID,
 			&result.Grade,
+			&result.GradePoint,
+			&result.GradingSchemeID,
 			&result.Marks,
+			&result.Status,
 			&result.IsDelete,
 			&result.CreatedBy,
 			&result.CreatedAt,
@@ -301,22 +362,24 @@ func GetAllResultForOneStudent(ctx context.Context, db *sql.DB, filter helpers.F
 
 }
 
-func (s *ResultModel) Insert(ctx context.Context, db *sql.DB) error {
+func (s *ResultModel) Insert(ctx context.Context, db helpers.Queryer) error {
 
 	query := fmt.Sprintf(`
 		INSERT INTO result(
 			student_enroll_id,
 			grade,
+			grade_point,
+			grading_scheme_id,
 			marks,
 			created_by,
 			created_at)
 		VALUES(
-		$1,$2,$3,$4,now())
-		RETURNING id, created_at,is_delete`)
+		$1,$2,$3,$4,$5,$6,now())
+		RETURNING id, created_at,is_delete,status`)
 
 	err := db.QueryRowContext(ctx, query,
-		s.StudentEnrollID, s.Grade, s.Marks, s.CreatedBy).Scan(
-		&s.ID, &s.CreatedAt, &s.IsDelete,
+		s.StudentEnrollID, s.Grade, s.GradePoint, s.GradingSchemeID, s.Marks, s.CreatedBy).Scan(
+		&s.ID, &s.CreatedAt, &s.IsDelete, &s.Status,
 	)
 
 	if err != nil {
@@ -333,14 +396,16 @@ func (s *ResultModel) UpdateByStudentEnroll(ctx context.Context, db *sql.DB) err
 		UPDATE result
 		SET
 			grade=$1,
-			marks=$2,
+			grade_point=$2,
+			grading_scheme_id=$3,
+			marks=$4,
 			updated_at=NOW(),
-			updated_by=$3
-		WHERE student_enroll_id = $4
+			updated_by=$5
+		WHERE student_enroll_id = $6
 		RETURNING id,updated_at,created_at,created_by`)
 
 	err := db.QueryRowContext(ctx, query,
-		s.Grade, s.Marks, s.UpdatedBy, s.StudentEnrollID).Scan(
+		s.Grade, s.GradePoint, s.GradingSchemeID, s.Marks, s.UpdatedBy, s.StudentEnrollID).Scan(
 		&s.ID, &s.UpdatedAt, &s.CreatedAt, &s.CreatedBy,
 	)
 
@@ -352,21 +417,54 @@ func (s *ResultModel) UpdateByStudentEnroll(ctx context.Context, db *sql.DB) err
 
 }
 
-func (s *ResultModel) Update(ctx context.Context, db *sql.DB) error {
+func (s *ResultModel) Update(ctx context.Context, db helpers.Queryer) error {
+
+	query := fmt.Sprintf(`
+		UPDATE result
+		SET
+			grade=$1,
+			grade_point=$2,
+			grading_scheme_id=$3,
+			marks=$4,
+			is_derived=false,
+			updated_at=NOW(),
+			updated_by=$5
+		WHERE id=$6
+		RETURNING id,updated_at,created_at,created_by,student_enroll_id,status`)
+
+	err := db.QueryRowContext(ctx, query,
+		s.Grade, s.GradePoint, s.GradingSchemeID, s.Marks, s.UpdatedBy, s.ID).Scan(
+		&s.ID, &s.UpdatedAt, &s.CreatedAt, &s.CreatedBy, &s.StudentEnrollID, &s.Status,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+
+}
+
+// DerivedUpdate stores marks and grade worked out from the session's assessment scores and flags the result
+// as derived, so ResetDerivedResultBySession can clear it when the assessments change.
+func (s *ResultModel) DerivedUpdate(ctx context.Context, db helpers.Queryer) error {
 
 	query := fmt.Sprintf(`
 		UPDATE result
 		SET
 			grade=$1,
-			marks=$2,
+			grade_point=$2,
+			grading_scheme_id=$3,
+			marks=$4,
+			is_derived=true,
 			updated_at=NOW(),
-			updated_by=$3
-		WHERE id=$4
-		RETURNING id,updated_at,created_at,created_by,student_enroll_id`)
+			updated_by=$5
+		WHERE id=$6
+		RETURNING id,updated_at,created_at,created_by,student_enroll_id,status`)
 
 	err := db.QueryRowContext(ctx, query,
-		s.Grade, s.Marks, s.UpdatedBy, s.ID).Scan(
-		&s.ID, &s.UpdatedAt, &s.CreatedAt, &s.CreatedBy, &s.StudentEnrollID,
+		s.Grade, s.GradePoint, s.GradingSchemeID, s.Marks, s.UpdatedBy, s.ID).Scan(
+		&s.ID, &s.UpdatedAt, &s.CreatedAt, &s.CreatedBy, &s.StudentEnrollID, &s.Status,
 	)
 
 	if err != nil {
@@ -377,7 +475,49 @@ func (s *ResultModel) Update(ctx context.Context, db *sql.DB) error {
 
 }
 
-func (s *ResultModel) Delete(ctx context.Context, db *sql.DB) error {
+// ResetDerivedResultBySession clears the marks and grade of the session's results that were derived from
+// assessment scores. Marks entered by hand, withdrawn results and results revised by an approved appeal are
+// left alone.
+func ResetDerivedResultBySession(ctx context.Context, db helpers.Queryer, sessionID uuid.UUID,
+	updatedBy uuid.NullUUID) error {
+
+	query := fmt.Sprintf(`
+		UPDATE result r
+		SET
+			grade='',
+			grade_point=0,
+			grading_scheme_id=NULL,
+			marks=0,
+			is_derived=false,
+			updated_at=NOW(),
+			updated_by=$2
+		WHERE r.is_delete = false
+		AND r.is_derived = true
+		AND r.grade != $3
+		AND r.student_enroll_id IN (
+			SELECT id
+			FROM student_enroll
+			WHERE session_id = $1
+			AND is_delete = false
+		)
+		AND NOT EXISTS (
+			SELECT 1
+			FROM result_appeal ra
+			WHERE ra.result_id = r.id
+			AND ra.is_delete = false
+			AND ra.status = $4
+		)`)
+
+	_, err := db.ExecContext(ctx, query, sessionID, updatedBy, RESULT_GRADE_WITHDRAWN, RESULT_APPEAL_APPROVED)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *ResultModel) Delete(ctx context.Context, db helpers.Queryer) error {
 
 	query := fmt.Sprintf(`
 		UPDATE result
@@ -396,3 +536,206 @@ func (s *ResultModel) Delete(ctx context.Context, db *sql.DB) error {
 
 	return nil
 }
+
+func GetAllResultByStudentAndSubject(ctx context.Context, db *sql.DB, studentID uuid.UUID, subjectID uuid.UUID) (
+	[]ResultModel, error) {
+
+	query := fmt.Sprintf(`
+		SELECT
+			r.id,
+			r.student_enroll_id,
+			r.grade,
+			r.grade_point,
+			r.grading_scheme_id,
+			r.marks,
+			r.status,
+			r.is_delete,
+			r.created_by,
+			r.created_at,
+			r.updated_by,
+			r.updated_at
+		FROM result r
+		INNER JOIN student_enroll se ON r.student_enroll_id = se.id
+		INNER JOIN session s ON se.session_id = s.id
+		WHERE r.is_delete = false
+		AND se.is_delete = false
+		AND r.status = $3
+		AND se.student_id = $1
+		AND s.subject_id = $2`)
+
+	rows, err := db.QueryContext(ctx, query, studentID, subjectID, RESULT_PUBLISHED)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var results []ResultModel
+	for rows.Next() {
+		var result ResultModel
+		rows.Scan(
+			&result.ID,
+			&result.StudentEnrollID,
+			&result.Grade,
+			&result.GradePoint,
+			&result.GradingSchemeID,
+			&result.Marks,
+			&result.Status,
+			&result.IsDelete,
+			&result.CreatedBy,
+			&result.CreatedAt,
+			&result.UpdatedBy,
+			&result.UpdatedAt,
+		)
+
+		results = append(results, result)
+	}
+
+	return results, nil
+
+}
+
+func GetAllResultByIntake(ctx context.Context, db *sql.DB, intakeID uuid.UUID) ([]ResultModel, error) {
+
+	query := fmt.Sprintf(`
+		SELECT
+			r.id,
+			r.student_enroll_id,
+			r.grade,
+			r.grade_point,
+			r.grading_scheme_id,
+			r.marks,
+			r.status,
+			r.is_delete,
+			r.created_by,
+			r.created_at,
+			r.updated_by,
+			r.updated_at
+		FROM result r
+		INNER JOIN student_enroll se ON r.student_enroll_id = se.id
+		INNER JOIN session s ON se.session_id = s.id
+		WHERE r.is_delete = false
+		AND se.is_delete = false
+		AND r.grade != ''
+		AND s.intake_id = $1
+		ORDER BY se.session_id`)
+
+	rows, err := db.QueryContext(ctx, query, intakeID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var results []ResultModel
+	for rows.Next() {
+		var result ResultModel
+		rows.Scan(
+			&result.ID,
+			&result.StudentEnrollID,
+			&result.Grade,
+			&result.GradePoint,
+			&result.GradingSchemeID,
+			&result.Marks,
+			&result.Status,
+			&result.IsDelete,
+			&result.CreatedBy,
+			&result.CreatedAt,
+			&result.UpdatedBy,
+			&result.UpdatedAt,
+		)
+
+		results = append(results, result)
+	}
+
+	return results, nil
+
+}
+
+func GetCountResultBySessionAndStatus(ctx context.Context, db helpers.Queryer, sessionID uuid.UUID) (
+	map[string]int, error) {
+
+	query := fmt.Sprintf(`
+		SELECT
+			r.status,
+			COUNT(r.id)
+		FROM result r
+		INNER JOIN student_enroll se ON r.student_enroll_id = se.id
+		WHERE r.is_delete = false
+		AND se.is_delete = false
+		AND se.session_id = $1
+		GROUP BY r.status`)
+
+	rows, err := db.QueryContext(ctx, query, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var status string
+		var count int
+		rows.Scan(
+			&status,
+			&count,
+		)
+
+		counts[status] = count
+	}
+
+	return counts, nil
+
+}
+
+func GetCountUngradedResultBySession(ctx context.Context, db helpers.Queryer, sessionID uuid.UUID) (int, error) {
+
+	query := fmt.Sprintf(`
+		SELECT
+			COUNT(r.id)
+		FROM result r
+		INNER JOIN student_enroll se ON r.student_enroll_id = se.id
+		WHERE r.is_delete = false
+		AND se.is_delete = false
+		AND r.grade = ''
+		AND se.session_id = $1`)
+
+	var count int
+	err := db.QueryRowContext(ctx, query, sessionID).Scan(&count)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+
+}
+
+// UpdateResultStatusBySession moves the session's results from any of fromStatuses to toStatus, leaving out the
+// results of the students in excludeStudentIDs.
+func UpdateResultStatusBySession(ctx context.Context, db helpers.Queryer, sessionID uuid.UUID, fromStatuses []string,
+	toStatus string, excludeStudentIDs []string, updatedBy uuid.NullUUID) (int64, error) {
+
+	query := fmt.Sprintf(`
+		UPDATE result
+		SET
+			status=$1,
+			updated_at=NOW(),
+			updated_by=$2
+		WHERE is_delete = false
+		AND status = ANY($3)
+		AND student_enroll_id IN (
+			SELECT id FROM student_enroll
+			WHERE is_delete = false
+			AND session_id = $4
+			AND student_id::STRING != ALL($5))`)
+
+	res, err := db.ExecContext(ctx, query, toStatus, updatedBy, pq.Array(fromStatuses), sessionID,
+		pq.Array(excludeStudentIDs))
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
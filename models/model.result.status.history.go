@@ -0,0 +1,154 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	uuid "github.com/satori/go.uuid"
+	"school/helpers"
+	"time"
+)
+
+type (
+	ResultStatusHistoryModel struct {
+		ID         uuid.UUID
+		SessionID  uuid.UUID
+		FromStatus string
+		ToStatus   string
+		Comment    string
+		CreatedBy  uuid.UUID
+		CreatedAt  time.Time
+	}
+
+	ResultStatusHistoryResponse struct {
+		ID         uuid.UUID `json:"id"`
+		SessionID  uuid.UUID `json:"session_id"`
+		FromStatus string    `json:"from_status"`
+		ToStatus   string    `json:"to_status"`
+		Comment    string    `json:"comment"`
+		CreatedBy  uuid.UUID `json:"created_by"`
+		CreatedAt  time.Time `json:"created_at"`
+	}
+)
+
+func (s ResultStatusHistoryModel) Response() ResultStatusHistoryResponse {
+	return ResultStatusHistoryResponse{
+		ID:         s.ID,
+		SessionID:  s.SessionID,
+		FromStatus: s.FromStatus,
+		ToStatus:   s.ToStatus,
+		Comment:    s.Comment,
+		CreatedBy:  s.CreatedBy,
+		CreatedAt:  s.CreatedAt,
+	}
+}
+
+func GetAllResultStatusHistoryBySession(ctx context.Context, db *sql.DB, sessionID uuid.UUID) (
+	[]ResultStatusHistoryModel, error) {
+
+	query := fmt.Sprintf(`
+		SELECT
+			id,
+			session_id,
+			from_status,
+			to_status,
+			comment,
+			created_by,
+			created_at
+		FROM result_status_history
+		WHERE session_id = $1
+		ORDER BY created_at ASC`)
+
+	rows, err := db.QueryContext(ctx, query, sessionID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var histories []ResultStatusHistoryModel
+	for rows.Next() {
+		var history ResultStatusHistoryModel
+
+		rows.Scan(
+			&history.ID,
+			&history.SessionID,
+			&history.FromStatus,
+			&history.ToStatus,
+			&history.Comment,
+			&history.CreatedBy,
+			&history.CreatedAt,
+		)
+
+		histories = append(histories, history)
+	}
+
+	return histories, nil
+
+}
+
+func (s *ResultStatusHistoryModel) Insert(ctx context.Context, db helpers.Queryer) error {
+
+	query := fmt.Sprintf(`
+		INSERT INTO result_status_history(
+			session_id,
+			from_status,
+			to_status,
+			comment,
+			created_by,
+			created_at)
+		VALUES(
+		$1,$2,$3,$4,$5,now())
+		RETURNING id, created_at`)
+
+	err := db.QueryRowContext(ctx, query,
+		s.SessionID, s.FromStatus, s.ToStatus, s.Comment, s.CreatedBy).Scan(
+		&s.ID, &s.CreatedAt,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+
+}
+
+// GetLastResultStatusHistoryBySession returns the latest transition of the session into the given status.
+func GetLastResultStatusHistoryBySession(ctx context.Context, db *sql.DB, sessionID uuid.UUID, toStatus string) (
+	ResultStatusHistoryModel, error) {
+
+	query := fmt.Sprintf(`
+		SELECT
+			id,
+			session_id,
+			from_status,
+			to_status,
+			comment,
+			created_by,
+			created_at
+		FROM result_status_history
+		WHERE session_id = $1
+		AND to_status = $2
+		ORDER BY created_at DESC
+		LIMIT 1`)
+
+	var history ResultStatusHistoryModel
+	err := db.QueryRowContext(ctx, query, sessionID, toStatus).Scan(
+		&history.ID,
+		&history.SessionID,
+		&history.FromStatus,
+		&history.ToStatus,
+		&history.Comment,
+		&history.CreatedBy,
+		&history.CreatedAt,
+	)
+
+	if err != nil {
+		return ResultStatusHistoryModel{}, err
+	}
+
+	return history, nil
+
+}
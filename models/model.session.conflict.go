@@ -0,0 +1,228 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	uuid "github.com/satori/go.uuid"
+	"school/helpers"
+)
+
+const (
+	CONFLICT_CLASSROOM = "classroom"
+	CONFLICT_LECTURER  = "lecturer"
+	CONFLICT_STUDENT   = "student"
+)
+
+type (
+	SessionConflictModel struct {
+		Type              string
+		SessionID         uuid.UUID
+		ConflictSessionID uuid.UUID
+	}
+
+	SessionConflictResponse struct {
+		Type            string          `json:"type"`
+		Session         SessionResponse `json:"session"`
+		ConflictSession SessionResponse `json:"conflict_session"`
+	}
+)
+
+func (s SessionConflictModel) Response(ctx context.Context, db *sql.DB, logger *helpers.Logger) (SessionConflictResponse, error) {
+
+	var sessionResponse SessionResponse
+
+	if s.SessionID != uuid.Nil {
+		session, err := GetOneSession(ctx, db, s.SessionID)
+		if err != nil {
+			logger.Err.Printf(`model.session.conflict.go/GetOneSession/%v`, err)
+			return SessionConflictResponse{}, err
+		}
+
+		sessionResponse, err = session.Response(ctx, db, logger)
+		if err != nil {
+			logger.Err.Printf(`model.session.conflict.go/sessionResponse/%v`, err)
+			return SessionConflictResponse{}, err
+		}
+	}
+
+	conflictSession, err := GetOneSession(ctx, db, s.ConflictSessionID)
+	if err != nil {
+		logger.Err.Printf(`model.session.conflict.go/GetOneConflictSession/%v`, err)
+		return SessionConflictResponse{}, err
+	}
+
+	conflictSessionResponse, err := conflictSession.Response(ctx, db, logger)
+	if err != nil {
+		logger.Err.Printf(`model.session.conflict.go/conflictSessionResponse/%v`, err)
+		return SessionConflictResponse{}, err
+	}
+
+	return SessionConflictResponse{
+		Type:            s.Type,
+		Session:         sessionResponse,
+		ConflictSession: conflictSessionResponse,
+	}, nil
+}
+
+// conflictTypes lists every clash between two overlapping sessions, so a pair sharing both the classroom and
+// the lecturer is reported once per type.
+func conflictTypes(isClassroom bool, isLecturer bool) []string {
+
+	var types []string
+
+	if isClassroom {
+		types = append(types, CONFLICT_CLASSROOM)
+	}
+
+	if isLecturer {
+		types = append(types, CONFLICT_LECTURER)
+	}
+
+	return types
+}
+
+func GetAllSessionConflict(ctx context.Context, db *sql.DB, session SessionModel) ([]SessionConflictModel, error) {
+
+	query := fmt.Sprintf(`
+		SELECT
+			classroom_id = $1,
+			lecturer_id = $7,
+			id
+		FROM session
+		WHERE is_delete = false
+		AND id != $2
+		AND intake_id = $3
+		AND day = $4
+		AND start_time < $6::TIME
+		AND end_time > $5::TIME
+		AND (classroom_id = $1 OR lecturer_id = $7)`)
+
+	rows, err := db.QueryContext(ctx, query, session.ClassroomID, session.ID, session.IntakeID, session.Day,
+		session.StartTime, session.EndTime, session.LecturerID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var conflicts []SessionConflictModel
+	for rows.Next() {
+		var isClassroom, isLecturer bool
+		conflict := SessionConflictModel{
+			SessionID: session.ID,
+		}
+
+		rows.Scan(
+			&isClassroom,
+			&isLecturer,
+			&conflict.ConflictSessionID,
+		)
+
+		for _, conflictType := range conflictTypes(isClassroom, isLecturer) {
+			conflict.Type = conflictType
+			conflicts = append(conflicts, conflict)
+		}
+	}
+
+	return conflicts, nil
+
+}
+
+func GetAllSessionConflictByStudent(ctx context.Context, db *sql.DB, studentID uuid.UUID, session SessionModel) (
+	[]SessionConflictModel, error) {
+
+	query := fmt.Sprintf(`
+		SELECT
+			s.id
+		FROM student_enroll se
+		INNER JOIN session s ON se.session_id = s.id
+		WHERE se.is_delete = false
+		AND se.status = $7
+		AND s.is_delete = false
+		AND se.student_id = $1
+		AND s.id != $2
+		AND s.intake_id = $3
+		AND s.day = $4
+		AND s.start_time < $6::TIME
+		AND s.end_time > $5::TIME`)
+
+	rows, err := db.QueryContext(ctx, query, studentID, session.ID, session.IntakeID, session.Day,
+		session.StartTime, session.EndTime, STUDENT_ENROLL_ENROLLED)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var conflicts []SessionConflictModel
+	for rows.Next() {
+		conflict := SessionConflictModel{
+			Type:      CONFLICT_STUDENT,
+			SessionID: session.ID,
+		}
+
+		rows.Scan(
+			&conflict.ConflictSessionID,
+		)
+
+		conflicts = append(conflicts, conflict)
+	}
+
+	return conflicts, nil
+
+}
+
+func GetAllSessionConflictByIntake(ctx context.Context, db *sql.DB, filter helpers.Filter) (
+	[]SessionConflictModel, error) {
+
+	query := fmt.Sprintf(`
+		SELECT
+			a.classroom_id = b.classroom_id,
+			a.lecturer_id = b.lecturer_id,
+			a.id,
+			b.id
+		FROM session a
+		INNER JOIN session b ON a.intake_id = b.intake_id
+			AND a.day = b.day
+			AND a.id < b.id
+			AND a.start_time < b.end_time
+			AND a.end_time > b.start_time
+			AND (a.classroom_id = b.classroom_id OR a.lecturer_id = b.lecturer_id)
+		WHERE a.is_delete = false
+		AND b.is_delete = false
+		AND a.intake_id = $1
+		ORDER BY a.day, a.start_time
+		LIMIT $2 OFFSET $3`)
+
+	rows, err := db.QueryContext(ctx, query, filter.IntakeID, filter.Limit, filter.Offset)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var conflicts []SessionConflictModel
+	for rows.Next() {
+		var isClassroom, isLecturer bool
+		var conflict SessionConflictModel
+
+		rows.Scan(
+			&isClassroom,
+			&isLecturer,
+			&conflict.SessionID,
+			&conflict.ConflictSessionID,
+		)
+
+		for _, conflictType := range conflictTypes(isClassroom, isLecturer) {
+			conflict.Type = conflictType
+			conflicts = append(conflicts, conflict)
+		}
+	}
+
+	return conflicts, nil
+
+}
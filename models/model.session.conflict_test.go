@@ -0,0 +1,27 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConflictTypes(t *testing.T) {
+
+	cases := []struct {
+		isClassroom bool
+		isLecturer  bool
+		types       []string
+	}{
+		{true, false, []string{CONFLICT_CLASSROOM}},
+		{false, true, []string{CONFLICT_LECTURER}},
+		{true, true, []string{CONFLICT_CLASSROOM, CONFLICT_LECTURER}},
+		{false, false, nil},
+	}
+
+	for _, c := range cases {
+		if got := conflictTypes(c.isClassroom, c.isLecturer); !reflect.DeepEqual(got, c.types) {
+			t.Fatalf("conflictTypes(%v, %v) = %v, expected %v", c.isClassroom, c.isLecturer, got, c.types)
+		}
+	}
+
+}
@@ -8,6 +8,7 @@ import (
 	uuid "github.com/satori/go.uuid"
 	"school/helpers"
 	"school/util"
+	"strings"
 	"time"
 )
 
@@ -22,6 +23,7 @@ type (
 		Day         int
 		StartTime   time.Time
 		EndTime     time.Time
+		Capacity    int
 		IsDelete    bool
 		CreatedBy   uuid.UUID
 		CreatedAt   time.Time
@@ -39,6 +41,7 @@ type (
 		Day       string            `json:"day"`
 		StartTime time.Time         `json:"start_time"`
 		EndTime   time.Time         `json:"end_time"`
+		Capacity  int               `json:"capacity"`
 		IsDelete  bool              `json:"is_delete"`
 		CreatedBy uuid.UUID         `json:"created_by"`
 		CreatedAt time.Time         `json:"created_at"`
@@ -108,6 +111,7 @@ func (s SessionModel) Response(ctx context.Context, db *sql.DB, logger *helpers.
 		Day:       day,
 		StartTime: s.StartTime,
 		EndTime:   s.EndTime,
+		Capacity:  s.Capacity,
 		IsDelete:  s.IsDelete,
 		CreatedBy: s.CreatedBy,
 		CreatedAt: s.CreatedAt,
@@ -129,6 +133,7 @@ func GetOneSession(ctx context.Context, db *sql.DB, sessionID uuid.UUID) (Sessio
 			day,
 			start_time,
 			end_time,
+			capacity,
 			is_delete,
 			created_by,
 			created_at,
@@ -150,6 +155,7 @@ func GetOneSession(ctx context.Context, db *sql.DB, sessionID uuid.UUID) (Sessio
 		&session.Day,
 		&session.StartTime,
 		&session.EndTime,
+		&session.Capacity,
 		&session.IsDelete,
 		&session.CreatedBy,
 		&session.CreatedAt,
@@ -178,6 +184,7 @@ func GetOneSessionByLecturer(ctx context.Context, db *sql.DB, lecturerID uuid.UU
 			day,
 			start_time,
 			end_time,
+			capacity,
 			is_delete,
 			created_by,
 			created_at,
@@ -199,6 +206,7 @@ func GetOneSessionByLecturer(ctx context.Context, db *sql.DB, lecturerID uuid.UU
 		&session.Day,
 		&session.StartTime,
 		&session.EndTime,
+		&session.Capacity,
 		&session.IsDelete,
 		&session.CreatedBy,
 		&session.CreatedAt,
@@ -244,6 +252,7 @@ func GetAllSession(ctx context.Context, db *sql.DB, filter helpers.Filter) ([]Se
 			day,
 			start_time,
 			end_time,
+			capacity,
 			is_delete,
 			created_by,
 			created_at,
@@ -277,6 +286,7 @@ func GetAllSession(ctx context.Context, db *sql.DB, filter helpers.Filter) ([]Se
 			&session.Day,
 			&session.StartTime,
 			&session.EndTime,
+			&session.Capacity,
 			&session.IsDelete,
 			&session.CreatedBy,
 			&session.CreatedAt,
@@ -303,6 +313,7 @@ func GetAllSessionByLecturer(ctx context.Context, db *sql.DB, filter helpers.Fil
 			day,
 			start_time,
 			end_time,
+			capacity,
 			is_delete,
 			created_by,
 			created_at,
@@ -333,6 +344,7 @@ func GetAllSessionByLecturer(ctx context.Context, db *sql.DB, filter helpers.Fil
 			&session.Day,
 			&session.StartTime,
 			&session.EndTime,
+			&session.Capacity,
 			&session.IsDelete,
 			&session.CreatedBy,
 			&session.CreatedAt,
@@ -359,14 +371,15 @@ func (s *SessionModel) Insert(ctx context.Context, db *sql.DB) error {
 			day,
 			start_time,
 			end_time,
+			capacity,
 			created_by,
 			created_at)
 		VALUES(
-		$1,$2,$3,$4,$5,$6,$7,$8,$9,now())
+		$1,$2,$3,$4,$5,$6,$7,$8,$9,$10,now())
 		RETURNING id, created_at,is_delete`)
 
 	err := db.QueryRowContext(ctx, query,
-		s.SubjectID, s.LecturerID, s.ProgramID, s.ClassroomID, s.IntakeID, s.Day, s.StartTime, s.EndTime, s.CreatedBy).Scan(
+		s.SubjectID, s.LecturerID, s.ProgramID, s.ClassroomID, s.IntakeID, s.Day, s.StartTime, s.EndTime, s.Capacity, s.CreatedBy).Scan(
 		&s.ID, &s.CreatedAt, &s.IsDelete,
 	)
 
@@ -391,13 +404,14 @@ func (s *SessionModel) Update(ctx context.Context, db *sql.DB) error {
 			day=$6,
 			start_time=$7,
 			end_time=$8,
+			capacity=$9,
 			updated_at=NOW(),
-			updated_by=$9
-		WHERE id=$10
+			updated_by=$10
+		WHERE id=$11
 		RETURNING id,created_at,updated_at,created_by,is_delete`)
 
 	err := db.QueryRowContext(ctx, query,
-		s.SubjectID, s.LecturerID, s.ProgramID, s.ClassroomID, s.IntakeID, s.Day, s.StartTime, s.EndTime, s.UpdatedBy, s.ID).Scan(
+		s.SubjectID, s.LecturerID, s.ProgramID, s.ClassroomID, s.IntakeID, s.Day, s.StartTime, s.EndTime, s.Capacity, s.UpdatedBy, s.ID).Scan(
 		&s.ID, &s.CreatedAt, &s.UpdatedAt, &s.CreatedBy, &s.IsDelete,
 	)
 
@@ -428,3 +442,226 @@ func (s *SessionModel) Delete(ctx context.Context, db *sql.DB) error {
 
 	return nil
 }
+
+func GetSessionCapacityForUpdate(ctx context.Context, tx *sql.Tx, sessionID uuid.UUID) (int, error) {
+
+	query := fmt.Sprintf(`
+		SELECT
+			capacity
+		FROM session
+		WHERE id = $1
+		FOR UPDATE`)
+
+	var capacity int
+	err := tx.QueryRowContext(ctx, query, sessionID).Scan(&capacity)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return capacity, nil
+
+}
+
+// GetAllSessionTimetable returns the sessions of the intake ordered by day and start time, limited to the
+// lecturer or to the sessions the student is enrolled in when either filter is set.
+func GetAllSessionTimetable(ctx context.Context, db *sql.DB, filter helpers.Filter) ([]SessionModel, error) {
+
+	var filters []string
+
+	if filter.IntakeID != uuid.Nil {
+		filters = append(filters, fmt.Sprintf(`
+			AND s.intake_id = '%s'`,
+			filter.IntakeID))
+	}
+
+	if filter.LecturerID != uuid.Nil {
+		filters = append(filters, fmt.Sprintf(`
+			AND s.lecturer_id = '%s'`,
+			filter.LecturerID))
+	}
+
+	if filter.ClassroomID != uuid.Nil {
+		filters = append(filters, fmt.Sprintf(`
+			AND s.classroom_id = '%s'`,
+			filter.ClassroomID))
+	}
+
+	if filter.StudentID != uuid.Nil {
+		filters = append(filters, fmt.Sprintf(`
+			AND s.id IN (
+				SELECT session_id
+				FROM student_enroll
+				WHERE is_delete = false
+				AND status = '%s'
+				AND student_id = '%s'
+			)`,
+			STUDENT_ENROLL_ENROLLED, filter.StudentID))
+	}
+
+	query := fmt.Sprintf(`
+		SELECT
+			s.id,
+			s.subject_id,
+			s.lecturer_id,
+			s.intake_id,
+			s.classroom_id,
+			s.program_id,
+			s.day,
+			s.start_time,
+			s.end_time,
+			s.capacity,
+			s.is_delete,
+			s.created_by,
+			s.created_at,
+			s.updated_by,
+			s.updated_at
+		FROM session s
+		WHERE s.is_delete = false
+		%s
+		ORDER BY s.day ASC, s.start_time ASC`, strings.Join(filters, ""))
+
+	rows, err := db.QueryContext(ctx, query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var sessions []SessionModel
+	for rows.Next() {
+		var session SessionModel
+		rows.Scan(
+			&session.ID,
+			&session.SubjectID,
+			&session.LecturerID,
+			&session.IntakeID,
+			&session.ClassroomID,
+			&session.ProgramID,
+			&session.Day,
+			&session.StartTime,
+			&session.EndTime,
+			&session.Capacity,
+			&session.IsDelete,
+			&session.CreatedBy,
+			&session.CreatedAt,
+			&session.UpdatedBy,
+			&session.UpdatedAt,
+		)
+
+		sessions = append(sessions, session)
+	}
+
+	return sessions, nil
+
+}
+
+// GetAllSessionInSlot returns the sessions held on the weekday whose time overlaps start and end, in the
+// classroom, intake or of the lecturer of the filter when those are set. With a date only the sessions whose
+// intake runs on that date and whose class of that date was neither cancelled nor moved are returned, the
+// moved classes are found with GetAllClassInSlot.
+func GetAllSessionInSlot(ctx context.Context, db *sql.DB, filter helpers.Filter, day int, date time.Time,
+	startTime time.Time, endTime time.Time) ([]SessionModel, error) {
+
+	var filters []string
+
+	if filter.ClassroomID != uuid.Nil {
+		filters = append(filters, fmt.Sprintf(`
+			AND s.classroom_id = '%s'`,
+			filter.ClassroomID))
+	}
+
+	if filter.IntakeID != uuid.Nil {
+		filters = append(filters, fmt.Sprintf(`
+			AND s.intake_id = '%s'`,
+			filter.IntakeID))
+	}
+
+	if filter.LecturerID != uuid.Nil {
+		filters = append(filters, fmt.Sprintf(`
+			AND s.lecturer_id = '%s'`,
+			filter.LecturerID))
+	}
+
+	if !date.IsZero() {
+		filters = append(filters, fmt.Sprintf(`
+			AND s.intake_id IN (
+				SELECT id
+				FROM intake
+				WHERE start_date <= '%[1]s'::DATE
+				AND end_date >= '%[1]s'::DATE
+			)
+			AND s.id NOT IN (
+				SELECT session_id
+				FROM class
+				WHERE date = '%[1]s'::DATE
+				AND (is_delete = true
+					OR status != '%[2]s'
+					OR classroom_id IS NOT NULL
+					OR substitute_lecturer_id IS NOT NULL
+					OR rescheduled_from_id IS NOT NULL)
+			)`,
+			date.Format("2006-01-02"), CLASS_SCHEDULED))
+	}
+
+	query := fmt.Sprintf(`
+		SELECT
+			s.id,
+			s.subject_id,
+			s.lecturer_id,
+			s.intake_id,
+			s.classroom_id,
+			s.program_id,
+			s.day,
+			s.start_time,
+			s.end_time,
+			s.capacity,
+			s.is_delete,
+			s.created_by,
+			s.created_at,
+			s.updated_by,
+			s.updated_at
+		FROM session s
+		WHERE s.is_delete = false
+		AND s.day = $1
+		AND s.start_time < $3::TIME
+		AND s.end_time > $2::TIME
+		%s
+		ORDER BY s.start_time ASC`, strings.Join(filters, ""))
+
+	rows, err := db.QueryContext(ctx, query, day, startTime, endTime)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var sessions []SessionModel
+	for rows.Next() {
+		var session SessionModel
+		rows.Scan(
+			&session.ID,
+			&session.SubjectID,
+			&session.LecturerID,
+			&session.IntakeID,
+			&session.ClassroomID,
+			&session.ProgramID,
+			&session.Day,
+			&session.StartTime,
+			&session.EndTime,
+			&session.Capacity,
+			&session.IsDelete,
+			&session.CreatedBy,
+			&session.CreatedAt,
+			&session.UpdatedBy,
+			&session.UpdatedAt,
+		)
+
+		sessions = append(sessions, session)
+	}
+
+	return sessions, nil
+
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	uuid "github.com/satori/go.uuid"
+	"school/helpers"
+)
+
+// NextStudentCodeSequence allocates the next student number of the program for the year. The row is
+// created on first use and incremented in the same statement, so concurrent callers never share a value.
+func NextStudentCodeSequence(ctx context.Context, db helpers.Queryer, programID uuid.UUID, year int) (int, error) {
+
+	query := fmt.Sprintf(`
+		INSERT INTO student_code_sequence(
+			program_id,
+			year,
+			last_value,
+			updated_at)
+		VALUES(
+		$1,$2,1,now())
+		ON CONFLICT (program_id, year)
+		DO UPDATE SET
+			last_value = student_code_sequence.last_value + 1,
+			updated_at = now()
+		RETURNING last_value`)
+
+	var sequence int
+	err := db.QueryRowContext(ctx, query, programID, year).Scan(&sequence)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return sequence, nil
+
+}
@@ -11,27 +11,38 @@ import (
 	"time"
 )
 
+// STUDENT_ENROLL_WITHDRAWN enrollments keep their W results but no longer hold a seat, attend classes or
+// receive notifications of the session.
+const (
+	STUDENT_ENROLL_ENROLLED  = "enrolled"
+	STUDENT_ENROLL_WITHDRAWN = "withdrawn"
+)
+
 type (
 	StudentEnrollModel struct {
-		ID        uuid.UUID
-		SessionID uuid.UUID
-		StudentID uuid.UUID
-		IsDelete  bool
-		CreatedBy uuid.UUID
-		CreatedAt time.Time
-		UpdatedBy uuid.NullUUID
-		UpdatedAt pq.NullTime
+		ID         uuid.UUID
+		SessionID  uuid.UUID
+		StudentID  uuid.UUID
+		LateReason string
+		Status     string
+		IsDelete   bool
+		CreatedBy  uuid.UUID
+		CreatedAt  time.Time
+		UpdatedBy  uuid.NullUUID
+		UpdatedAt  pq.NullTime
 	}
 
 	StudentEnrollResponse struct {
-		ID        uuid.UUID       `json:"id"`
-		Session   SessionResponse `json:"session"`
-		Student   StudentResponse `json:"student"`
-		IsDelete  bool            `json:"is_delete"`
-		CreatedBy uuid.UUID       `json:"created_by"`
-		CreatedAt time.Time       `json:"created_at"`
-		UpdatedBy uuid.UUID       `json:"updated_by"`
-		UpdatedAt time.Time       `json:"updated_at"`
+		ID         uuid.UUID       `json:"id"`
+		Session    SessionResponse `json:"session"`
+		Student    StudentResponse `json:"student"`
+		LateReason string          `json:"late_reason"`
+		Status     string          `json:"status"`
+		IsDelete   bool            `json:"is_delete"`
+		CreatedBy  uuid.UUID       `json:"created_by"`
+		CreatedAt  time.Time       `json:"created_at"`
+		UpdatedBy  uuid.UUID       `json:"updated_by"`
+		UpdatedAt  time.Time       `json:"updated_at"`
 	}
 )
 
@@ -62,14 +73,16 @@ func (s StudentEnrollModel) Response(ctx context.Context, db *sql.DB, logger *he
 	}
 
 	return StudentEnrollResponse{
-		ID:        s.ID,
-		Session:   sessionResponse,
-		Student:   studentResponse,
-		IsDelete:  s.IsDelete,
-		CreatedBy: s.CreatedBy,
-		CreatedAt: s.CreatedAt,
-		UpdatedBy: s.UpdatedBy.UUID,
-		UpdatedAt: s.UpdatedAt.Time,
+		ID:         s.ID,
+		Session:    sessionResponse,
+		Student:    studentResponse,
+		LateReason: s.LateReason,
+		Status:     s.Status,
+		IsDelete:   s.IsDelete,
+		CreatedBy:  s.CreatedBy,
+		CreatedAt:  s.CreatedAt,
+		UpdatedBy:  s.UpdatedBy.UUID,
+		UpdatedAt:  s.UpdatedAt.Time,
 	}, nil
 }
 
@@ -80,6 +93,8 @@ func GetOneStudentEnroll(ctx context.Context, db *sql.DB, studentEnrollID uuid.U
 			id,
 			session_id,
 			student_id,
+			late_reason,
+			status,
 			is_delete,
 			created_by,
 			created_at,
@@ -95,6 +110,8 @@ func GetOneStudentEnroll(ctx context.Context, db *sql.DB, studentEnrollID uuid.U
 		&student.ID,
 		&student.SessionID,
 		&student.StudentID,
+		&student.LateReason,
+		&student.Status,
 		&student.IsDelete,
 		&student.CreatedBy,
 		&student.CreatedAt,
@@ -117,6 +134,8 @@ func GetOneStudentEnrollBySession(ctx context.Context, db *sql.DB, studentEnroll
 			se.id,
 			session_id,
 			student_id,
+			se.late_reason,
+			se.status,
 			se.is_delete,
 			se.created_by,
 			se.created_at,
@@ -132,6 +151,8 @@ func GetOneStudentEnrollBySession(ctx context.Context, db *sql.DB, studentEnroll
 		&student.ID,
 		&student.SessionID,
 		&student.StudentID,
+		&student.LateReason,
+		&student.Status,
 		&student.IsDelete,
 		&student.CreatedBy,
 		&student.CreatedAt,
@@ -147,22 +168,23 @@ func GetOneStudentEnrollBySession(ctx context.Context, db *sql.DB, studentEnroll
 
 }
 
-//select  student_id from student_enroll inner join session where session_id = param.id
-func (s *StudentEnrollModel) Insert(ctx context.Context, db *sql.DB) error {
+// select  student_id from student_enroll inner join session where session_id = param.id
+func (s *StudentEnrollModel) Insert(ctx context.Context, db helpers.Queryer) error {
 
 	query := fmt.Sprintf(`
 		INSERT INTO student_enroll(
 			session_id,
 			student_id,
+			late_reason,
 			created_by,
 			created_at)
 		VALUES(
-		$1,$2,$3,now())
-		RETURNING id, created_at,is_delete`)
+		$1,$2,$3,$4,now())
+		RETURNING id, created_at,status,is_delete`)
 
 	err := db.QueryRowContext(ctx, query,
-		s.SessionID, s.StudentID, s.CreatedBy).Scan(
-		&s.ID, &s.CreatedAt, &s.IsDelete,
+		s.SessionID, s.StudentID, s.LateReason, s.CreatedBy).Scan(
+		&s.ID, &s.CreatedAt, &s.Status, &s.IsDelete,
 	)
 
 	if err != nil {
@@ -192,6 +214,8 @@ func GetAllStudentEnrollByOneStudent(ctx context.Context, db *sql.DB, filter hel
 			se.id,
 			session_id,
 			student_id,
+			se.late_reason,
+			se.status,
 			se.is_delete,
 			se.created_by,
 			se.created_at,
@@ -219,6 +243,8 @@ func GetAllStudentEnrollByOneStudent(ctx context.Context, db *sql.DB, filter hel
 			&student.ID,
 			&student.SessionID,
 			&student.StudentID,
+			&student.LateReason,
+			&student.Status,
 			&student.IsDelete,
 			&student.CreatedBy,
 			&student.CreatedAt,
@@ -251,6 +277,8 @@ func GetAllStudentEnrollBySession(ctx context.Context, db *sql.DB, filter helper
 			id,
 			session_id,
 			student_id,
+			late_reason,
+			status,
 			is_delete,
 			created_by,
 			created_at,
@@ -278,6 +306,8 @@ func GetAllStudentEnrollBySession(ctx context.Context, db *sql.DB, filter helper
 			&student.ID,
 			&student.SessionID,
 			&student.StudentID,
+			&student.LateReason,
+			&student.Status,
 			&student.IsDelete,
 			&student.CreatedBy,
 			&student.CreatedAt,
@@ -292,7 +322,7 @@ func GetAllStudentEnrollBySession(ctx context.Context, db *sql.DB, filter helper
 
 }
 
-func (s *StudentEnrollModel) Delete(ctx context.Context, db *sql.DB) error {
+func (s *StudentEnrollModel) Delete(ctx context.Context, db helpers.Queryer) error {
 
 	query := fmt.Sprintf(`
 		UPDATE student_enroll
@@ -312,6 +342,30 @@ func (s *StudentEnrollModel) Delete(ctx context.Context, db *sql.DB) error {
 	return nil
 }
 
+// Withdraw keeps the enrollment, and its results, but gives up the student's seat in the session.
+func (s *StudentEnrollModel) Withdraw(ctx context.Context, db helpers.Queryer) error {
+
+	query := fmt.Sprintf(`
+		UPDATE student_enroll
+		SET
+			status=$1,
+			updated_by=$2,
+			updated_at=NOW()
+		WHERE id=$3
+		RETURNING status`)
+
+	err := db.QueryRowContext(ctx, query,
+		STUDENT_ENROLL_WITHDRAWN, s.UpdatedBy, s.ID).Scan(
+		&s.Status,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func GetOneStudentEnrollBySessionAndStudentID(ctx context.Context, db *sql.DB, sessionID uuid.UUID, studentID uuid.UUID) (
 	StudentEnrollModel, error) {
 
@@ -320,6 +374,8 @@ func GetOneStudentEnrollBySessionAndStudentID(ctx context.Context, db *sql.DB, s
 			id,
 			session_id,
 			student_id,
+			late_reason,
+			status,
 			is_delete,
 			created_by,
 			created_at,
@@ -327,13 +383,16 @@ func GetOneStudentEnrollBySessionAndStudentID(ctx context.Context, db *sql.DB, s
 			updated_at
 		FROM student_enroll se
 		WHERE session_id = $1
-		AND student_id = $2 `)
+		AND student_id = $2
+		AND is_delete = false`)
 
 	var student StudentEnrollModel
 	err := db.QueryRowContext(ctx, query, sessionID, studentID).Scan(
 		&student.ID,
 		&student.SessionID,
 		&student.StudentID,
+		&student.LateReason,
+		&student.Status,
 		&student.IsDelete,
 		&student.CreatedBy,
 		&student.CreatedAt,
@@ -368,6 +427,8 @@ func GetAllStudentEnrollByStudent(ctx context.Context, db *sql.DB, filter helper
 			id,
 			session_id,
 			student_id,
+			late_reason,
+			status,
 			is_delete,
 			created_by,
 			created_at,
@@ -396,6 +457,8 @@ func GetAllStudentEnrollByStudent(ctx context.Context, db *sql.DB, filter helper
 			&student.ID,
 			&student.SessionID,
 			&student.StudentID,
+			&student.LateReason,
+			&student.Status,
 			&student.IsDelete,
 			&student.CreatedBy,
 			&student.CreatedAt,
@@ -409,3 +472,24 @@ func GetAllStudentEnrollByStudent(ctx context.Context, db *sql.DB, filter helper
 	return students, nil
 
 }
+
+func CountStudentEnrollBySession(ctx context.Context, db helpers.Queryer, sessionID uuid.UUID) (int, error) {
+
+	query := fmt.Sprintf(`
+		SELECT
+			COUNT(id)
+		FROM student_enroll
+		WHERE is_delete = false
+		AND session_id = $1
+		AND status = $2`)
+
+	var count int
+	err := db.QueryRowContext(ctx, query, sessionID, STUDENT_ENROLL_ENROLLED).Scan(&count)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+
+}
@@ -12,6 +12,16 @@ import (
 	"time"
 )
 
+const (
+	STUDENT_APPLICANT = "applicant"
+	STUDENT_ENROLLED  = "enrolled"
+	STUDENT_ON_LEAVE  = "on_leave"
+	STUDENT_SUSPENDED = "suspended"
+	STUDENT_WITHDRAWN = "withdrawn"
+	STUDENT_GRADUATED = "graduated"
+	STUDENT_EXPELLED  = "expelled"
+)
+
 type (
 	StudentModel struct {
 		ID          uuid.UUID
@@ -25,6 +35,7 @@ type (
 		StudentCode string
 		Password    string
 		IsActive    bool
+		Status      string
 		CreatedBy   uuid.UUID
 		CreatedAt   time.Time
 		UpdatedBy   uuid.NullUUID
@@ -42,6 +53,7 @@ type (
 		PhoneNo     string          `json:"phone_no"`
 		StudentCode string          `json:"student_code"`
 		IsActive    bool            `json:"is_active"`
+		Status      string          `json:"status"`
 		CreatedBy   uuid.UUID       `json:"created_by"`
 		CreatedAt   time.Time       `json:"created_at"`
 		UpdatedBy   uuid.UUID       `json:"updated_by"`
@@ -84,6 +96,7 @@ func (s StudentModel) Response(ctx context.Context, db *sql.DB, logger *helpers.
 		PhoneNo:     s.PhoneNo,
 		StudentCode: s.StudentCode,
 		IsActive:    s.IsActive,
+		Status:      s.Status,
 		CreatedBy:   s.CreatedBy,
 		CreatedAt:   s.CreatedAt,
 		UpdatedBy:   s.UpdatedBy.UUID,
@@ -107,6 +120,7 @@ func GetOneStudent(ctx context.Context, db *sql.DB, studentID uuid.UUID) (Studen
 			password,
 			student_code,
 			is_active,
+			status,
 			created_by,
 			created_at,
 			updated_by,
@@ -129,6 +143,7 @@ func GetOneStudent(ctx context.Context, db *sql.DB, studentID uuid.UUID) (Studen
 		&student.Password,
 		&student.StudentCode,
 		&student.IsActive,
+		&student.Status,
 		&student.CreatedBy,
 		&student.CreatedAt,
 		&student.UpdatedBy,
@@ -151,6 +166,15 @@ func GetAllStudent(ctx context.Context, db *sql.DB, filter helpers.Filter) ([]St
 		searchQuery = fmt.Sprintf(`AND LOWER(name) LIKE LOWER('%%%s%%')`, filter.Search)
 	}
 
+	statusQuery := "is_active = true"
+
+	args := []interface{}{filter.Limit, filter.Offset}
+
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		statusQuery = fmt.Sprintf(`status = $%d`, len(args))
+	}
+
 	query := fmt.Sprintf(`
 		SELECT
 			id,
@@ -163,17 +187,18 @@ func GetAllStudent(ctx context.Context, db *sql.DB, filter helpers.Filter) ([]St
 			phone_no,
 			student_code,
 			is_active,
+			status,
 			created_by,
 			created_at,
 			updated_by,
 			updated_at
 		FROM student
-		WHERE is_active = true
+		WHERE %s
 		%s
 		ORDER BY name  %s
-		LIMIT $1 OFFSET $2`, searchQuery, filter.Dir)
+		LIMIT $1 OFFSET $2`, statusQuery, searchQuery, filter.Dir)
 
-	rows, err := db.QueryContext(ctx, query, filter.Limit, filter.Offset)
+	rows, err := db.QueryContext(ctx, query, args...)
 
 	if err != nil {
 		return nil, err
@@ -196,6 +221,7 @@ func GetAllStudent(ctx context.Context, db *sql.DB, filter helpers.Filter) ([]St
 			&student.PhoneNo,
 			&student.StudentCode,
 			&student.IsActive,
+			&student.Status,
 			&student.CreatedBy,
 			&student.CreatedAt,
 			&student.UpdatedBy,
@@ -224,13 +250,15 @@ func GetOneStudentByCode(ctx context.Context, db *sql.DB, code string) (StudentM
 			student_code,
 			password,
 			is_active,
+			status,
 			created_by,
 			created_at,
 			updated_by,
 			updated_at
 		FROM student
 		WHERE
-			student_code = $1 AND is_active=true
+			student_code = $1
+		AND is_active = true
 	`)
 
 	var student StudentModel
@@ -246,6 +274,7 @@ func GetOneStudentByCode(ctx context.Context, db *sql.DB, code string) (StudentM
 		&student.StudentCode,
 		&student.Password,
 		&student.IsActive,
+		&student.Status,
 		&student.CreatedBy,
 		&student.CreatedAt,
 		&student.UpdatedBy,
@@ -260,7 +289,100 @@ func GetOneStudentByCode(ctx context.Context, db *sql.DB, code string) (StudentM
 
 }
 
-func (s *StudentModel) Insert(ctx context.Context, db *sql.DB) error {
+// CountStudentByCode counts the students holding the code, active or not.
+func CountStudentByCode(ctx context.Context, db helpers.Queryer, code string) (int, error) {
+
+	query := fmt.Sprintf(`
+		SELECT
+			COUNT(id)
+		FROM student
+		WHERE student_code = $1`)
+
+	var count int
+	err := db.QueryRowContext(ctx, query, code).Scan(&count)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+
+}
+
+// CountStudentByEmail counts the students registered with the email, ignoring case.
+func CountStudentByEmail(ctx context.Context, db helpers.Queryer, email string) (int, error) {
+
+	query := fmt.Sprintf(`
+		SELECT
+			COUNT(id)
+		FROM student
+		WHERE LOWER(email) = LOWER($1)`)
+
+	var count int
+	err := db.QueryRowContext(ctx, query, email).Scan(&count)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+
+}
+
+// GetAllDuplicateStudentCode returns every student whose code is shared with another student, grouped by
+// code and oldest first.
+func GetAllDuplicateStudentCode(ctx context.Context, db *sql.DB) ([]StudentModel, error) {
+
+	query := fmt.Sprintf(`
+		SELECT
+			id,
+			program_id,
+			name,
+			student_code,
+			is_active,
+			status,
+			created_by,
+			created_at
+		FROM student
+		WHERE student_code IN (
+			SELECT student_code
+			FROM student
+			GROUP BY student_code
+			HAVING COUNT(id) > 1
+		)
+		ORDER BY student_code ASC, created_at ASC, id ASC`)
+
+	rows, err := db.QueryContext(ctx, query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var students []StudentModel
+	for rows.Next() {
+		var student StudentModel
+
+		rows.Scan(
+			&student.ID,
+			&student.ProgramID,
+			&student.Name,
+			&student.StudentCode,
+			&student.IsActive,
+			&student.Status,
+			&student.CreatedBy,
+			&student.CreatedAt,
+		)
+
+		students = append(students, student)
+	}
+
+	return students, nil
+
+}
+
+func (s *StudentModel) Insert(ctx context.Context, db helpers.Queryer) error {
 
 	password, err := bcrypt.GenerateFromPassword([]byte(s.Password), 12)
 	if err != nil {
@@ -278,14 +400,17 @@ func (s *StudentModel) Insert(ctx context.Context, db *sql.DB) error {
 			student_code,
 			password,
 			phone_no,
+			status,
+			is_active,
 			created_by,
 			created_at)
 		VALUES(
-		$1,$2,$3,$4,$5,$6,$7,$8,$9,$10,now())
+		$1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,now())
 		RETURNING id, created_at,is_active`)
 
 	err = db.QueryRowContext(ctx, query,
-		s.Name, s.ProgramID, s.Address, s.DateOfBirth, s.Gender, s.Email, s.StudentCode, password, s.PhoneNo, s.CreatedBy).Scan(
+		s.Name, s.ProgramID, s.Address, s.DateOfBirth, s.Gender, s.Email, s.StudentCode, password, s.PhoneNo,
+		s.Status, IsStudentStatusActive(s.Status), s.CreatedBy).Scan(
 		&s.ID, &s.CreatedAt, &s.IsActive,
 	)
 
@@ -312,11 +437,11 @@ func (s *StudentModel) Update(ctx context.Context, db *sql.DB) error {
 			updated_at=NOW(),
 			updated_by=$8
 		WHERE id=$9
-		RETURNING id,created_at,updated_at,created_by,student_code,is_active`)
+		RETURNING id,created_at,updated_at,created_by,student_code,is_active,status`)
 
 	err := db.QueryRowContext(ctx, query,
 		s.Name, s.ProgramID, s.Address, s.DateOfBirth, s.Gender, s.Email, s.PhoneNo, s.UpdatedBy, s.ID).Scan(
-		&s.ID, &s.CreatedAt, &s.UpdatedAt, &s.CreatedBy, &s.StudentCode, &s.IsActive,
+		&s.ID, &s.CreatedAt, &s.UpdatedAt, &s.CreatedBy, &s.StudentCode, &s.IsActive, &s.Status,
 	)
 
 	if err != nil {
@@ -351,18 +476,44 @@ func (s *StudentModel) PasswordUpdate(ctx context.Context, db *sql.DB) error {
 
 }
 
-func (s *StudentModel) Delete(ctx context.Context, db *sql.DB) error {
+func (s *StudentModel) CodeUpdate(ctx context.Context, db helpers.Queryer) error {
 
 	query := fmt.Sprintf(`
 		UPDATE student
 		SET
-			is_active=false,
-			updated_by=$1,
+			student_code=$1,
+			updated_by=$2,
 			updated_at=NOW()
-		WHERE id=$2`)
+		WHERE id=$3`)
 
 	_, err := db.ExecContext(ctx, query,
-		s.UpdatedBy, s.ID)
+		s.StudentCode, s.UpdatedBy, s.ID)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// StatusUpdate moves the student to a new status. Students who withdrew or were expelled are no longer
+// active.
+func (s *StudentModel) StatusUpdate(ctx context.Context, db helpers.Queryer) error {
+
+	query := fmt.Sprintf(`
+		UPDATE student
+		SET
+			status=$1,
+			is_active=$2,
+			updated_by=$3,
+			updated_at=NOW()
+		WHERE id=$4
+		RETURNING is_active`)
+
+	err := db.QueryRowContext(ctx, query,
+		s.Status, IsStudentStatusActive(s.Status), s.UpdatedBy, s.ID).Scan(
+		&s.IsActive,
+	)
 
 	if err != nil {
 		return err
@@ -370,3 +521,42 @@ func (s *StudentModel) Delete(ctx context.Context, db *sql.DB) error {
 
 	return nil
 }
+
+// WithdrawInactiveStudent moves the students deleted before the lifecycle statuses existed to withdrawn. Their
+// rows are inactive but kept the default enrolled status.
+func WithdrawInactiveStudent(ctx context.Context, db helpers.Queryer, updatedBy uuid.NullUUID) ([]uuid.UUID, error) {
+
+	query := fmt.Sprintf(`
+		UPDATE student
+		SET
+			status=$1,
+			updated_by=$2,
+			updated_at=NOW()
+		WHERE is_active = false
+		AND status = $3
+		RETURNING id`)
+
+	rows, err := db.QueryContext(ctx, query, STUDENT_WITHDRAWN, updatedBy, STUDENT_ENROLLED)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var studentIDs []uuid.UUID
+	for rows.Next() {
+		var studentID uuid.UUID
+
+		rows.Scan(&studentID)
+
+		studentIDs = append(studentIDs, studentID)
+	}
+
+	return studentIDs, nil
+}
+
+// IsStudentStatusActive reports whether a student in the status still belongs to the school.
+func IsStudentStatusActive(status string) bool {
+	return status != STUDENT_WITHDRAWN && status != STUDENT_EXPELLED
+}
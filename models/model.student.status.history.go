@@ -0,0 +1,122 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	uuid "github.com/satori/go.uuid"
+	"school/helpers"
+	"time"
+)
+
+type (
+	StudentStatusHistoryModel struct {
+		ID          uuid.UUID
+		StudentID   uuid.UUID
+		FromStatus  string
+		ToStatus    string
+		Reason      string
+		EffectiveAt time.Time
+		CreatedBy   uuid.UUID
+		CreatedAt   time.Time
+	}
+
+	StudentStatusHistoryResponse struct {
+		ID          uuid.UUID `json:"id"`
+		StudentID   uuid.UUID `json:"student_id"`
+		FromStatus  string    `json:"from_status"`
+		ToStatus    string    `json:"to_status"`
+		Reason      string    `json:"reason"`
+		EffectiveAt time.Time `json:"effective_at"`
+		CreatedBy   uuid.UUID `json:"created_by"`
+		CreatedAt   time.Time `json:"created_at"`
+	}
+)
+
+func (s StudentStatusHistoryModel) Response() StudentStatusHistoryResponse {
+	return StudentStatusHistoryResponse{
+		ID:          s.ID,
+		StudentID:   s.StudentID,
+		FromStatus:  s.FromStatus,
+		ToStatus:    s.ToStatus,
+		Reason:      s.Reason,
+		EffectiveAt: s.EffectiveAt,
+		CreatedBy:   s.CreatedBy,
+		CreatedAt:   s.CreatedAt,
+	}
+}
+
+func GetAllStudentStatusHistoryByStudent(ctx context.Context, db *sql.DB, studentID uuid.UUID) (
+	[]StudentStatusHistoryModel, error) {
+
+	query := fmt.Sprintf(`
+		SELECT
+			id,
+			student_id,
+			from_status,
+			to_status,
+			reason,
+			effective_at,
+			created_by,
+			created_at
+		FROM student_status_history
+		WHERE student_id = $1
+		ORDER BY effective_at ASC, created_at ASC`)
+
+	rows, err := db.QueryContext(ctx, query, studentID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var histories []StudentStatusHistoryModel
+	for rows.Next() {
+		var history StudentStatusHistoryModel
+
+		rows.Scan(
+			&history.ID,
+			&history.StudentID,
+			&history.FromStatus,
+			&history.ToStatus,
+			&history.Reason,
+			&history.EffectiveAt,
+			&history.CreatedBy,
+			&history.CreatedAt,
+		)
+
+		histories = append(histories, history)
+	}
+
+	return histories, nil
+
+}
+
+func (s *StudentStatusHistoryModel) Insert(ctx context.Context, db helpers.Queryer) error {
+
+	query := fmt.Sprintf(`
+		INSERT INTO student_status_history(
+			student_id,
+			from_status,
+			to_status,
+			reason,
+			effective_at,
+			created_by,
+			created_at)
+		VALUES(
+		$1,$2,$3,$4,$5,$6,now())
+		RETURNING id, created_at`)
+
+	err := db.QueryRowContext(ctx, query,
+		s.StudentID, s.FromStatus, s.ToStatus, s.Reason, s.EffectiveAt, s.CreatedBy).Scan(
+		&s.ID, &s.CreatedAt,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+
+}
@@ -0,0 +1,259 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/lib/pq"
+	uuid "github.com/satori/go.uuid"
+	"school/helpers"
+	"time"
+)
+
+type (
+	StudentWaitlistModel struct {
+		ID        uuid.UUID
+		SessionID uuid.UUID
+		StudentID uuid.UUID
+		IsDelete  bool
+		CreatedBy uuid.UUID
+		CreatedAt time.Time
+		UpdatedBy uuid.NullUUID
+		UpdatedAt pq.NullTime
+	}
+
+	StudentWaitlistResponse struct {
+		ID        uuid.UUID       `json:"id"`
+		Session   SessionResponse `json:"session"`
+		Position  int             `json:"position"`
+		IsDelete  bool            `json:"is_delete"`
+		CreatedBy uuid.UUID       `json:"created_by"`
+		CreatedAt time.Time       `json:"created_at"`
+		UpdatedBy uuid.UUID       `json:"updated_by"`
+		UpdatedAt time.Time       `json:"updated_at"`
+	}
+)
+
+func (s StudentWaitlistModel) Response(ctx context.Context, db *sql.DB, logger *helpers.Logger) (StudentWaitlistResponse, error) {
+
+	session, err := GetOneSession(ctx, db, s.SessionID)
+	if err != nil {
+		logger.Err.Printf(`model.student.waitlist.go/GetOneSession/%v`, err)
+		return StudentWaitlistResponse{}, err
+	}
+
+	sessionResponse, err := session.Response(ctx, db, logger)
+	if err != nil {
+		logger.Err.Printf(`model.student.waitlist.go/sessionResponse/%v`, err)
+		return StudentWaitlistResponse{}, err
+	}
+
+	position, err := GetStudentWaitlistPosition(ctx, db, s)
+	if err != nil {
+		logger.Err.Printf(`model.student.waitlist.go/GetStudentWaitlistPosition/%v`, err)
+		return StudentWaitlistResponse{}, err
+	}
+
+	return StudentWaitlistResponse{
+		ID:        s.ID,
+		Session:   sessionResponse,
+		Position:  position,
+		IsDelete:  s.IsDelete,
+		CreatedBy: s.CreatedBy,
+		CreatedAt: s.CreatedAt,
+		UpdatedBy: s.UpdatedBy.UUID,
+		UpdatedAt: s.UpdatedAt.Time,
+	}, nil
+}
+
+func GetStudentWaitlistPosition(ctx context.Context, db *sql.DB, waitlist StudentWaitlistModel) (int, error) {
+
+	query := fmt.Sprintf(`
+		SELECT
+			COUNT(id)
+		FROM student_waitlist
+		WHERE is_delete = false
+		AND session_id = $1
+		AND created_at <= $2`)
+
+	var position int
+	err := db.QueryRowContext(ctx, query, waitlist.SessionID, waitlist.CreatedAt).Scan(&position)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return position, nil
+
+}
+
+func GetOneStudentWaitlistBySessionAndStudentID(ctx context.Context, db helpers.Queryer, sessionID uuid.UUID,
+	studentID uuid.UUID) (StudentWaitlistModel, error) {
+
+	query := fmt.Sprintf(`
+		SELECT
+			id,
+			session_id,
+			student_id,
+			is_delete,
+			created_by,
+			created_at,
+			updated_by,
+			updated_at
+		FROM student_waitlist
+		WHERE is_delete = false
+		AND session_id = $1
+		AND student_id = $2`)
+
+	var waitlist StudentWaitlistModel
+	err := db.QueryRowContext(ctx, query, sessionID, studentID).Scan(
+		&waitlist.ID,
+		&waitlist.SessionID,
+		&waitlist.StudentID,
+		&waitlist.IsDelete,
+		&waitlist.CreatedBy,
+		&waitlist.CreatedAt,
+		&waitlist.UpdatedBy,
+		&waitlist.UpdatedAt,
+	)
+
+	if err != nil {
+		return StudentWaitlistModel{}, err
+	}
+
+	return waitlist, nil
+
+}
+
+func GetFirstStudentWaitlistBySession(ctx context.Context, db helpers.Queryer, sessionID uuid.UUID) (
+	StudentWaitlistModel, error) {
+
+	query := fmt.Sprintf(`
+		SELECT
+			id,
+			session_id,
+			student_id,
+			is_delete,
+			created_by,
+			created_at,
+			updated_by,
+			updated_at
+		FROM student_waitlist
+		WHERE is_delete = false
+		AND session_id = $1
+		ORDER BY created_at ASC
+		LIMIT 1`)
+
+	var waitlist StudentWaitlistModel
+	err := db.QueryRowContext(ctx, query, sessionID).Scan(
+		&waitlist.ID,
+		&waitlist.SessionID,
+		&waitlist.StudentID,
+		&waitlist.IsDelete,
+		&waitlist.CreatedBy,
+		&waitlist.CreatedAt,
+		&waitlist.UpdatedBy,
+		&waitlist.UpdatedAt,
+	)
+
+	if err != nil {
+		return StudentWaitlistModel{}, err
+	}
+
+	return waitlist, nil
+
+}
+
+func GetAllStudentWaitlistByStudent(ctx context.Context, db *sql.DB, filter helpers.Filter) (
+	[]StudentWaitlistModel, error) {
+
+	query := fmt.Sprintf(`
+		SELECT
+			id,
+			session_id,
+			student_id,
+			is_delete,
+			created_by,
+			created_at,
+			updated_by,
+			updated_at
+		FROM student_waitlist
+		WHERE is_delete = false
+		AND student_id = $1
+		ORDER BY created_at ASC
+		LIMIT $2 OFFSET $3`)
+
+	rows, err := db.QueryContext(ctx, query, filter.StudentID, filter.Limit, filter.Offset)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var waitlists []StudentWaitlistModel
+	for rows.Next() {
+		var waitlist StudentWaitlistModel
+
+		rows.Scan(
+			&waitlist.ID,
+			&waitlist.SessionID,
+			&waitlist.StudentID,
+			&waitlist.IsDelete,
+			&waitlist.CreatedBy,
+			&waitlist.CreatedAt,
+			&waitlist.UpdatedBy,
+			&waitlist.UpdatedAt,
+		)
+
+		waitlists = append(waitlists, waitlist)
+	}
+
+	return waitlists, nil
+
+}
+
+func (s *StudentWaitlistModel) Insert(ctx context.Context, db helpers.Queryer) error {
+
+	query := fmt.Sprintf(`
+		INSERT INTO student_waitlist(
+			session_id,
+			student_id,
+			created_by,
+			created_at)
+		VALUES(
+		$1,$2,$3,now())
+		RETURNING id, created_at,is_delete`)
+
+	err := db.QueryRowContext(ctx, query,
+		s.SessionID, s.StudentID, s.CreatedBy).Scan(
+		&s.ID, &s.CreatedAt, &s.IsDelete,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+
+}
+
+func (s *StudentWaitlistModel) Delete(ctx context.Context, db helpers.Queryer) error {
+
+	query := fmt.Sprintf(`
+		UPDATE student_waitlist
+		SET
+			is_delete=true,
+			updated_by=$1,
+			updated_at=NOW()
+		WHERE id=$2`)
+
+	_, err := db.ExecContext(ctx, query,
+		s.UpdatedBy, s.ID)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -12,40 +12,46 @@ import (
 
 type (
 	SubjectModel struct {
-		ID          uuid.UUID
-		Name        string
-		Description string
-		Duration    int
-		IsDelete    bool
-		CreatedBy   uuid.UUID
-		CreatedAt   time.Time
-		UpdatedBy   uuid.NullUUID
-		UpdatedAt   pq.NullTime
+		ID              uuid.UUID
+		Name            string
+		Description     string
+		Duration        int
+		CreditHours     int
+		GradingSchemeID uuid.NullUUID
+		IsDelete        bool
+		CreatedBy       uuid.UUID
+		CreatedAt       time.Time
+		UpdatedBy       uuid.NullUUID
+		UpdatedAt       pq.NullTime
 	}
 	SubjectResponse struct {
-		ID          uuid.UUID `json:"id"`
-		Name        string    `json:"name"`
-		Description string    `json:"description"`
-		Duration    int       `json:"duration"`
-		IsDelete    bool      `json:"is_delete"`
-		CreatedBy   uuid.UUID `json:"created_by"`
-		CreatedAt   time.Time `json:"created_at"`
-		UpdatedBy   uuid.UUID `json:"updated_by"`
-		UpdatedAt   time.Time `json:"updated_at"`
+		ID              uuid.UUID `json:"id"`
+		Name            string    `json:"name"`
+		Description     string    `json:"description"`
+		Duration        int       `json:"duration"`
+		CreditHours     int       `json:"credit_hours"`
+		GradingSchemeID uuid.UUID `json:"grading_scheme_id"`
+		IsDelete        bool      `json:"is_delete"`
+		CreatedBy       uuid.UUID `json:"created_by"`
+		CreatedAt       time.Time `json:"created_at"`
+		UpdatedBy       uuid.UUID `json:"updated_by"`
+		UpdatedAt       time.Time `json:"updated_at"`
 	}
 )
 
 func (s SubjectModel) Response() SubjectResponse {
 	return SubjectResponse{
-		ID:          s.ID,
-		Name:        s.Name,
-		Description: s.Description,
-		Duration:    s.Duration,
-		IsDelete:    s.IsDelete,
-		CreatedBy:   s.CreatedBy,
-		CreatedAt:   s.CreatedAt,
-		UpdatedBy:   s.UpdatedBy.UUID,
-		UpdatedAt:   s.UpdatedAt.Time,
+		ID:              s.ID,
+		Name:            s.Name,
+		Description:     s.Description,
+		Duration:        s.Duration,
+		CreditHours:     s.CreditHours,
+		GradingSchemeID: s.GradingSchemeID.UUID,
+		IsDelete:        s.IsDelete,
+		CreatedBy:       s.CreatedBy,
+		CreatedAt:       s.CreatedAt,
+		UpdatedBy:       s.UpdatedBy.UUID,
+		UpdatedAt:       s.UpdatedAt.Time,
 	}
 }
 
@@ -57,6 +63,8 @@ func GetOneSubject(ctx context.Context, db *sql.DB, subjectID uuid.UUID) (Subjec
 			name,
 			description,
 			duration,
+			credit_hours,
+			grading_scheme_id,
 			is_delete,
 			created_by,
 			created_at,
@@ -73,6 +81,8 @@ func GetOneSubject(ctx context.Context, db *sql.DB, subjectID uuid.UUID) (Subjec
 		&subject.Name,
 		&subject.Description,
 		&subject.Duration,
+		&subject.CreditHours,
+		&subject.GradingSchemeID,
 		&subject.IsDelete,
 		&subject.CreatedBy,
 		&subject.CreatedAt,
@@ -97,11 +107,13 @@ func GetAllSubject(ctx context.Context, db *sql.DB, filter helpers.Filter) ([]Su
 	}
 
 	query := fmt.Sprintf(`
-		SELECTZ
+		SELECT
 			id,
 			name,
 			description,
 			duration,
+			credit_hours,
+			grading_scheme_id,
 			is_delete,
 			created_by,
 			created_at,
@@ -129,6 +141,8 @@ func GetAllSubject(ctx context.Context, db *sql.DB, filter helpers.Filter) ([]Su
 			&subject.Name,
 			&subject.Description,
 			&subject.Duration,
+			&subject.CreditHours,
+			&subject.GradingSchemeID,
 			&subject.IsDelete,
 			&subject.CreatedBy,
 			&subject.CreatedAt,
@@ -150,14 +164,16 @@ func (s *SubjectModel) Insert(ctx context.Context, db *sql.DB) error {
 			name,
 			description,
 			duration,
+			credit_hours,
+			grading_scheme_id,
 			created_by,
 			created_at)
 		VALUES(
-		$1,$2,$3,$4,now())
+		$1,$2,$3,$4,$5,$6,now())
 		RETURNING id, created_at,is_delete`)
 
 	err := db.QueryRowContext(ctx, query,
-		s.Name, s.Description, s.Duration, s.CreatedBy).Scan(
+		s.Name, s.Description, s.Duration, s.CreditHours, s.GradingSchemeID, s.CreatedBy).Scan(
 		&s.ID, &s.CreatedAt, &s.IsDelete,
 	)
 
@@ -177,13 +193,15 @@ func (s *SubjectModel) Update(ctx context.Context, db *sql.DB) error {
 			name=$1,
 			description=$2,
 			duration=$3,
+			credit_hours=$4,
+			grading_scheme_id=$5,
 			updated_at=NOW(),
-			updated_by=$4
-		WHERE id=$5
+			updated_by=$6
+		WHERE id=$7
 		RETURNING id,created_at,updated_at,created_by,is_delete`)
 
 	err := db.QueryRowContext(ctx, query,
-		s.Name, s.Description, s.Duration, s.UpdatedBy, s.ID).Scan(
+		s.Name, s.Description, s.Duration, s.CreditHours, s.GradingSchemeID, s.UpdatedBy, s.ID).Scan(
 		&s.ID, &s.CreatedAt, &s.UpdatedAt, &s.CreatedBy, &s.IsDelete,
 	)
 
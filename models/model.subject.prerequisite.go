@@ -0,0 +1,224 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/lib/pq"
+	uuid "github.com/satori/go.uuid"
+	"school/helpers"
+	"time"
+)
+
+type (
+	SubjectPrerequisiteModel struct {
+		ID                    uuid.UUID
+		SubjectID             uuid.UUID
+		PrerequisiteSubjectID uuid.UUID
+		MinGrade              string
+		IsDelete              bool
+		CreatedBy             uuid.UUID
+		CreatedAt             time.Time
+		UpdatedBy             uuid.NullUUID
+		UpdatedAt             pq.NullTime
+	}
+
+	SubjectPrerequisiteResponse struct {
+		ID                  uuid.UUID       `json:"id"`
+		SubjectID           uuid.UUID       `json:"subject_id"`
+		PrerequisiteSubject SubjectResponse `json:"prerequisite_subject"`
+		MinGrade            string          `json:"min_grade"`
+		IsDelete            bool            `json:"is_delete"`
+		CreatedBy           uuid.UUID       `json:"created_by"`
+		CreatedAt           time.Time       `json:"created_at"`
+		UpdatedBy           uuid.UUID       `json:"updated_by"`
+		UpdatedAt           time.Time       `json:"updated_at"`
+	}
+)
+
+func (s SubjectPrerequisiteModel) Response(ctx context.Context, db *sql.DB, logger *helpers.Logger) (
+	SubjectPrerequisiteResponse, error) {
+
+	subject, err := GetOneSubject(ctx, db, s.PrerequisiteSubjectID)
+	if err != nil {
+		logger.Err.Printf(`model.subject.prerequisite.go/GetOneSubject/%v`, err)
+		return SubjectPrerequisiteResponse{}, err
+	}
+
+	return SubjectPrerequisiteResponse{
+		ID:                  s.ID,
+		SubjectID:           s.SubjectID,
+		PrerequisiteSubject: subject.Response(),
+		MinGrade:            s.MinGrade,
+		IsDelete:            s.IsDelete,
+		CreatedBy:           s.CreatedBy,
+		CreatedAt:           s.CreatedAt,
+		UpdatedBy:           s.UpdatedBy.UUID,
+		UpdatedAt:           s.UpdatedAt.Time,
+	}, nil
+}
+
+func GetOneSubjectPrerequisite(ctx context.Context, db *sql.DB, prerequisiteID uuid.UUID) (
+	SubjectPrerequisiteModel, error) {
+
+	query := fmt.Sprintf(`
+		SELECT
+			id,
+			subject_id,
+			prerequisite_subject_id,
+			min_grade,
+			is_delete,
+			created_by,
+			created_at,
+			updated_by,
+			updated_at
+		FROM subject_prerequisite
+		WHERE is_delete = false
+		AND id = $1`)
+
+	var prerequisite SubjectPrerequisiteModel
+	err := db.QueryRowContext(ctx, query, prerequisiteID).Scan(
+		&prerequisite.ID,
+		&prerequisite.SubjectID,
+		&prerequisite.PrerequisiteSubjectID,
+		&prerequisite.MinGrade,
+		&prerequisite.IsDelete,
+		&prerequisite.CreatedBy,
+		&prerequisite.CreatedAt,
+		&prerequisite.UpdatedBy,
+		&prerequisite.UpdatedAt,
+	)
+
+	if err != nil {
+		return SubjectPrerequisiteModel{}, err
+	}
+
+	return prerequisite, nil
+
+}
+
+func GetAllSubjectPrerequisiteBySubject(ctx context.Context, db *sql.DB, filter helpers.Filter) (
+	[]SubjectPrerequisiteModel, error) {
+
+	var subjectIDQuery string
+
+	if filter.SubjectID != uuid.Nil {
+		subjectIDQuery = fmt.Sprintf(`AND subject_id = '%s'`, filter.SubjectID)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT
+			id,
+			subject_id,
+			prerequisite_subject_id,
+			min_grade,
+			is_delete,
+			created_by,
+			created_at,
+			updated_by,
+			updated_at
+		FROM subject_prerequisite
+		WHERE is_delete = false
+		%s
+		LIMIT $1 OFFSET $2`, subjectIDQuery)
+
+	rows, err := db.QueryContext(ctx, query, filter.Limit, filter.Offset)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var prerequisites []SubjectPrerequisiteModel
+	for rows.Next() {
+		var prerequisite SubjectPrerequisiteModel
+
+		rows.Scan(
+			&prerequisite.ID,
+			&prerequisite.SubjectID,
+			&prerequisite.PrerequisiteSubjectID,
+			&prerequisite.MinGrade,
+			&prerequisite.IsDelete,
+			&prerequisite.CreatedBy,
+			&prerequisite.CreatedAt,
+			&prerequisite.UpdatedBy,
+			&prerequisite.UpdatedAt,
+		)
+
+		prerequisites = append(prerequisites, prerequisite)
+	}
+
+	return prerequisites, nil
+
+}
+
+func (s *SubjectPrerequisiteModel) Insert(ctx context.Context, db *sql.DB) error {
+
+	query := fmt.Sprintf(`
+		INSERT INTO subject_prerequisite(
+			subject_id,
+			prerequisite_subject_id,
+			min_grade,
+			created_by,
+			created_at)
+		VALUES(
+		$1,$2,$3,$4,now())
+		RETURNING id, created_at,is_delete`)
+
+	err := db.QueryRowContext(ctx, query,
+		s.SubjectID, s.PrerequisiteSubjectID, s.MinGrade, s.CreatedBy).Scan(
+		&s.ID, &s.CreatedAt, &s.IsDelete,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+
+}
+
+func (s *SubjectPrerequisiteModel) Update(ctx context.Context, db *sql.DB) error {
+
+	query := fmt.Sprintf(`
+		UPDATE subject_prerequisite
+		SET
+			min_grade=$1,
+			updated_at=NOW(),
+			updated_by=$2
+		WHERE id=$3
+		RETURNING id,subject_id,prerequisite_subject_id,created_at,updated_at,created_by,is_delete`)
+
+	err := db.QueryRowContext(ctx, query,
+		s.MinGrade, s.UpdatedBy, s.ID).Scan(
+		&s.ID, &s.SubjectID, &s.PrerequisiteSubjectID, &s.CreatedAt, &s.UpdatedAt, &s.CreatedBy, &s.IsDelete,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+
+}
+
+func (s *SubjectPrerequisiteModel) Delete(ctx context.Context, db *sql.DB) error {
+
+	query := fmt.Sprintf(`
+		UPDATE subject_prerequisite
+		SET
+			is_delete=true,
+			updated_by=$1,
+			updated_at=NOW()
+		WHERE id=$2`)
+
+	_, err := db.ExecContext(ctx, query,
+		s.UpdatedBy, s.ID)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
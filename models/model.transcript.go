@@ -0,0 +1,93 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	uuid "github.com/satori/go.uuid"
+	"time"
+)
+
+type (
+	TranscriptModel struct {
+		ResultID        uuid.UUID
+		SubjectID       uuid.UUID
+		SubjectName     string
+		CreditHours     int
+		IntakeID        uuid.UUID
+		IntakeYear      string
+		IntakeTrimester int
+		IntakeStartDate time.Time
+		Marks           int
+		Grade           string
+		GradePoint      float64
+		GradingSchemeID uuid.NullUUID
+		IsPassFail      bool
+		PassMark        int
+	}
+)
+
+func GetAllTranscriptByStudent(ctx context.Context, db *sql.DB, studentID uuid.UUID) ([]TranscriptModel, error) {
+
+	query := fmt.Sprintf(`
+		SELECT
+			r.id,
+			su.id,
+			su.name,
+			su.credit_hours,
+			i.id,
+			i.year,
+			i.trimester,
+			i.start_date,
+			r.marks,
+			r.grade,
+			r.grade_point,
+			r.grading_scheme_id,
+			COALESCE(gs.is_pass_fail, false),
+			COALESCE(gs.pass_mark, 0)
+		FROM result r
+		INNER JOIN student_enroll se ON r.student_enroll_id = se.id
+		INNER JOIN session s ON se.session_id = s.id
+		INNER JOIN subject su ON s.subject_id = su.id
+		INNER JOIN intake i ON s.intake_id = i.id
+		LEFT JOIN grading_scheme gs ON r.grading_scheme_id = gs.id
+		WHERE r.is_delete = false
+		AND se.is_delete = false
+		AND r.grade != ''
+		AND r.status = $2
+		AND se.student_id = $1
+		ORDER BY i.start_date ASC, su.name ASC`)
+
+	rows, err := db.QueryContext(ctx, query, studentID, RESULT_PUBLISHED)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var transcripts []TranscriptModel
+	for rows.Next() {
+		var transcript TranscriptModel
+		rows.Scan(
+			&transcript.ResultID,
+			&transcript.SubjectID,
+			&transcript.SubjectName,
+			&transcript.CreditHours,
+			&transcript.IntakeID,
+			&transcript.IntakeYear,
+			&transcript.IntakeTrimester,
+			&transcript.IntakeStartDate,
+			&transcript.Marks,
+			&transcript.Grade,
+			&transcript.GradePoint,
+			&transcript.GradingSchemeID,
+			&transcript.IsPassFail,
+			&transcript.PassMark,
+		)
+
+		transcripts = append(transcripts, transcript)
+	}
+
+	return transcripts, nil
+
+}
@@ -0,0 +1,96 @@
+package routers
+
+import (
+	"github.com/gorilla/mux"
+	uuid "github.com/satori/go.uuid"
+	"net/http"
+	"school/api"
+	"school/helpers"
+)
+
+func HandlerAcademicPeriodListByIntake(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	params := mux.Vars(r)
+
+	intakeID, err := uuid.FromString(params["id"])
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerAcademicPeriodListByIntake/parseID",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	param := api.AcademicPeriodListParam{
+		IntakeID: intakeID,
+		Type:     r.URL.Query().Get("type"),
+	}
+
+	return academicPeriodService.ListByIntake(ctx, param)
+}
+
+func HandlerAcademicPeriodAdd(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	params := mux.Vars(r)
+
+	intakeID, err := uuid.FromString(params["id"])
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerAcademicPeriodAdd/parseID",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	var param api.AcademicPeriodAddParam
+
+	err = helpers.ParseBodyRequestData(ctx, r, &param)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerAcademicPeriodAdd/ParseBodyRequestData",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	param.IntakeID = intakeID
+
+	return academicPeriodService.Add(ctx, param)
+}
+
+func HandlerAcademicPeriodUpdate(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	params := mux.Vars(r)
+
+	academicPeriodID, err := uuid.FromString(params["id"])
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerAcademicPeriodUpdate/parseID",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	var param api.AcademicPeriodUpdateParam
+
+	err = helpers.ParseBodyRequestData(ctx, r, &param)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerAcademicPeriodUpdate/ParseBodyRequestData",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	param.ID = academicPeriodID
+
+	return academicPeriodService.Update(ctx, param)
+}
+
+func HandlerAcademicPeriodDelete(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	params := mux.Vars(r)
+
+	academicPeriodID, err := uuid.FromString(params["id"])
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerAcademicPeriodDelete/parseID",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	param := api.AcademicPeriodDeleteParam{ID: academicPeriodID}
+
+	return academicPeriodService.Delete(ctx, param)
+}
@@ -0,0 +1,167 @@
+package routers
+
+import (
+	"github.com/gorilla/mux"
+	uuid "github.com/satori/go.uuid"
+	"net/http"
+	"school/api"
+	"school/helpers"
+)
+
+func HandlerAssessmentListBySession(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	params := mux.Vars(r)
+
+	sessionID, err := uuid.FromString(params["id"])
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerAssessmentListBySession/parseID",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	param := api.AssessmentListParam{SessionID: sessionID}
+
+	return assessmentService.ListBySession(ctx, param)
+}
+
+func HandlerAssessmentAdd(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	params := mux.Vars(r)
+
+	sessionID, err := uuid.FromString(params["id"])
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerAssessmentAdd/parseID",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	var param api.AssessmentAddParam
+
+	err = helpers.ParseBodyRequestData(ctx, r, &param)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerAssessmentAdd/ParseBodyRequestData",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	param.SessionID = sessionID
+
+	return assessmentService.Add(ctx, param)
+}
+
+func HandlerAssessmentUpdate(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	params := mux.Vars(r)
+
+	sessionID, err := uuid.FromString(params["id"])
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerAssessmentUpdate/parseID",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	assessmentID, err := uuid.FromString(params["assessment_id"])
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerAssessmentUpdate/parseAssessmentID",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	var param api.AssessmentUpdateParam
+
+	err = helpers.ParseBodyRequestData(ctx, r, &param)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerAssessmentUpdate/ParseBodyRequestData",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	param.ID = assessmentID
+	param.SessionID = sessionID
+
+	return assessmentService.Update(ctx, param)
+}
+
+func HandlerAssessmentDelete(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	params := mux.Vars(r)
+
+	sessionID, err := uuid.FromString(params["id"])
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerAssessmentDelete/parseID",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	assessmentID, err := uuid.FromString(params["assessment_id"])
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerAssessmentDelete/parseAssessmentID",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	param := api.AssessmentDeleteParam{
+		ID:        assessmentID,
+		SessionID: sessionID,
+	}
+
+	return assessmentService.Delete(ctx, param)
+}
+
+func HandlerAssessmentScoreList(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	params := mux.Vars(r)
+
+	sessionID, err := uuid.FromString(params["id"])
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerAssessmentScoreList/parseID",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	assessmentID, err := uuid.FromString(params["assessment_id"])
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerAssessmentScoreList/parseAssessmentID",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	param := api.AssessmentScoreListParam{
+		ID:        assessmentID,
+		SessionID: sessionID,
+	}
+
+	return assessmentService.ListScore(ctx, param)
+}
+
+func HandlerAssessmentScoreUpdate(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	params := mux.Vars(r)
+
+	sessionID, err := uuid.FromString(params["id"])
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerAssessmentScoreUpdate/parseID",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	assessmentID, err := uuid.FromString(params["assessment_id"])
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerAssessmentScoreUpdate/parseAssessmentID",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	var param api.AssessmentScoreUpdateParam
+
+	err = helpers.ParseBodyRequestData(ctx, r, &param)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerAssessmentScoreUpdate/ParseBodyRequestData",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	param.ID = assessmentID
+	param.SessionID = sessionID
+
+	return assessmentService.UpdateScore(ctx, param)
+}
@@ -109,3 +109,109 @@ func HandlerAttendanceListByClass(w http.ResponseWriter, r *http.Request) (inter
 	}
 	return attendanceService.ListByClass(ctx, filter, param)
 }
+
+func HandlerAttendanceUpdateByClass(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	params := mux.Vars(r)
+
+	classID, err := uuid.FromString(params["id"])
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerAttendanceUpdateByClass/parseID",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	var param api.AttendanceUpdateByClassParam
+
+	err = helpers.ParseBodyRequestData(ctx, r, &param)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerAttendanceUpdateByClass/ParseBodyRequestData",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	param.ClassID = classID
+
+	return attendanceService.UpdateByClass(ctx, param)
+}
+
+func HandlerAttendanceSummaryBySession(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	params := mux.Vars(r)
+
+	sessionID, err := uuid.FromString(params["id"])
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerAttendanceSummaryBySession/parseID",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	param := api.AttendanceSummaryBySessionParam{SessionID: sessionID}
+
+	return attendanceService.SummaryBySession(ctx, param)
+}
+
+func HandlerAttendanceSummaryByOneStudent(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	return attendanceService.SummaryByOneStudent(ctx)
+}
+
+func HandlerAttendanceListBelowThreshold(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	filter, err := helpers.ParseFilter(ctx, r)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerAttendanceListBelowThreshold/parseFilter",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	param := api.AttendanceBelowThresholdParam{IntakeID: filter.IntakeID}
+
+	return attendanceService.ListBelowThreshold(ctx, param)
+}
+
+func HandlerAttendanceOpenCheckIn(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	params := mux.Vars(r)
+
+	classID, err := uuid.FromString(params["id"])
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerAttendanceOpenCheckIn/parseID",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	param := api.AttendanceCheckInOpenParam{ClassID: classID}
+
+	return attendanceService.OpenCheckIn(ctx, param)
+}
+
+func HandlerAttendanceCheckIn(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	params := mux.Vars(r)
+
+	classID, err := uuid.FromString(params["id"])
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerAttendanceCheckIn/parseID",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	var param api.AttendanceCheckInParam
+
+	err = helpers.ParseBodyRequestData(ctx, r, &param)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerAttendanceCheckIn/ParseBodyRequestData",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	param.ClassID = classID
+
+	return attendanceService.CheckIn(ctx, param)
+}
@@ -0,0 +1,56 @@
+package routers
+
+import (
+	"net/http"
+	"school/api"
+	"school/helpers"
+	"school/session"
+)
+
+func HandlerStudentCalendarTokenAdd(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	return calendarService.TokenAdd(ctx, api.CalendarTokenParam{Role: session.STUDENT_ROLE})
+}
+
+func HandlerStudentCalendarTokenDelete(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	return calendarService.TokenDelete(ctx, api.CalendarTokenParam{Role: session.STUDENT_ROLE})
+}
+
+func HandlerLecturerCalendarTokenAdd(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	return calendarService.TokenAdd(ctx, api.CalendarTokenParam{Role: session.LECTURER_ROLE})
+}
+
+func HandlerLecturerCalendarTokenDelete(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	return calendarService.TokenDelete(ctx, api.CalendarTokenParam{Role: session.LECTURER_ROLE})
+}
+
+func HandlerStudentCalendarFeed(w http.ResponseWriter, r *http.Request) (*helpers.File, *helpers.Error) {
+
+	ctx := r.Context()
+
+	return calendarService.Feed(ctx, api.CalendarFeedParam{
+		Role:  session.STUDENT_ROLE,
+		Token: r.FormValue("token"),
+	})
+}
+
+func HandlerLecturerCalendarFeed(w http.ResponseWriter, r *http.Request) (*helpers.File, *helpers.Error) {
+
+	ctx := r.Context()
+
+	return calendarService.Feed(ctx, api.CalendarFeedParam{
+		Role:  session.LECTURER_ROLE,
+		Token: r.FormValue("token"),
+	})
+}
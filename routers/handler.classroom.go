@@ -6,6 +6,8 @@ import (
 	"net/http"
 	"school/api"
 	"school/helpers"
+	"strconv"
+	"time"
 )
 
 func HandlerClassroomList(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
@@ -100,3 +102,149 @@ func HandlerClassroomDelete(w http.ResponseWriter, r *http.Request) (interface{}
 
 	return classroomService.Delete(ctx, param)
 }
+
+func HandlerClassroomBookingList(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	params := mux.Vars(r)
+
+	classroomID, err := uuid.FromString(params["id"])
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerClassroomBookingList/parseID",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	param := api.ClassroomBookingListParam{ClassroomID: classroomID}
+
+	if value := r.URL.Query().Get("from"); value != "" {
+		param.From, err = time.Parse("2006-01-02", value)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, "handler", "HandlerClassroomBookingList/parseFrom",
+				helpers.BadRequestMessage, http.StatusBadRequest)
+		}
+	}
+
+	if value := r.URL.Query().Get("to"); value != "" {
+		param.To, err = time.Parse("2006-01-02", value)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, "handler", "HandlerClassroomBookingList/parseTo",
+				helpers.BadRequestMessage, http.StatusBadRequest)
+		}
+	}
+
+	return classroomService.BookingList(ctx, param)
+}
+
+func HandlerClassroomBookingAdd(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	params := mux.Vars(r)
+
+	classroomID, err := uuid.FromString(params["id"])
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerClassroomBookingAdd/parseID",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	var param api.ClassroomBookingAddParam
+
+	err = helpers.ParseBodyRequestData(ctx, r, &param)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerClassroomBookingAdd/ParseBodyRequestData",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	param.ClassroomID = classroomID
+
+	return classroomService.BookingAdd(ctx, param)
+}
+
+func HandlerClassroomBookingDelete(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	params := mux.Vars(r)
+
+	bookingID, err := uuid.FromString(params["id"])
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerClassroomBookingDelete/parseID",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	param := api.ClassroomBookingDeleteParam{ID: bookingID}
+
+	return classroomService.BookingDelete(ctx, param)
+}
+
+func HandlerClassroomFree(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	query := r.URL.Query()
+
+	var param api.ClassroomFreeParam
+	var err error
+
+	if value := query.Get("date"); value != "" {
+		param.Date, err = time.Parse("2006-01-02", value)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, "handler", "HandlerClassroomFree/parseDate",
+				helpers.BadRequestMessage, http.StatusBadRequest)
+		}
+	} else {
+		param.Day, err = strconv.Atoi(query.Get("day"))
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, "handler", "HandlerClassroomFree/parseDay",
+				helpers.BadRequestMessage, http.StatusBadRequest)
+		}
+	}
+
+	param.StartTime, err = time.Parse("15:04", query.Get("start_time"))
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerClassroomFree/parseStartTime",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	param.EndTime, err = time.Parse("15:04", query.Get("end_time"))
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerClassroomFree/parseEndTime",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	if value := query.Get("capacity"); value != "" {
+		param.Capacity, err = strconv.Atoi(value)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, "handler", "HandlerClassroomFree/parseCapacity",
+				helpers.BadRequestMessage, http.StatusBadRequest)
+		}
+	}
+
+	if value := query.Get("intake_id"); value != "" {
+		param.IntakeID, err = uuid.FromString(value)
+		if err != nil {
+			return nil, helpers.ErrorWrap(err, "handler", "HandlerClassroomFree/parseIntakeID",
+				helpers.BadRequestMessage, http.StatusBadRequest)
+		}
+	}
+
+	param.Features = query["feature"]
+
+	return classroomService.Free(ctx, param)
+}
+
+func HandlerClassroomUtilization(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	filter, err := helpers.ParseFilter(ctx, r)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerClassroomUtilization/parseFilter",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	param := api.ClassroomUtilizationParam{IntakeID: filter.IntakeID}
+
+	return classroomService.Utilization(ctx, param)
+}
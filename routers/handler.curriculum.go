@@ -0,0 +1,133 @@
+package routers
+
+import (
+	"github.com/gorilla/mux"
+	uuid "github.com/satori/go.uuid"
+	"net/http"
+	"school/api"
+	"school/helpers"
+)
+
+func HandlerCurriculumList(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	params := mux.Vars(r)
+
+	programID, err := uuid.FromString(params["id"])
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerCurriculumList/parseID",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	param := api.CurriculumListParam{ProgramID: programID}
+
+	return curriculumService.ListByProgram(ctx, param)
+}
+
+func HandlerCurriculumAdd(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	params := mux.Vars(r)
+
+	programID, err := uuid.FromString(params["id"])
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerCurriculumAdd/parseID",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	var param api.CurriculumAddParam
+
+	err = helpers.ParseBodyRequestData(ctx, r, &param)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerCurriculumAdd/ParseBodyRequestData",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	param.ProgramID = programID
+
+	return curriculumService.Add(ctx, param)
+}
+
+func HandlerCurriculumUpdate(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	params := mux.Vars(r)
+
+	programID, err := uuid.FromString(params["id"])
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerCurriculumUpdate/parseID",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	curriculumID, err := uuid.FromString(params["curriculum_id"])
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerCurriculumUpdate/parseCurriculumID",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	var param api.CurriculumUpdateParam
+
+	err = helpers.ParseBodyRequestData(ctx, r, &param)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerCurriculumUpdate/ParseBodyRequestData",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	param.ID = curriculumID
+	param.ProgramID = programID
+
+	return curriculumService.Update(ctx, param)
+}
+
+func HandlerCurriculumDelete(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	params := mux.Vars(r)
+
+	programID, err := uuid.FromString(params["id"])
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerCurriculumDelete/parseID",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	curriculumID, err := uuid.FromString(params["curriculum_id"])
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerCurriculumDelete/parseCurriculumID",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	param := api.CurriculumDeleteParam{
+		ID:        curriculumID,
+		ProgramID: programID,
+	}
+
+	return curriculumService.Delete(ctx, param)
+}
+
+func HandlerDegreeAudit(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	params := mux.Vars(r)
+
+	studentID, err := uuid.FromString(params["id"])
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerDegreeAudit/parseID",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	param := api.DegreeAuditParam{StudentID: studentID}
+
+	return curriculumService.DegreeAudit(ctx, param)
+}
+
+func HandlerDegreeAuditByOneStudent(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	return curriculumService.DegreeAuditByOneStudent(ctx)
+}
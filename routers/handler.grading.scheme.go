@@ -0,0 +1,120 @@
+package routers
+
+import (
+	"github.com/gorilla/mux"
+	uuid "github.com/satori/go.uuid"
+	"net/http"
+	"school/api"
+	"school/helpers"
+)
+
+func HandlerGradingSchemeList(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	filter, err := helpers.ParseFilter(ctx, r)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerGradingSchemeList/parseFilter",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+	return gradingSchemeService.List(ctx, filter)
+
+}
+
+func HandlerGradingSchemeDetail(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+	ctx := r.Context()
+
+	params := mux.Vars(r)
+
+	gradingSchemeID, err := uuid.FromString(params["id"])
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerGradingSchemeDetail/parseID",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	param := api.GradingSchemeDetailParam{ID: gradingSchemeID}
+
+	return gradingSchemeService.Detail(ctx, param)
+}
+
+func HandlerGradingSchemeAdd(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	var param api.GradingSchemeAddParam
+
+	err := helpers.ParseBodyRequestData(ctx, r, &param)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerGradingSchemeAdd/ParseBodyRequestData",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	return gradingSchemeService.Add(ctx, param)
+}
+
+func HandlerGradingSchemeUpdate(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	params := mux.Vars(r)
+
+	gradingSchemeID, err := uuid.FromString(params["id"])
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerGradingSchemeUpdate/parseID",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	var param api.GradingSchemeUpdateParam
+
+	err = helpers.ParseBodyRequestData(ctx, r, &param)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerGradingSchemeUpdate/ParseBodyRequestData",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	param.ID = gradingSchemeID
+
+	return gradingSchemeService.Update(ctx, param)
+}
+
+func HandlerGradingSchemeDelete(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	params := mux.Vars(r)
+
+	gradingSchemeID, err := uuid.FromString(params["id"])
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerGradingSchemeDelete/parseID",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	param := api.GradingSchemeDeleteParam{ID: gradingSchemeID}
+
+	return gradingSchemeService.Delete(ctx, param)
+}
+
+func HandlerGradingSchemeRegrade(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	params := mux.Vars(r)
+
+	gradingSchemeID, err := uuid.FromString(params["id"])
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerGradingSchemeRegrade/parseID",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	var param api.GradingSchemeRegradeParam
+
+	err = helpers.ParseBodyRequestData(ctx, r, &param)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerGradingSchemeRegrade/ParseBodyRequestData",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	param.ID = gradingSchemeID
+
+	return gradingSchemeService.Regrade(ctx, param)
+}
@@ -0,0 +1,73 @@
+package routers
+
+import (
+	"github.com/gorilla/mux"
+	uuid "github.com/satori/go.uuid"
+	"net/http"
+	"school/api"
+	"school/helpers"
+)
+
+func HandlerGraduationList(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	filter, err := helpers.ParseFilter(ctx, r)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerGraduationList/parseFilter",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	return graduationService.List(ctx, filter)
+}
+
+func HandlerGraduationEligibility(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	params := mux.Vars(r)
+
+	intakeID, err := uuid.FromString(params["id"])
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerGraduationEligibility/parseID",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	filter, err := helpers.ParseFilter(ctx, r)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerGraduationEligibility/parseFilter",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	param := api.GraduationEligibilityParam{
+		IntakeID:  intakeID,
+		ProgramID: filter.ProgramID,
+	}
+
+	return graduationService.Eligibility(ctx, param)
+}
+
+func HandlerGraduationConfirm(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	params := mux.Vars(r)
+
+	intakeID, err := uuid.FromString(params["id"])
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerGraduationConfirm/parseID",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	var param api.GraduationConfirmParam
+
+	err = helpers.ParseBodyRequestData(ctx, r, &param)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerGraduationConfirm/ParseBodyRequestData",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	param.IntakeID = intakeID
+
+	return graduationService.Confirm(ctx, param)
+}
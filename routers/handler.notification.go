@@ -0,0 +1,106 @@
+package routers
+
+import (
+	"github.com/gorilla/mux"
+	uuid "github.com/satori/go.uuid"
+	"net/http"
+	"school/api"
+	"school/helpers"
+	"school/session"
+)
+
+func HandlerStudentNotificationList(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	filter, err := helpers.ParseFilter(ctx, r)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerStudentNotificationList/parseFilter",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	return notificationService.List(ctx, api.NotificationListParam{
+		Role:   session.STUDENT_ROLE,
+		Filter: filter,
+	})
+}
+
+func HandlerStudentNotificationUnreadCount(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	return notificationService.UnreadCount(ctx, api.NotificationParam{Role: session.STUDENT_ROLE})
+}
+
+func HandlerStudentNotificationRead(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	params := mux.Vars(r)
+
+	notificationID, err := uuid.FromString(params["id"])
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerStudentNotificationRead/parseID",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	return notificationService.Read(ctx, api.NotificationParam{
+		ID:   notificationID,
+		Role: session.STUDENT_ROLE,
+	})
+}
+
+func HandlerStudentNotificationReadAll(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	return notificationService.ReadAll(ctx, api.NotificationParam{Role: session.STUDENT_ROLE})
+}
+
+func HandlerLecturerNotificationList(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	filter, err := helpers.ParseFilter(ctx, r)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerLecturerNotificationList/parseFilter",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	return notificationService.List(ctx, api.NotificationListParam{
+		Role:   session.LECTURER_ROLE,
+		Filter: filter,
+	})
+}
+
+func HandlerLecturerNotificationUnreadCount(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	return notificationService.UnreadCount(ctx, api.NotificationParam{Role: session.LECTURER_ROLE})
+}
+
+func HandlerLecturerNotificationRead(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	params := mux.Vars(r)
+
+	notificationID, err := uuid.FromString(params["id"])
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerLecturerNotificationRead/parseID",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	return notificationService.Read(ctx, api.NotificationParam{
+		ID:   notificationID,
+		Role: session.LECTURER_ROLE,
+	})
+}
+
+func HandlerLecturerNotificationReadAll(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	return notificationService.ReadAll(ctx, api.NotificationParam{Role: session.LECTURER_ROLE})
+}
@@ -143,3 +143,189 @@ func HandlerResultListByOneStudent(w http.ResponseWriter, r *http.Request) (inte
 	}
 	return resultService.ListByOneStudent(ctx, filter)
 }
+
+func HandlerResultExport(w http.ResponseWriter, r *http.Request) (*helpers.File, *helpers.Error) {
+
+	ctx := r.Context()
+
+	params := mux.Vars(r)
+
+	sessionID, err := uuid.FromString(params["id"])
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerResultExport/parseID",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	param := api.ResultExportParam{SessionID: sessionID}
+
+	return resultService.Export(ctx, param)
+}
+
+func HandlerResultImport(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	params := mux.Vars(r)
+
+	sessionID, err := uuid.FromString(params["id"])
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerResultImport/parseID",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	records, err := parseRecords(w, r)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerResultImport/parseRecords",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	param := api.ResultImportParam{
+		SessionID: sessionID,
+		DryRun:    r.FormValue("dry_run") == "true",
+		Records:   records,
+	}
+
+	return resultService.Import(ctx, param)
+}
+
+func HandlerResultSubmit(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	params := mux.Vars(r)
+
+	sessionID, err := uuid.FromString(params["id"])
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerResultSubmit/parseID",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	var param api.ResultWorkflowParam
+
+	err = helpers.ParseBodyRequestData(ctx, r, &param)
+	if err != nil {
+
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerResultSubmit/ParseBodyRequestData",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+
+	}
+
+	param.SessionID = sessionID
+
+	return resultService.Submit(ctx, param)
+}
+
+func HandlerResultApprove(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	params := mux.Vars(r)
+
+	sessionID, err := uuid.FromString(params["id"])
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerResultApprove/parseID",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	var param api.ResultWorkflowParam
+
+	err = helpers.ParseBodyRequestData(ctx, r, &param)
+	if err != nil {
+
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerResultApprove/ParseBodyRequestData",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+
+	}
+
+	param.SessionID = sessionID
+
+	return resultService.Approve(ctx, param)
+}
+
+func HandlerResultReturn(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	params := mux.Vars(r)
+
+	sessionID, err := uuid.FromString(params["id"])
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerResultReturn/parseID",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	var param api.ResultWorkflowParam
+
+	err = helpers.ParseBodyRequestData(ctx, r, &param)
+	if err != nil {
+
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerResultReturn/ParseBodyRequestData",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+
+	}
+
+	param.SessionID = sessionID
+
+	return resultService.Return(ctx, param)
+}
+
+func HandlerResultPublish(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	params := mux.Vars(r)
+
+	sessionID, err := uuid.FromString(params["id"])
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerResultPublish/parseID",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	var param api.ResultWorkflowParam
+
+	err = helpers.ParseBodyRequestData(ctx, r, &param)
+	if err != nil {
+
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerResultPublish/ParseBodyRequestData",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+
+	}
+
+	param.SessionID = sessionID
+
+	return resultService.Publish(ctx, param)
+}
+
+func HandlerResultHistory(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	params := mux.Vars(r)
+
+	sessionID, err := uuid.FromString(params["id"])
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerResultHistory/parseID",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	param := api.ResultWorkflowParam{SessionID: sessionID}
+
+	return resultService.History(ctx, param)
+}
+
+func HandlerResultHistoryByLecturer(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	params := mux.Vars(r)
+
+	sessionID, err := uuid.FromString(params["id"])
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerResultHistoryByLecturer/parseID",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	param := api.ResultWorkflowParam{SessionID: sessionID}
+
+	return resultService.HistoryByLecturer(ctx, param)
+}
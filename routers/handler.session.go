@@ -1,6 +1,7 @@
 package routers
 
 import (
+	"errors"
 	"github.com/gorilla/mux"
 	uuid "github.com/satori/go.uuid"
 	"net/http"
@@ -110,3 +111,79 @@ func HandlerSessionDelete(w http.ResponseWriter, r *http.Request) (interface{},
 
 	return sessionService.Delete(ctx, param)
 }
+
+func HandlerSessionListConflict(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	filter, err := helpers.ParseFilter(ctx, r)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerSessionListConflict/parseFilter",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	if filter.IntakeID == uuid.Nil {
+		return nil, helpers.ErrorWrap(errors.New("Intake Is Required"), "handler",
+			"HandlerSessionListConflict/IntakeID",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	return sessionService.ListConflict(ctx, filter)
+}
+
+func HandlerTimetableByLecturer(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	filter, err := helpers.ParseFilter(ctx, r)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerTimetableByLecturer/parseFilter",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	if filter.IntakeID == uuid.Nil {
+		return nil, helpers.ErrorWrap(errors.New("Intake Is Required"), "handler",
+			"HandlerTimetableByLecturer/IntakeID",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	param := api.TimetableParam{IntakeID: filter.IntakeID}
+
+	return sessionService.TimetableByLecturer(ctx, param)
+}
+
+func HandlerTimetableByStudent(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	filter, err := helpers.ParseFilter(ctx, r)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerTimetableByStudent/parseFilter",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	if filter.IntakeID == uuid.Nil {
+		return nil, helpers.ErrorWrap(errors.New("Intake Is Required"), "handler",
+			"HandlerTimetableByStudent/IntakeID",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	param := api.TimetableParam{IntakeID: filter.IntakeID}
+
+	return sessionService.TimetableByStudent(ctx, param)
+}
+
+func HandlerTeachingLoad(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	filter, err := helpers.ParseFilter(ctx, r)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerTeachingLoad/parseFilter",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	param := api.TeachingLoadParam{IntakeID: filter.IntakeID}
+
+	return sessionService.TeachingLoad(ctx, param)
+}
@@ -40,6 +40,21 @@ func HandlerStudentEnrollAdd(w http.ResponseWriter, r *http.Request) (interface{
 	return studentEnrollService.Add(ctx, param)
 }
 
+func HandlerStudentEnrollAddLate(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	var param api.StudentEnrollAddLateParam
+
+	err := helpers.ParseBodyRequestData(ctx, r, &param)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerStudentEnrollAddLate/ParseBodyRequestData",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	return studentEnrollService.AddLate(ctx, param)
+}
+
 func HandlerStudentEnrollListByOneStudent(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
 	ctx := r.Context()
 
@@ -120,3 +135,39 @@ func HandlerStudentEnrollDelete(w http.ResponseWriter, r *http.Request) (interfa
 
 	return studentEnrollService.Delete(ctx, param)
 }
+
+func HandlerStudentEnrollDrop(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	params := mux.Vars(r)
+
+	studentEnrollID, err := uuid.FromString(params["id"])
+
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerStudentEnrollDrop/parseID",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	param := api.StudentEnrollDropParam{ID: studentEnrollID}
+
+	return studentEnrollService.Drop(ctx, param)
+}
+
+func HandlerStudentEnrollWithdraw(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	params := mux.Vars(r)
+
+	studentEnrollID, err := uuid.FromString(params["id"])
+
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerStudentEnrollWithdraw/parseID",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	param := api.StudentEnrollWithdrawParam{ID: studentEnrollID}
+
+	return studentEnrollService.Withdraw(ctx, param)
+}
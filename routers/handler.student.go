@@ -151,3 +151,75 @@ func HandlerStudentPasswordUpdate(w http.ResponseWriter, r *http.Request) (inter
 
 	return studentService.PasswordUpdate(ctx, param)
 }
+
+func HandlerStudentStatusUpdate(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	params := mux.Vars(r)
+
+	studentID, err := uuid.FromString(params["id"])
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerStudentStatusUpdate/parseID",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	var param api.StudentStatusParam
+
+	err = helpers.ParseBodyRequestData(ctx, r, &param)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerStudentStatusUpdate/ParseBodyRequestData",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	param.ID = studentID
+
+	return studentService.UpdateStatus(ctx, param)
+}
+
+func HandlerStudentStatusHistory(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	params := mux.Vars(r)
+
+	studentID, err := uuid.FromString(params["id"])
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerStudentStatusHistory/parseID",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	param := api.StudentStatusHistoryParam{ID: studentID}
+
+	return studentService.StatusHistory(ctx, param)
+}
+
+func HandlerStudentImport(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	records, err := parseRecords(w, r)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerStudentImport/parseRecords",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	param := api.StudentImportParam{
+		DryRun:   r.FormValue("dry_run") == "true",
+		Delivery: r.FormValue("delivery"),
+		Records:  records,
+	}
+
+	return studentService.Import(ctx, param)
+}
+
+func HandlerStudentCredentials(w http.ResponseWriter, r *http.Request) (*helpers.File, *helpers.Error) {
+
+	ctx := r.Context()
+
+	params := mux.Vars(r)
+
+	param := api.StudentCredentialParam{Token: params["token"]}
+
+	return studentService.Credentials(ctx, param)
+}
@@ -0,0 +1,109 @@
+package routers
+
+import (
+	"github.com/gorilla/mux"
+	uuid "github.com/satori/go.uuid"
+	"net/http"
+	"school/api"
+	"school/helpers"
+)
+
+func HandlerSubjectPrerequisiteList(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	params := mux.Vars(r)
+
+	subjectID, err := uuid.FromString(params["id"])
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerSubjectPrerequisiteList/parseID",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	param := api.SubjectPrerequisiteListParam{SubjectID: subjectID}
+
+	return prerequisiteService.ListBySubject(ctx, param)
+}
+
+func HandlerSubjectPrerequisiteAdd(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	params := mux.Vars(r)
+
+	subjectID, err := uuid.FromString(params["id"])
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerSubjectPrerequisiteAdd/parseID",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	var param api.SubjectPrerequisiteAddParam
+
+	err = helpers.ParseBodyRequestData(ctx, r, &param)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerSubjectPrerequisiteAdd/ParseBodyRequestData",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	param.SubjectID = subjectID
+
+	return prerequisiteService.Add(ctx, param)
+}
+
+func HandlerSubjectPrerequisiteUpdate(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	params := mux.Vars(r)
+
+	subjectID, err := uuid.FromString(params["id"])
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerSubjectPrerequisiteUpdate/parseID",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	prerequisiteID, err := uuid.FromString(params["prerequisite_id"])
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerSubjectPrerequisiteUpdate/parsePrerequisiteID",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	var param api.SubjectPrerequisiteUpdateParam
+
+	err = helpers.ParseBodyRequestData(ctx, r, &param)
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerSubjectPrerequisiteUpdate/ParseBodyRequestData",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	param.ID = prerequisiteID
+	param.SubjectID = subjectID
+
+	return prerequisiteService.Update(ctx, param)
+}
+
+func HandlerSubjectPrerequisiteDelete(w http.ResponseWriter, r *http.Request) (interface{}, *helpers.Error) {
+
+	ctx := r.Context()
+
+	params := mux.Vars(r)
+
+	subjectID, err := uuid.FromString(params["id"])
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerSubjectPrerequisiteDelete/parseID",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	prerequisiteID, err := uuid.FromString(params["prerequisite_id"])
+	if err != nil {
+		return nil, helpers.ErrorWrap(err, "handler", "HandlerSubjectPrerequisiteDelete/parsePrerequisiteID",
+			helpers.BadRequestMessage, http.StatusBadRequest)
+	}
+
+	param := api.SubjectPrerequisiteDeleteParam{
+		ID:        prerequisiteID,
+		SubjectID: subjectID,
+	}
+
+	return prerequisiteService.Delete(ctx, param)
+}
@@ -2,6 +2,7 @@ package routers
 
 import (
 	"encoding/json"
+	"fmt"
 	"github.com/gorilla/mux"
 	"net/http"
 	"school/helpers"
@@ -10,7 +11,8 @@ import (
 )
 
 type (
-	HandlerFunc func(http.ResponseWriter, *http.Request) (interface{}, *helpers.Error)
+	HandlerFunc     func(http.ResponseWriter, *http.Request) (interface{}, *helpers.Error)
+	FileHandlerFunc func(http.ResponseWriter, *http.Request) (*helpers.File, *helpers.Error)
 )
 
 func (fn HandlerFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -33,6 +35,24 @@ func (fn HandlerFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func (fn FileHandlerFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	r.ParseForm()
+	file, err := fn(w, r)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(err.StatusCode)
+		json.NewEncoder(w).Encode(&helpers.Response{
+			BaseResponse: helpers.BaseResponse{
+				Errors: []string{err.Error()},
+			},
+		})
+		return
+	}
+	w.Header().Set("Content-Type", file.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, file.Name))
+	w.Write(file.Content)
+}
+
 func InitHandlers() *mux.Router {
 	r := mux.NewRouter()
 
@@ -50,16 +70,60 @@ func InitHandlers() *mux.Router {
 
 	apiV1.Handle("/student/student-enrolls", middleware.SessionMiddleware(middleware.RolesMiddleware(
 		HandlerFunc(HandlerStudentEnrollListByOneStudent), session.STUDENT_ROLE))).Methods(http.MethodGet)
+	apiV1.Handle("/student/student-enrolls/{id}", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerStudentEnrollDrop), session.STUDENT_ROLE))).Methods(http.MethodDelete)
+	apiV1.Handle("/student/student-enrolls/{id}/withdraw", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerStudentEnrollWithdraw), session.STUDENT_ROLE))).Methods(http.MethodPost)
 
 	//StudentResults
 	apiV1.Handle("/student/results", middleware.SessionMiddleware(middleware.RolesMiddleware(
 		HandlerFunc(HandlerResultListByOneStudent), session.STUDENT_ROLE))).Methods(http.MethodGet)
 
+	apiV1.Handle("/student/transcript", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerTranscriptByOneStudent), session.STUDENT_ROLE))).Methods(http.MethodGet)
+	apiV1.Handle("/student/degree-audit", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerDegreeAuditByOneStudent), session.STUDENT_ROLE))).Methods(http.MethodGet)
+	apiV1.Handle("/student/attendance-summary", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerAttendanceSummaryByOneStudent), session.STUDENT_ROLE))).Methods(http.MethodGet)
+	apiV1.Handle("/student/timetable", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerTimetableByStudent), session.STUDENT_ROLE))).Methods(http.MethodGet)
+	apiV1.Handle("/student/calendar-token", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerStudentCalendarTokenAdd), session.STUDENT_ROLE))).Methods(http.MethodPost)
+	apiV1.Handle("/student/calendar-token", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerStudentCalendarTokenDelete), session.STUDENT_ROLE))).Methods(http.MethodDelete)
+	apiV1.Handle("/student/notifications", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerStudentNotificationList), session.STUDENT_ROLE))).Methods(http.MethodGet)
+	apiV1.Handle("/student/notifications/unread-count", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerStudentNotificationUnreadCount), session.STUDENT_ROLE))).Methods(http.MethodGet)
+	apiV1.Handle("/student/notifications/read", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerStudentNotificationReadAll), session.STUDENT_ROLE))).Methods(http.MethodPut)
+	apiV1.Handle("/student/notifications/{id}/read", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerStudentNotificationRead), session.STUDENT_ROLE))).Methods(http.MethodPut)
+	apiV1.Handle("/student/classes/{id}/check-in", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerAttendanceCheckIn), session.STUDENT_ROLE))).Methods(http.MethodPost)
+
+	apiV1.Handle("/student/result-appeals", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerResultAppealListByOneStudent), session.STUDENT_ROLE))).Methods(http.MethodGet)
+	apiV1.Handle("/student/result-appeals", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerResultAppealAdd), session.STUDENT_ROLE))).Methods(http.MethodPost)
+
 	//LecturerUpdateAttendance
 	apiV1.Handle("/lecturer/attendances/{id}", middleware.SessionMiddleware(middleware.RolesMiddleware(
 		HandlerFunc(HandlerAttendanceUpdate), session.LECTURER_ROLE))).Methods(http.MethodPut)
 	apiV1.Handle("/lecturer/classes/{id}/attendances", middleware.SessionMiddleware(middleware.RolesMiddleware(
 		HandlerFunc(HandlerAttendanceListByClass), session.LECTURER_ROLE))).Methods(http.MethodGet)
+	apiV1.Handle("/lecturer/classes/{id}/attendances", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerAttendanceUpdateByClass), session.LECTURER_ROLE))).Methods(http.MethodPut)
+	apiV1.Handle("/lecturer/classes/{id}/check-in", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerAttendanceOpenCheckIn), session.LECTURER_ROLE))).Methods(http.MethodPost)
+	apiV1.Handle("/lecturer/classes/{id}/cancel", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerLecturerClassCancel), session.LECTURER_ROLE))).Methods(http.MethodPost)
+	apiV1.Handle("/lecturer/classes/{id}/reschedule", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerLecturerClassReschedule), session.LECTURER_ROLE))).Methods(http.MethodPost)
+	apiV1.Handle("/lecturer/classes/{id}/substitute", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerLecturerClassSubstitute), session.LECTURER_ROLE))).Methods(http.MethodPost)
+	apiV1.Handle("/lecturer/sessions/{id}/attendance-summary", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerAttendanceSummaryBySession), session.LECTURER_ROLE))).Methods(http.MethodGet)
 	apiV1.Handle("/lecturer/sessions/{id}/classes", middleware.SessionMiddleware(middleware.RolesMiddleware(
 		HandlerFunc(HandlerClassListBySession), session.LECTURER_ROLE))).Methods(http.MethodGet)
 	//LecturerUpdateResult
@@ -70,17 +134,69 @@ func InitHandlers() *mux.Router {
 	apiV1.Handle("/lecturer/sessions/{id}/student-enrolls", middleware.SessionMiddleware(middleware.RolesMiddleware(
 		HandlerFunc(HandlerStudentEnrollListBySession), session.LECTURER_ROLE))).Methods(http.MethodGet)
 
+	apiV1.Handle("/lecturer/sessions/{id}/results/import", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerResultImport), session.LECTURER_ROLE))).Methods(http.MethodPost)
+	apiV1.Handle("/lecturer/sessions/{id}/results/export", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		FileHandlerFunc(HandlerResultExport), session.LECTURER_ROLE))).Methods(http.MethodGet)
+	apiV1.Handle("/lecturer/sessions/{id}/results/submit", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerResultSubmit), session.LECTURER_ROLE))).Methods(http.MethodPost)
+	apiV1.Handle("/lecturer/sessions/{id}/results/history", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerResultHistoryByLecturer), session.LECTURER_ROLE))).Methods(http.MethodGet)
+
+	apiV1.Handle("/lecturer/result-appeals", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerResultAppealListByLecturer), session.LECTURER_ROLE))).Methods(http.MethodGet)
+	apiV1.Handle("/lecturer/result-appeals/{id}/review", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerResultAppealReviewByLecturer), session.LECTURER_ROLE))).Methods(http.MethodPut)
+
+	//LecturerAssessments
+	apiV1.Handle("/lecturer/sessions/{id}/assessments", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerAssessmentListBySession), session.LECTURER_ROLE))).Methods(http.MethodGet)
+	apiV1.Handle("/lecturer/sessions/{id}/assessments", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerAssessmentAdd), session.LECTURER_ROLE))).Methods(http.MethodPost)
+	apiV1.Handle("/lecturer/sessions/{id}/assessments/{assessment_id}", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerAssessmentUpdate), session.LECTURER_ROLE))).Methods(http.MethodPut)
+	apiV1.Handle("/lecturer/sessions/{id}/assessments/{assessment_id}", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerAssessmentDelete), session.LECTURER_ROLE))).Methods(http.MethodDelete)
+	apiV1.Handle("/lecturer/sessions/{id}/assessments/{assessment_id}/scores", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerAssessmentScoreList), session.LECTURER_ROLE))).Methods(http.MethodGet)
+	apiV1.Handle("/lecturer/sessions/{id}/assessments/{assessment_id}/scores", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerAssessmentScoreUpdate), session.LECTURER_ROLE))).Methods(http.MethodPut)
+
 	apiV1.Handle("/lecturer/sessions", middleware.SessionMiddleware(middleware.RolesMiddleware(
 		HandlerFunc(HandlerSessionListByLecturer), session.LECTURER_ROLE))).Methods(http.MethodGet)
+	apiV1.Handle("/lecturer/timetable", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerTimetableByLecturer), session.LECTURER_ROLE))).Methods(http.MethodGet)
+	apiV1.Handle("/lecturer/calendar-token", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerLecturerCalendarTokenAdd), session.LECTURER_ROLE))).Methods(http.MethodPost)
+	apiV1.Handle("/lecturer/calendar-token", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerLecturerCalendarTokenDelete), session.LECTURER_ROLE))).Methods(http.MethodDelete)
+	apiV1.Handle("/lecturer/notifications", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerLecturerNotificationList), session.LECTURER_ROLE))).Methods(http.MethodGet)
+	apiV1.Handle("/lecturer/notifications/unread-count", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerLecturerNotificationUnreadCount), session.LECTURER_ROLE))).Methods(http.MethodGet)
+	apiV1.Handle("/lecturer/notifications/read", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerLecturerNotificationReadAll), session.LECTURER_ROLE))).Methods(http.MethodPut)
+	apiV1.Handle("/lecturer/notifications/{id}/read", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerLecturerNotificationRead), session.LECTURER_ROLE))).Methods(http.MethodPut)
 
 	apiV1.Handle("/attendances", middleware.SessionMiddleware(middleware.RolesMiddleware(
 		HandlerFunc(HandlerAttendanceList), session.ADMIN_ROLE, session.LECTURER_ROLE))).Methods(http.MethodGet)
+	apiV1.Handle("/attendances/below-threshold", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerAttendanceListBelowThreshold), session.ADMIN_ROLE))).Methods(http.MethodGet)
 
 	apiV1.Handle("/classes", middleware.SessionMiddleware(middleware.RolesMiddleware(
 		HandlerFunc(HandlerClassAdd), session.ADMIN_ROLE))).Methods(http.MethodPost)
+	apiV1.Handle("/classes/{id}/cancel", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerClassCancel), session.ADMIN_ROLE))).Methods(http.MethodPost)
+	apiV1.Handle("/classes/{id}/reschedule", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerClassReschedule), session.ADMIN_ROLE))).Methods(http.MethodPost)
+	apiV1.Handle("/classes/{id}/substitute", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerClassSubstitute), session.ADMIN_ROLE))).Methods(http.MethodPost)
 
 	apiV1.Handle("/student-enrolls", middleware.SessionMiddleware(middleware.RolesMiddleware(
 		HandlerFunc(HandlerStudentEnrollAdd), session.STUDENT_ROLE))).Methods(http.MethodPost)
+	apiV1.Handle("/student-enrolls/late", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerStudentEnrollAddLate), session.ADMIN_ROLE))).Methods(http.MethodPost)
 	apiV1.Handle("/student-enrolls/{id}", middleware.SessionMiddleware(middleware.RolesMiddleware(
 		HandlerFunc(HandlerStudentEnrollDelete), session.ADMIN_ROLE))).Methods(http.MethodDelete)
 
@@ -97,12 +213,28 @@ func InitHandlers() *mux.Router {
 	apiV1.Handle("/students/{id}", middleware.SessionMiddleware(HandlerFunc(HandlerStudentDetail))).Methods(http.MethodGet)
 	apiV1.Handle("/students", middleware.SessionMiddleware(middleware.RolesMiddleware(
 		HandlerFunc(HandlerStudentAdd), session.ADMIN_ROLE))).Methods(http.MethodPost)
+	apiV1.Handle("/students/import", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerStudentImport), session.ADMIN_ROLE))).Methods(http.MethodPost)
+	apiV1.Handle("/students/credentials/{token}", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		FileHandlerFunc(HandlerStudentCredentials), session.ADMIN_ROLE))).Methods(http.MethodGet)
+	apiV1.Handle("/students/{id}/transcript", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerTranscriptDetail), session.ADMIN_ROLE))).Methods(http.MethodGet)
+	apiV1.Handle("/students/{id}/degree-audit", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerDegreeAudit), session.ADMIN_ROLE))).Methods(http.MethodGet)
+	apiV1.Handle("/students/{id}/status", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerStudentStatusUpdate), session.ADMIN_ROLE))).Methods(http.MethodPost)
+	apiV1.Handle("/students/{id}/status-history", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerStudentStatusHistory), session.ADMIN_ROLE))).Methods(http.MethodGet)
 	apiV1.Handle("/students/{id}", middleware.SessionMiddleware(middleware.RolesMiddleware(
 		HandlerFunc(HandlerStudentUpdate), session.ADMIN_ROLE))).Methods(http.MethodPut)
 	apiV1.Handle("/students/{id}", middleware.SessionMiddleware(middleware.RolesMiddleware(
 		HandlerFunc(HandlerStudentDelete), session.ADMIN_ROLE))).Methods(http.MethodDelete)
 
 	apiV1.Handle("/sessions", middleware.SessionMiddleware(HandlerFunc(HandlerSessionList))).Methods(http.MethodGet)
+	apiV1.Handle("/sessions/conflicts", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerSessionListConflict), session.ADMIN_ROLE))).Methods(http.MethodGet)
+	apiV1.Handle("/sessions/teaching-load", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerTeachingLoad), session.ADMIN_ROLE))).Methods(http.MethodGet)
 	apiV1.Handle("/sessions/{id}", middleware.SessionMiddleware(HandlerFunc(HandlerSessionDetail))).Methods(http.MethodGet)
 	apiV1.Handle("/sessions", middleware.SessionMiddleware(middleware.RolesMiddleware(
 		HandlerFunc(HandlerSessionAdd), session.ADMIN_ROLE))).Methods(http.MethodPost)
@@ -110,9 +242,24 @@ func InitHandlers() *mux.Router {
 		HandlerFunc(HandlerSessionUpdate), session.ADMIN_ROLE))).Methods(http.MethodPut)
 	apiV1.Handle("/sessions/{id}", middleware.SessionMiddleware(middleware.RolesMiddleware(
 		HandlerFunc(HandlerSessionDelete), session.ADMIN_ROLE))).Methods(http.MethodDelete)
+	apiV1.Handle("/sessions/{id}/results/approve", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerResultApprove), session.ADMIN_ROLE))).Methods(http.MethodPost)
+	apiV1.Handle("/sessions/{id}/results/return", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerResultReturn), session.ADMIN_ROLE))).Methods(http.MethodPost)
+	apiV1.Handle("/sessions/{id}/results/publish", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerResultPublish), session.ADMIN_ROLE))).Methods(http.MethodPost)
+	apiV1.Handle("/sessions/{id}/results/history", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerResultHistory), session.ADMIN_ROLE))).Methods(http.MethodGet)
+
+	apiV1.Handle("/result-appeals", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerResultAppealList), session.ADMIN_ROLE))).Methods(http.MethodGet)
+	apiV1.Handle("/result-appeals/{id}/review", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerResultAppealReview), session.ADMIN_ROLE))).Methods(http.MethodPut)
 
-	apiV1.Handle("/results", middleware.SessionMiddleware(HandlerFunc(HandlerResultList))).Methods(http.MethodGet)
-	apiV1.Handle("/results/{id}", middleware.SessionMiddleware(HandlerFunc(HandlerResultDetail))).Methods(http.MethodGet)
+	apiV1.Handle("/results", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerResultList), session.ADMIN_ROLE, session.LECTURER_ROLE))).Methods(http.MethodGet)
+	apiV1.Handle("/results/{id}", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerResultDetail), session.ADMIN_ROLE, session.LECTURER_ROLE))).Methods(http.MethodGet)
 	apiV1.Handle("/results/{id}", middleware.SessionMiddleware(middleware.RolesMiddleware(
 		HandlerFunc(HandlerResultUpdate), session.ADMIN_ROLE))).Methods(http.MethodPut)
 	apiV1.Handle("/results/{id}", middleware.SessionMiddleware(middleware.RolesMiddleware(
@@ -127,6 +274,15 @@ func InitHandlers() *mux.Router {
 	apiV1.Handle("/programs/{id}", middleware.SessionMiddleware(middleware.RolesMiddleware(
 		HandlerFunc(HandlerProgramDelete), session.ADMIN_ROLE))).Methods(http.MethodDelete)
 
+	apiV1.Handle("/programs/{id}/curriculum", middleware.SessionMiddleware(
+		HandlerFunc(HandlerCurriculumList))).Methods(http.MethodGet)
+	apiV1.Handle("/programs/{id}/curriculum", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerCurriculumAdd), session.ADMIN_ROLE))).Methods(http.MethodPost)
+	apiV1.Handle("/programs/{id}/curriculum/{curriculum_id}", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerCurriculumUpdate), session.ADMIN_ROLE))).Methods(http.MethodPut)
+	apiV1.Handle("/programs/{id}/curriculum/{curriculum_id}", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerCurriculumDelete), session.ADMIN_ROLE))).Methods(http.MethodDelete)
+
 	apiV1.Handle("/intakes", middleware.SessionMiddleware(HandlerFunc(HandlerIntakeList))).Methods(http.MethodGet)
 	apiV1.Handle("/intakes/{id}", middleware.SessionMiddleware(HandlerFunc(HandlerIntakeDetail))).Methods(http.MethodGet)
 	apiV1.Handle("/intakes", middleware.SessionMiddleware(middleware.RolesMiddleware(
@@ -135,6 +291,29 @@ func InitHandlers() *mux.Router {
 		HandlerFunc(HandlerIntakeUpdate), session.ADMIN_ROLE))).Methods(http.MethodPut)
 	apiV1.Handle("/intakes/{id}", middleware.SessionMiddleware(middleware.RolesMiddleware(
 		HandlerFunc(HandlerIntakeDelete), session.ADMIN_ROLE))).Methods(http.MethodDelete)
+	apiV1.Handle("/intakes/{id}/classes/generate", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerClassGenerate), session.ADMIN_ROLE))).Methods(http.MethodPost)
+	apiV1.Handle("/intakes/{id}/academic-periods", middleware.SessionMiddleware(
+		HandlerFunc(HandlerAcademicPeriodListByIntake))).Methods(http.MethodGet)
+	apiV1.Handle("/intakes/{id}/academic-periods", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerAcademicPeriodAdd), session.ADMIN_ROLE))).Methods(http.MethodPost)
+	apiV1.Handle("/academic-periods/{id}", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerAcademicPeriodUpdate), session.ADMIN_ROLE))).Methods(http.MethodPut)
+	apiV1.Handle("/academic-periods/{id}", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerAcademicPeriodDelete), session.ADMIN_ROLE))).Methods(http.MethodDelete)
+
+	apiV1.Handle("/intakes/{id}/graduation-eligibility", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerGraduationEligibility), session.ADMIN_ROLE))).Methods(http.MethodGet)
+	apiV1.Handle("/intakes/{id}/graduations", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerGraduationConfirm), session.ADMIN_ROLE))).Methods(http.MethodPost)
+	apiV1.Handle("/graduations", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerGraduationList), session.ADMIN_ROLE))).Methods(http.MethodGet)
+
+	apiV1.Handle("/holidays", middleware.SessionMiddleware(HandlerFunc(HandlerHolidayList))).Methods(http.MethodGet)
+	apiV1.Handle("/holidays", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerHolidayAdd), session.ADMIN_ROLE))).Methods(http.MethodPost)
+	apiV1.Handle("/holidays/{id}", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerHolidayDelete), session.ADMIN_ROLE))).Methods(http.MethodDelete)
 
 	apiV1.Handle("/subjects", middleware.SessionMiddleware(HandlerFunc(HandlerSubjectList))).Methods(http.MethodGet)
 	apiV1.Handle("/subjects/{id}", middleware.SessionMiddleware(HandlerFunc(HandlerSubjectDetail))).Methods(http.MethodGet)
@@ -145,6 +324,32 @@ func InitHandlers() *mux.Router {
 	apiV1.Handle("/subjects/{id}", middleware.SessionMiddleware(middleware.RolesMiddleware(
 		HandlerFunc(HandlerSubjectDelete), session.ADMIN_ROLE))).Methods(http.MethodDelete)
 
+	apiV1.Handle("/subjects/{id}/prerequisites", middleware.SessionMiddleware(
+		HandlerFunc(HandlerSubjectPrerequisiteList))).Methods(http.MethodGet)
+	apiV1.Handle("/subjects/{id}/prerequisites", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerSubjectPrerequisiteAdd), session.ADMIN_ROLE))).Methods(http.MethodPost)
+	apiV1.Handle("/subjects/{id}/prerequisites/{prerequisite_id}", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerSubjectPrerequisiteUpdate), session.ADMIN_ROLE))).Methods(http.MethodPut)
+	apiV1.Handle("/subjects/{id}/prerequisites/{prerequisite_id}", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerSubjectPrerequisiteDelete), session.ADMIN_ROLE))).Methods(http.MethodDelete)
+
+	apiV1.Handle("/grading-schemes", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerGradingSchemeList), session.ADMIN_ROLE))).Methods(http.MethodGet)
+	apiV1.Handle("/grading-schemes/{id}", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerGradingSchemeDetail), session.ADMIN_ROLE))).Methods(http.MethodGet)
+	apiV1.Handle("/grading-schemes", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerGradingSchemeAdd), session.ADMIN_ROLE))).Methods(http.MethodPost)
+	apiV1.Handle("/grading-schemes/{id}", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerGradingSchemeUpdate), session.ADMIN_ROLE))).Methods(http.MethodPut)
+	apiV1.Handle("/grading-schemes/{id}", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerGradingSchemeDelete), session.ADMIN_ROLE))).Methods(http.MethodDelete)
+	apiV1.Handle("/grading-schemes/{id}/regrade", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerGradingSchemeRegrade), session.ADMIN_ROLE))).Methods(http.MethodPost)
+
+	apiV1.Handle("/classrooms/free", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerClassroomFree), session.ADMIN_ROLE, session.LECTURER_ROLE))).Methods(http.MethodGet)
+	apiV1.Handle("/classrooms/utilization", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerClassroomUtilization), session.ADMIN_ROLE))).Methods(http.MethodGet)
 	apiV1.Handle("/classrooms/{id}", middleware.SessionMiddleware(HandlerFunc(HandlerClassroomDetail))).Methods(http.MethodGet)
 	apiV1.Handle("/classrooms", middleware.SessionMiddleware(middleware.RolesMiddleware(
 		HandlerFunc(HandlerClassroomAdd), session.ADMIN_ROLE))).Methods(http.MethodPost)
@@ -152,6 +357,12 @@ func InitHandlers() *mux.Router {
 		HandlerFunc(HandlerClassroomUpdate), session.ADMIN_ROLE))).Methods(http.MethodPut)
 	apiV1.Handle("/classrooms/{id}", middleware.SessionMiddleware(middleware.RolesMiddleware(
 		HandlerFunc(HandlerClassroomDelete), session.ADMIN_ROLE))).Methods(http.MethodDelete)
+	apiV1.Handle("/classrooms/{id}/bookings", middleware.SessionMiddleware(
+		HandlerFunc(HandlerClassroomBookingList))).Methods(http.MethodGet)
+	apiV1.Handle("/classrooms/{id}/bookings", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerClassroomBookingAdd), session.ADMIN_ROLE))).Methods(http.MethodPost)
+	apiV1.Handle("/classroom-bookings/{id}", middleware.SessionMiddleware(middleware.RolesMiddleware(
+		HandlerFunc(HandlerClassroomBookingDelete), session.ADMIN_ROLE))).Methods(http.MethodDelete)
 
 	apiV1.Handle("/faculties", middleware.SessionMiddleware(HandlerFunc(HandlerFacultyList))).Methods(http.MethodGet)
 	apiV1.Handle("/faculties/{id}", middleware.SessionMiddleware(HandlerFunc(HandlerFacultyDetail))).Methods(http.MethodGet)
@@ -166,5 +377,9 @@ func InitHandlers() *mux.Router {
 	apiV1.Handle("/admin/login", HandlerFunc(HandlerAdminLogin)).Methods(http.MethodPost)
 	apiV1.Handle("/student/login", HandlerFunc(HandlerStudentLogin)).Methods(http.MethodPost)
 
+	//Calendar feeds are authenticated by their token, calendar clients cannot send the session header
+	apiV1.Handle("/student/timetable.ics", FileHandlerFunc(HandlerStudentCalendarFeed)).Methods(http.MethodGet)
+	apiV1.Handle("/lecturer/timetable.ics", FileHandlerFunc(HandlerLecturerCalendarFeed)).Methods(http.MethodGet)
+
 	return r
 }
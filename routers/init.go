@@ -8,22 +8,33 @@ import (
 )
 
 var (
-	dbPool               *sql.DB
-	cachePool            *redis.Pool
-	logger               *helpers.Logger
-	studentService       *api.StudentModule
-	lecturerService      *api.LecturerModule
-	subjectService       *api.SubjectModule
-	intakeService        *api.IntakeModule
-	studentEnrollService *api.StudentEnrollModule
-	attendanceService    *api.AttendanceModule
-	classroomService     *api.ClassroomModule
-	facultyService       *api.FacultyModule
-	programService       *api.ProgramModule
-	resultService        *api.ResultModule
-	sessionService       *api.SessionModule
-	adminService         *api.AdminModule
-	classService         *api.ClassModule
+	dbPool                *sql.DB
+	cachePool             *redis.Pool
+	logger                *helpers.Logger
+	studentService        *api.StudentModule
+	lecturerService       *api.LecturerModule
+	subjectService        *api.SubjectModule
+	intakeService         *api.IntakeModule
+	studentEnrollService  *api.StudentEnrollModule
+	attendanceService     *api.AttendanceModule
+	classroomService      *api.ClassroomModule
+	facultyService        *api.FacultyModule
+	programService        *api.ProgramModule
+	resultService         *api.ResultModule
+	sessionService        *api.SessionModule
+	adminService          *api.AdminModule
+	classService          *api.ClassModule
+	prerequisiteService   *api.SubjectPrerequisiteModule
+	transcriptService     *api.TranscriptModule
+	gradingSchemeService  *api.GradingSchemeModule
+	assessmentService     *api.AssessmentModule
+	resultAppealService   *api.ResultAppealModule
+	holidayService        *api.HolidayModule
+	academicPeriodService *api.AcademicPeriodModule
+	curriculumService     *api.CurriculumModule
+	graduationService     *api.GraduationModule
+	calendarService       *api.CalendarModule
+	notificationService   *api.NotificationModule
 )
 
 func Init(db *sql.DB, cache *redis.Pool, log *helpers.Logger) {
@@ -43,4 +54,15 @@ func Init(db *sql.DB, cache *redis.Pool, log *helpers.Logger) {
 	sessionService = api.NewSessionModule(dbPool, cachePool, logger)
 	adminService = api.NewAdminModule(dbPool, cachePool, logger)
 	classService = api.NewClassModule(dbPool, cachePool, logger)
+	prerequisiteService = api.NewSubjectPrerequisiteModule(dbPool, cachePool, logger)
+	transcriptService = api.NewTranscriptModule(dbPool, cachePool, logger)
+	gradingSchemeService = api.NewGradingSchemeModule(dbPool, cachePool, logger)
+	assessmentService = api.NewAssessmentModule(dbPool, cachePool, logger)
+	resultAppealService = api.NewResultAppealModule(dbPool, cachePool, logger)
+	holidayService = api.NewHolidayModule(dbPool, cachePool, logger)
+	academicPeriodService = api.NewAcademicPeriodModule(dbPool, cachePool, logger)
+	curriculumService = api.NewCurriculumModule(dbPool, cachePool, logger)
+	graduationService = api.NewGraduationModule(dbPool, cachePool, logger)
+	calendarService = api.NewCalendarModule(dbPool, cachePool, logger)
+	notificationService = api.NewNotificationModule(dbPool, cachePool, logger)
 }
@@ -0,0 +1,52 @@
+package routers
+
+import (
+	"encoding/csv"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"school/helpers"
+	"strings"
+)
+
+// RECORDS_MAX_BYTES is the largest upload parseRecords accepts, so an XLSX file is never read into memory
+// without a bound.
+const RECORDS_MAX_BYTES = 10 << 20
+
+// parseRecords reads an uploaded CSV or XLSX file, either as the "file" field of a multipart form or as the
+// raw body. XLSX is recognised by the file name or the content type.
+func parseRecords(w http.ResponseWriter, r *http.Request) ([][]string, error) {
+
+	r.Body = http.MaxBytesReader(w, r.Body, RECORDS_MAX_BYTES)
+
+	var body io.Reader = r.Body
+	contentType := r.Header.Get("Content-Type")
+	isXLSX := strings.Contains(contentType, "spreadsheetml")
+
+	if strings.HasPrefix(contentType, "multipart/form-data") {
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+
+		body = file
+		isXLSX = strings.HasSuffix(strings.ToLower(header.Filename), ".xlsx") ||
+			strings.Contains(header.Header.Get("Content-Type"), "spreadsheetml")
+	}
+
+	if isXLSX {
+		content, err := ioutil.ReadAll(body)
+		if err != nil {
+			return nil, err
+		}
+
+		return helpers.ReadXLSX(content)
+	}
+
+	reader := csv.NewReader(body)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	return reader.ReadAll()
+}
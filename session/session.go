@@ -70,3 +70,31 @@ func (s Session) Get(ctx context.Context) (SessionData, error) {
 	return sessionData, nil
 
 }
+
+// DeleteUserSession signs the user out of every session held in that role, so a change to the user takes
+// effect before their sessions expire.
+func DeleteUserSession(ctx context.Context, userID uuid.UUID, role string) error {
+
+	keys, err := helpers.GetKeysFromCacheWithPrefix(ctx, USER_SESSION+":")
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		sessionData, err := Session{SessionKey: key}.Get(ctx)
+		if err != nil {
+			continue
+		}
+
+		if sessionData.UserID != userID || sessionData.Role != role {
+			continue
+		}
+
+		err = helpers.DeleteCache(ctx, key)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -1,23 +1,62 @@
 package util
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
+	"fmt"
 	"math"
-	"math/rand"
+	"math/big"
+	mathrand "math/rand"
+	"regexp"
 	"strconv"
 	"time"
 )
 
-func RandomString(n int) string {
-	var letters = []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789")
+var studentCodeToken = regexp.MustCompile(`\{(YYYY|YY|PROGRAM|FACULTY|SEQ)(?::(\d+))?\}`)
+
+var letters = []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789")
 
+func RandomString(n int) string {
 	s := make([]rune, n)
 	for i := range s {
-		s[i] = letters[rand.Intn(len(letters))]
+		s[i] = letters[mathrand.Intn(len(letters))]
 	}
 	return string(s)
 }
 
+// RandomPassword returns n letters and digits drawn from crypto/rand, for initial account passwords.
+func RandomPassword(n int) (string, error) {
+	s := make([]rune, n)
+	for i := range s {
+		index, err := rand.Int(rand.Reader, big.NewInt(int64(len(letters))))
+		if err != nil {
+			return "", err
+		}
+		s[i] = letters[index.Int64()]
+	}
+	return string(s), nil
+}
+
+// RandomToken returns 32 bytes from crypto/rand as unpadded base64url, for tokens that grant access on their
+// own such as calendar feeds and credential downloads.
+func RandomToken() (string, error) {
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// HashToken returns the hex SHA-256 of a token, the form in which a long lived token is stored.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
 func GetGender(gender int) (string, error) {
 	switch gender {
 	case 0:
@@ -85,17 +124,124 @@ func GetDay(day int) string {
 	}
 }
 
-func GetTrimester(month int) int {
+func GetGradeRank(grade string) int {
 
-	switch month {
-	case 4:
-		return 1
-	case 7:
-		return 2
-	case 11:
+	switch grade {
+	case "A":
+		return 4
+	case "B":
 		return 3
+	case "C":
+		return 2
+	case "D":
+		return 1
 	default:
 		return 0
 	}
+}
+
+func HasPath(graph map[string][]string, from, to string) bool {
+
+	visited := make(map[string]bool)
+	stack := []string{from}
+
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if node == to {
+			return true
+		}
 
+		if visited[node] {
+			continue
+		}
+		visited[node] = true
+
+		stack = append(stack, graph[node]...)
+	}
+
+	return false
+}
+
+func GetGradePoint(grade string) float64 {
+
+	switch grade {
+	case "A":
+		return 4.0
+	case "B":
+		return 3.0
+	case "C":
+		return 2.0
+	case "D":
+		return 1.0
+	default:
+		return 0
+	}
+}
+
+func GetClassification(cgpa float64) string {
+
+	if cgpa >= 3.67 {
+		return "First Class"
+	} else if cgpa >= 3.0 {
+		return "Second Class Upper"
+	} else if cgpa >= 2.5 {
+		return "Second Class Lower"
+	} else if cgpa >= 2.0 {
+		return "Third Class"
+	} else {
+		return "Pass"
+	}
+}
+
+func GetAcademicStanding(cgpa, probationCGPA, dismissalCGPA float64) string {
+
+	if cgpa >= probationCGPA {
+		return "Good Standing"
+	} else if cgpa >= dismissalCGPA {
+		return "Probation"
+	} else {
+		return "Dismissal"
+	}
+}
+
+// FormatStudentCode fills the {YYYY}, {YY}, {PROGRAM}, {FACULTY} and {SEQ} tokens of the format. A token
+// may carry a width such as {SEQ:4} to be padded with zeros. The format must contain {SEQ} so that codes
+// in the same program and year differ.
+func FormatStudentCode(format string, year, programCode, facultyCode, sequence int) (string, error) {
+
+	hasSequence := false
+
+	code := studentCodeToken.ReplaceAllStringFunc(format, func(token string) string {
+		match := studentCodeToken.FindStringSubmatch(token)
+
+		var value int
+		switch match[1] {
+		case "YYYY":
+			value = year
+		case "YY":
+			value = year % 100
+		case "PROGRAM":
+			value = programCode
+		case "FACULTY":
+			value = facultyCode
+		case "SEQ":
+			hasSequence = true
+			value = sequence
+		}
+
+		width, _ := strconv.Atoi(match[2])
+		if match[1] == "YY" && width == 0 {
+			width = 2
+		}
+
+		return fmt.Sprintf("%0*d", width, value)
+	})
+
+	if !hasSequence {
+		return "", errors.New("Student Code Format Must Contain {SEQ}")
+	}
+
+	return code, nil
 }
@@ -3,6 +3,7 @@ package util
 import (
 	"fmt"
 	"log"
+	"strings"
 	"testing"
 )
 
@@ -17,3 +18,105 @@ func TestGetYearCode(t *testing.T) {
 	fmt.Println(code)
 
 }
+
+func TestHasPath(t *testing.T) {
+
+	graph := map[string][]string{
+		"database2": {"database1"},
+		"database1": {"programming1"},
+	}
+
+	if !HasPath(graph, "database2", "programming1") {
+		t.Fatal("expected path from database2 to programming1")
+	}
+
+	if HasPath(graph, "programming1", "database2") {
+		t.Fatal("unexpected path from programming1 to database2")
+	}
+
+}
+
+func TestGetClassification(t *testing.T) {
+
+	cases := map[float64]string{
+		4.0:  "First Class",
+		3.67: "First Class",
+		3.2:  "Second Class Upper",
+		2.5:  "Second Class Lower",
+		2.1:  "Third Class",
+		1.8:  "Pass",
+	}
+
+	for cgpa, classification := range cases {
+		if got := GetClassification(cgpa); got != classification {
+			t.Fatalf("GetClassification(%v) = %s, expected %s", cgpa, got, classification)
+		}
+	}
+
+}
+
+func TestFormatStudentCode(t *testing.T) {
+
+	code, err := FormatStudentCode("1{YY}{PROGRAM}0{FACULTY}{SEQ:3}", 2026, 12, 3, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if code != "1261203007" {
+		t.Fatalf("FormatStudentCode = %s, expected 1261203007", code)
+	}
+
+	_, err = FormatStudentCode("{YYYY}{PROGRAM}", 2026, 12, 3, 7)
+	if err == nil {
+		t.Fatal("expected error for format without {SEQ}")
+	}
+
+}
+
+func TestRandomPassword(t *testing.T) {
+
+	password, err := RandomPassword(12)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(password) != 12 || strings.Trim(password, string(letters)) != "" {
+		t.Fatalf("RandomPassword(12) = %s, expected 12 letters and digits", password)
+	}
+
+	other, err := RandomPassword(12)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if password == other {
+		t.Fatal("RandomPassword() returned the same password twice")
+	}
+
+}
+
+func TestRandomToken(t *testing.T) {
+
+	token, err := RandomToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(token) != 43 || strings.ContainsAny(token, "+/=") {
+		t.Fatalf("RandomToken() = %s, expected 43 base64url characters", token)
+	}
+
+	other, err := RandomToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if token == other {
+		t.Fatal("RandomToken() returned the same token twice")
+	}
+
+	if HashToken(token) != HashToken(token) || HashToken(token) == HashToken(other) {
+		t.Fatal("HashToken() is not stable per token")
+	}
+
+}